@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/userctl"
+	pbv2 "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// envOr returns the value of an environment variable, or fallback when it is unset.
+func envOr(name string, fallback string) string {
+	if value, ok := os.LookupEnv(name); ok {
+		return value
+	}
+	return fallback
+}
+
+func main() {
+	addr := flag.String("addr", envOr("USERCTL_ADDR", "localhost:3001"), "address of the gRPC server, $USERCTL_ADDR")
+	token := flag.String("token", os.Getenv("USERCTL_TOKEN"), "JWT sent as the bearer token, $USERCTL_TOKEN")
+	tenant := flag.String("tenant", "", "tenant to operate on, or * for all tenants")
+	output := flag.String("o", userctl.OutputTable, "output format, table or json")
+	useTLS := flag.Bool("tls", false, "connect with TLS")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of the command")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: userctl [flags] <command> [arguments]\n\n%s\nFlags:\n", userctl.Usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	printer, err := userctl.NewPrinter(*output, os.Stdout)
+	if err != nil {
+		fail(err)
+	}
+
+	transportCredentials := insecure.NewCredentials()
+	if *useTLS {
+		transportCredentials = credentials.NewTLS(&tls.Config{})
+	}
+	conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		fail(err)
+	}
+
+	ctx, cancel := context.WithTimeout(userctl.WithCredentials(context.Background(), *token, *tenant), *timeout)
+
+	cli := userctl.NewCLI(pbv2.NewUserServiceClient(conn), printer, os.Stderr)
+	err = cli.Run(ctx, flag.Args())
+	cancel()
+	conn.Close()
+	if err != nil {
+		fail(err)
+	}
+}
+
+// fail reports an error and exits, with status 2 for usage errors as the flag package does.
+func fail(err error) {
+	if errors.Is(err, userctl.ErrUsage) {
+		fmt.Fprintln(os.Stderr, "userctl:", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	fmt.Fprintln(os.Stderr, "userctl:", err)
+	os.Exit(1)
+}
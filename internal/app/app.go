@@ -1,21 +1,28 @@
 package app
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"os"
 
 	fiberserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/fiber"
 	grpcserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/grpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 type App struct {
 	FiberServer *fiberserver.UserFiberServer
 	GRPCServer  *grpcserver.UserGrpcServer
+	Logger      *slog.Logger
+	// TracerProvider is shut down before exiting, to flush the last spans, unless it is nil
+	TracerProvider *sdktrace.TracerProvider
 }
 
-func NewApp(fiberServer *fiberserver.UserFiberServer, gRPCServer *grpcserver.UserGrpcServer) *App {
+func NewApp(fiberServer *fiberserver.UserFiberServer, gRPCServer *grpcserver.UserGrpcServer, logger *slog.Logger) *App {
 	return &App{
 		FiberServer: fiberServer,
 		GRPCServer:  gRPCServer,
+		Logger:      logger,
 	}
 }
 
@@ -26,21 +33,27 @@ func (app *App) Run(httpPort string, gRPCPort string) {
 
 	// Run Fiber server
 	go func() {
-		log.Println("Starting Fiber server on port", httpPort)
+		app.Logger.Info("Starting Fiber server", "port", httpPort)
 		httpErrChan <- app.FiberServer.Run(httpPort)
 	}()
 
 	// Run gRPC server
 	go func() {
-		log.Println("Starting gRPC server on port", gRPCPort)
+		app.Logger.Info("Starting gRPC server", "port", gRPCPort)
 		grpcErrChan <- app.GRPCServer.Run(gRPCPort)
 	}()
 
 	// Wait for errors from the servers
 	select {
 	case err := <-httpErrChan:
-		log.Fatal("Fiber server error: ", err)
+		app.Logger.Error("Fiber server error", "error", err)
 	case err := <-grpcErrChan:
-		log.Fatal("gRPC server error: ", err)
+		app.Logger.Error("gRPC server error", "error", err)
 	}
+	if app.TracerProvider != nil {
+		if err := app.TracerProvider.Shutdown(context.Background()); err != nil {
+			app.Logger.Error("Failed to flush spans", "error", err)
+		}
+	}
+	os.Exit(1)
 }
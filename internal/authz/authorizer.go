@@ -0,0 +1,69 @@
+package authz
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+)
+
+// ServiceAccountUserID is the user_id carried by tokens issued to other BitBridge services.
+const ServiceAccountUserID = "-1"
+
+var (
+	// ErrUnauthenticated is returned when a request carries no caller.
+	ErrUnauthenticated = errors.New("authentication required")
+	// ErrForbidden is returned when the caller lacks the required permission.
+	ErrForbidden = errors.New("permission denied")
+)
+
+// IRoleResolver looks up the roles held by a user.
+type IRoleResolver interface {
+	Roles(ctx context.Context, userID string) ([]string, error)
+}
+
+// IAuthorizer decides whether a caller meets a requirement and returns the scope of the grant.
+type IAuthorizer interface {
+	Authorize(ctx context.Context, userID string, requirement Requirement) (Scope, error)
+}
+
+type Authorizer struct {
+	Policy *Policy
+	Roles  IRoleResolver
+}
+
+// NewAuthorizer creates a new instance of Authorizer.
+func NewAuthorizer(policy *Policy, roles IRoleResolver) *Authorizer {
+	return &Authorizer{
+		Policy: policy,
+		Roles:  roles,
+	}
+}
+
+// Authorize implements IAuthorizer.
+func (a *Authorizer) Authorize(ctx context.Context, userID string, requirement Requirement) (Scope, error) {
+	if userID == "" {
+		return Scope{}, ErrUnauthenticated
+	}
+
+	roles, err := a.Roles.Roles(ctx, userID)
+	if err != nil {
+		return Scope{}, err
+	}
+
+	if tenant.IsCrossing(ctx) && !a.Policy.Allows(roles, PermissionCrossTenant) {
+		return Scope{}, ErrForbidden
+	}
+
+	if a.Policy.Allows(roles, requirement.Any) {
+		return Scope{UserID: userID}, nil
+	}
+	if requirement.Self != "" && a.Policy.Allows(roles, requirement.Self) {
+		return Scope{UserID: userID, SelfOnly: true}, nil
+	}
+
+	return Scope{}, ErrForbidden
+}
+
+// Ensure Authorizer implements IAuthorizer
+var _ IAuthorizer = &Authorizer{}
@@ -0,0 +1,142 @@
+package authz_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIRoleResolver struct {
+	mock.Mock
+}
+
+func (m *MockIRoleResolver) Roles(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestPolicy_Allows(t *testing.T) {
+	policy := authz.DefaultPolicy()
+
+	assert.True(t, policy.Allows([]string{authz.RoleUser, authz.RoleSupport}, authz.PermissionReadAudit))
+	assert.True(t, policy.Allows([]string{authz.RoleService}, authz.PermissionCreate))
+	assert.False(t, policy.Allows([]string{authz.RoleUser}, authz.PermissionReadPrivate))
+	assert.True(t, policy.Allows([]string{authz.RoleSupport}, authz.PermissionReflect))
+	assert.False(t, policy.Allows([]string{authz.RoleUser, authz.RoleService}, authz.PermissionReflect))
+	assert.False(t, policy.Allows([]string{"unknown"}, authz.PermissionReadSelf))
+	assert.False(t, policy.Allows(nil, authz.PermissionReadSelf))
+}
+
+func TestAuthorize_AnyPermission(t *testing.T) {
+	// Arrange
+	mockRoles := new(MockIRoleResolver)
+	authorizer := authz.NewAuthorizer(authz.DefaultPolicy(), mockRoles)
+	ctx := context.Background()
+	mockRoles.On("Roles", ctx, "1").Return([]string{authz.RoleUser, authz.RoleAdmin}, nil)
+
+	// Act
+	scope, err := authorizer.Authorize(ctx, "1", authz.Requirement{Any: authz.PermissionReadPrivate, Self: authz.PermissionReadSelf})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, authz.Scope{UserID: "1"}, scope)
+}
+
+func TestAuthorize_SelfOnly(t *testing.T) {
+	// Arrange
+	mockRoles := new(MockIRoleResolver)
+	authorizer := authz.NewAuthorizer(authz.DefaultPolicy(), mockRoles)
+	ctx := context.Background()
+	mockRoles.On("Roles", ctx, "1").Return([]string{authz.RoleUser}, nil)
+
+	// Act
+	scope, err := authorizer.Authorize(ctx, "1", authz.Requirement{Any: authz.PermissionReadPrivate, Self: authz.PermissionReadSelf})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, authz.Scope{UserID: "1", SelfOnly: true}, scope)
+}
+
+func TestAuthorize_Forbidden(t *testing.T) {
+	// Arrange
+	mockRoles := new(MockIRoleResolver)
+	authorizer := authz.NewAuthorizer(authz.DefaultPolicy(), mockRoles)
+	ctx := context.Background()
+	mockRoles.On("Roles", ctx, "1").Return([]string{authz.RoleUser}, nil)
+
+	// Act
+	_, err := authorizer.Authorize(ctx, "1", authz.Requirement{Any: authz.PermissionReadAudit})
+
+	// Assert
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+}
+
+func TestAuthorize_Unauthenticated(t *testing.T) {
+	mockRoles := new(MockIRoleResolver)
+	authorizer := authz.NewAuthorizer(authz.DefaultPolicy(), mockRoles)
+
+	_, err := authorizer.Authorize(context.Background(), "", authz.Requirement{Any: authz.PermissionReadAudit})
+
+	assert.ErrorIs(t, err, authz.ErrUnauthenticated)
+	mockRoles.AssertNotCalled(t, "Roles", mock.Anything, mock.Anything)
+}
+
+func TestAuthorize_ResolverError(t *testing.T) {
+	mockRoles := new(MockIRoleResolver)
+	authorizer := authz.NewAuthorizer(authz.DefaultPolicy(), mockRoles)
+	ctx := context.Background()
+	mockRoles.On("Roles", ctx, "1").Return(nil, assert.AnError)
+
+	_, err := authorizer.Authorize(ctx, "1", authz.Requirement{Any: authz.PermissionReadAudit})
+
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestCheckTarget(t *testing.T) {
+	selfScoped := authz.WithScope(context.Background(), authz.Scope{UserID: "1", SelfOnly: true})
+	fullScoped := authz.WithScope(context.Background(), authz.Scope{UserID: "1"})
+
+	assert.NoError(t, authz.CheckTarget(selfScoped, "1"))
+	assert.ErrorIs(t, authz.CheckTarget(selfScoped, "2"), authz.ErrForbidden)
+	assert.NoError(t, authz.CheckTarget(fullScoped, "2"))
+	assert.NoError(t, authz.CheckTarget(context.Background(), "2"))
+}
+
+func TestAuthorize_CrossTenant(t *testing.T) {
+	mockRoles := new(MockIRoleResolver)
+	authorizer := authz.NewAuthorizer(authz.DefaultPolicy(), mockRoles)
+	ctx := tenant.WithTenant(context.Background(), tenant.AllTenants)
+	mockRoles.On("Roles", ctx, "admin").Return([]string{authz.RoleUser, authz.RoleAdmin}, nil)
+	mockRoles.On("Roles", ctx, "platform").Return([]string{authz.RoleUser, authz.RolePlatformAdmin}, nil)
+	requirement := authz.Requirement{Any: authz.PermissionReadAudit}
+
+	_, err := authorizer.Authorize(ctx, "admin", requirement)
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+
+	scope, err := authorizer.Authorize(ctx, "platform", requirement)
+	assert.NoError(t, err)
+	assert.Equal(t, authz.Scope{UserID: "platform"}, scope)
+}
+
+func TestAuthorize_TenantWithoutTokenTenant(t *testing.T) {
+	mockRoles := new(MockIRoleResolver)
+	authorizer := authz.NewAuthorizer(authz.DefaultPolicy(), mockRoles)
+	ctx := tenant.WithCrossing(tenant.WithTenant(context.Background(), "acme"))
+	mockRoles.On("Roles", ctx, "admin").Return([]string{authz.RoleUser, authz.RoleAdmin}, nil)
+	mockRoles.On("Roles", ctx, "platform").Return([]string{authz.RoleUser, authz.RolePlatformAdmin}, nil)
+	requirement := authz.Requirement{Any: authz.PermissionReadAudit}
+
+	_, err := authorizer.Authorize(ctx, "admin", requirement)
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+
+	scope, err := authorizer.Authorize(ctx, "platform", requirement)
+	assert.NoError(t, err)
+	assert.Equal(t, authz.Scope{UserID: "platform"}, scope)
+}
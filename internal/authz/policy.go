@@ -0,0 +1,89 @@
+package authz
+
+type Permission string
+
+const (
+	PermissionReadPrivate Permission = "user:read_private"
+	PermissionReadSelf    Permission = "user:read_self"
+	PermissionCreate      Permission = "user:create"
+	PermissionUpdate      Permission = "user:update"
+	PermissionUpdateSelf  Permission = "user:update_self"
+	PermissionDelete      Permission = "user:delete"
+	PermissionDeleteSelf  Permission = "user:delete_self"
+	PermissionWatch       Permission = "user:watch"
+	PermissionImport      Permission = "user:import"
+	// PermissionVerifyCredentials allows checking a user's password, as the auth service does at sign-in.
+	PermissionVerifyCredentials Permission = "user:verify_credentials"
+	PermissionReadAudit         Permission = "audit:read"
+	PermissionUnlock            Permission = "user:unlock"
+	PermissionReadLoginHistory  Permission = "login:read"
+	// PermissionVerifyMFA allows checking a user's second factor, as the auth service does at sign-in.
+	PermissionVerifyMFA Permission = "user:verify_mfa"
+	// PermissionManageMFA allows disabling another user's MFA without a code, for users who lost their device.
+	PermissionManageMFA     Permission = "user:manage_mfa"
+	PermissionManageMFASelf Permission = "user:manage_mfa_self"
+	// PermissionExportData and PermissionErase answer data subject access and erasure requests.
+	PermissionExportData Permission = "user:export_data"
+	PermissionErase      Permission = "user:erase"
+	// PermissionReflect allows listing the gRPC services and their schemas through server reflection.
+	PermissionReflect Permission = "service:reflect"
+	// PermissionCrossTenant allows a request to run in cross-tenant mode on top of its other permissions.
+	PermissionCrossTenant Permission = "tenant:cross"
+)
+
+const (
+	// RolePlatformAdmin administers every tenant; RoleAdmin is limited to its own.
+	RolePlatformAdmin = "platform_admin"
+	RoleAdmin         = "admin"
+	RoleSupport       = "support"
+	RoleService       = "service"
+	// RoleUser is held implicitly by every authenticated user.
+	RoleUser = "user"
+)
+
+// Requirement declares what a route or RPC needs. Any grants access to every user's record;
+// Self, when set, grants access restricted to the caller's own record.
+type Requirement struct {
+	Any  Permission
+	Self Permission
+}
+
+// Policy maps roles to the permissions they grant.
+type Policy struct {
+	RolePermissions map[string][]Permission
+}
+
+// DefaultPolicy returns the role definitions used by the service.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		RolePermissions: map[string][]Permission{
+			RolePlatformAdmin: {
+				PermissionReadPrivate, PermissionReadSelf, PermissionCreate, PermissionUpdate,
+				PermissionUpdateSelf, PermissionDelete, PermissionDeleteSelf, PermissionWatch, PermissionReadAudit,
+				PermissionImport, PermissionUnlock, PermissionReadLoginHistory, PermissionManageMFA, PermissionManageMFASelf,
+				PermissionExportData, PermissionErase, PermissionReflect, PermissionCrossTenant,
+			},
+			RoleAdmin: {
+				PermissionReadPrivate, PermissionReadSelf, PermissionCreate, PermissionUpdate,
+				PermissionUpdateSelf, PermissionDelete, PermissionDeleteSelf, PermissionWatch, PermissionReadAudit,
+				PermissionImport, PermissionUnlock, PermissionReadLoginHistory, PermissionManageMFA, PermissionManageMFASelf,
+				PermissionExportData, PermissionErase, PermissionReflect,
+			},
+			RoleSupport: {PermissionReadPrivate, PermissionReadAudit, PermissionUnlock, PermissionReadLoginHistory, PermissionReflect},
+			RoleService: {PermissionReadPrivate, PermissionCreate, PermissionWatch, PermissionVerifyCredentials, PermissionVerifyMFA},
+			RoleUser:    {PermissionReadSelf, PermissionUpdateSelf, PermissionDeleteSelf, PermissionManageMFASelf},
+		},
+	}
+}
+
+// Allows reports whether any of the roles grants the permission.
+func (p *Policy) Allows(roles []string, permission Permission) bool {
+	for _, role := range roles {
+		for _, granted := range p.RolePermissions[role] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
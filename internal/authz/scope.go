@@ -0,0 +1,33 @@
+package authz
+
+import "context"
+
+// Scope is the outcome of a successful authorization. A self-only scope limits the request
+// to the caller's own record, which services enforce with CheckTarget once the target is known.
+type Scope struct {
+	UserID   string
+	SelfOnly bool
+}
+
+type scopeContextKey struct{}
+
+// WithScope returns a copy of ctx carrying the scope.
+func WithScope(ctx context.Context, scope Scope) context.Context {
+	return context.WithValue(ctx, scopeContextKey{}, scope)
+}
+
+// ScopeFromContext returns the scope stored by WithScope.
+func ScopeFromContext(ctx context.Context) (Scope, bool) {
+	scope, ok := ctx.Value(scopeContextKey{}).(Scope)
+	return scope, ok
+}
+
+// CheckTarget returns ErrForbidden when the context holds a self-only scope for another user.
+// Contexts without a scope come from trusted in-process callers and are not restricted.
+func CheckTarget(ctx context.Context, targetUserID string) error {
+	scope, ok := ScopeFromContext(ctx)
+	if ok && scope.SelfOnly && scope.UserID != targetUserID {
+		return ErrForbidden
+	}
+	return nil
+}
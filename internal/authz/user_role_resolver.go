@@ -0,0 +1,44 @@
+package authz
+
+import (
+	"context"
+	"errors"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserRoleResolver is an implementation of IRoleResolver that reads roles from the user record.
+// The service account is not stored as a user and always holds RoleService.
+type UserRoleResolver struct {
+	Repository repository.IUserRepository
+}
+
+// NewUserRoleResolver creates a new instance of UserRoleResolver.
+func NewUserRoleResolver(repository repository.IUserRepository) *UserRoleResolver {
+	return &UserRoleResolver{
+		Repository: repository,
+	}
+}
+
+// Roles implements IRoleResolver.
+func (r *UserRoleResolver) Roles(ctx context.Context, userID string) ([]string, error) {
+	if userID == ServiceAccountUserID {
+		return []string{RoleService}, nil
+	}
+
+	user, err := r.Repository.FindById(ctx, userID)
+	if err != nil {
+		// A token for a user that no longer exists, or was never a stored user, grants nothing
+		var serviceError *common_error.ServiceError
+		if errors.Is(err, primitive.ErrInvalidHex) || (errors.As(err, &serviceError) && serviceError.Code == common_error.NotFound) {
+			return nil, ErrUnauthenticated
+		}
+		return nil, err
+	}
+
+	return append([]string{RoleUser}, user.Roles...), nil
+}
+
+var _ IRoleResolver = (*UserRoleResolver)(nil)
@@ -0,0 +1,61 @@
+package authz_test
+
+import (
+	"context"
+	"testing"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockIUserRepository only stubs the lookup used by the resolver.
+type MockIUserRepository struct {
+	repository.IUserRepository
+	mock.Mock
+}
+
+func (m *MockIUserRepository) FindById(ctx context.Context, id string) (*model.PrivateUserModel, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PrivateUserModel), args.Error(1)
+}
+
+func TestRoles_ServiceAccount(t *testing.T) {
+	mockRepository := new(MockIUserRepository)
+	resolver := authz.NewUserRoleResolver(mockRepository)
+
+	roles, err := resolver.Roles(context.Background(), authz.ServiceAccountUserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{authz.RoleService}, roles)
+	mockRepository.AssertNotCalled(t, "FindById", mock.Anything, mock.Anything)
+}
+
+func TestRoles_StoredUser(t *testing.T) {
+	mockRepository := new(MockIUserRepository)
+	resolver := authz.NewUserRoleResolver(mockRepository)
+	ctx := context.Background()
+	mockRepository.On("FindById", ctx, "1").Return(&model.PrivateUserModel{Roles: []string{authz.RoleSupport}}, nil)
+
+	roles, err := resolver.Roles(ctx, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{authz.RoleUser, authz.RoleSupport}, roles)
+}
+
+func TestRoles_UnknownUser(t *testing.T) {
+	mockRepository := new(MockIUserRepository)
+	resolver := authz.NewUserRoleResolver(mockRepository)
+	ctx := context.Background()
+	mockRepository.On("FindById", ctx, "1").Return(nil, common_error.NewServiceError(common_error.NotFound, "User not found", nil))
+
+	_, err := resolver.Roles(ctx, "1")
+
+	assert.ErrorIs(t, err, authz.ErrUnauthenticated)
+}
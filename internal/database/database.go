@@ -2,19 +2,55 @@ package database
 
 import (
 	"context"
+	"errors"
 	"log"
+	"log/slog"
+	"time"
 
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/metrics"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tracing"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// LoginAttemptRetention is how long login attempts are kept for history and brute-force tracking.
+const LoginAttemptRetention = 30 * 24 * time.Hour
+
 type Database struct {
-	Client     *mongo.Client
-	Collection *mongo.Collection
+	Client      *mongo.Client
+	Collection  *mongo.Collection
+	Outbox      *mongo.Collection
+	Audit       *mongo.Collection
+	Idempotency *mongo.Collection
+	Logins      *mongo.Collection
+	Keys        *mongo.Collection
+	// PreImages reports whether CreateIndexes enabled pre-images of user changes
+	PreImages bool
+	Logger    *slog.Logger
 }
 
-func NewDatabase(connStr string) (*Database, error) {
-	clientOptions := options.Client().ApplyURI(connStr).SetMaxPoolSize(50)
+// MaxPoolSize is the maximum number of connections to each MongoDB server.
+const MaxPoolSize = 50
+
+// NewDatabase connects to MongoDB, reporting command latencies and pool connections to mongoMetrics
+// and tracing commands with tracerProvider, unless they are nil.
+func NewDatabase(connStr string, mongoMetrics *metrics.MongoMetrics, tracerProvider trace.TracerProvider, logger *slog.Logger) (*Database, error) {
+	clientOptions := options.Client().ApplyURI(connStr).SetMaxPoolSize(MaxPoolSize)
+	var commandMonitors []*event.CommandMonitor
+	if mongoMetrics != nil {
+		commandMonitors = append(commandMonitors, mongoMetrics.CommandMonitor())
+		clientOptions.SetPoolMonitor(mongoMetrics.PoolMonitor(MaxPoolSize))
+	}
+	if tracerProvider != nil {
+		commandMonitors = append(commandMonitors, tracing.NewCommandMonitor(tracerProvider))
+	}
+	if len(commandMonitors) > 0 {
+		clientOptions.SetMonitor(tracing.JoinCommandMonitors(commandMonitors...))
+	}
 	client, err := mongo.Connect(context.Background(), clientOptions)
 	if err != nil {
 		return nil, err
@@ -26,22 +62,128 @@ func NewDatabase(connStr string) (*Database, error) {
 
 	db := client.Database("user")
 	collection := db.Collection("user")
+	outbox := db.Collection("outbox")
+	audit := db.Collection("audit")
+	idempotency := db.Collection("idempotency")
+	logins := db.Collection("login_attempt")
+	keys := db.Collection("data_key")
 	return &Database{
-		Client:     client,
-		Collection: collection,
+		Client:      client,
+		Collection:  collection,
+		Outbox:      outbox,
+		Audit:       audit,
+		Idempotency: idempotency,
+		Logins:      logins,
+		Keys:        keys,
+		Logger:      logger,
 	}, nil
 }
 
 func (d *Database) CreateIndexes() error {
+	if err := d.migrateToTenants(); err != nil {
+		return err
+	}
+	// Without pre-images the service still starts, but tenant-scoped watchers are not told about deletes
+	if err := d.enablePreImages(); err != nil {
+		d.Logger.Warn("Failed to enable pre-images of user changes, deletes are only streamed to cross-tenant watchers", "error", err)
+	} else {
+		d.PreImages = true
+	}
+
 	indexModels := []mongo.IndexModel{
-		{Keys: map[string]interface{}{"username": 1}, Options: options.Index().SetUnique(true)},
-		{Keys: map[string]interface{}{"email": 1}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "username", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "email", Value: 1}}, Options: options.Index().SetUnique(true)},
+		// Encrypted emails differ on every write, so their uniqueness is enforced on the blind index
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "email_index", Value: 1}}, Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"email_index": bson.M{"$exists": true}})},
+		{Keys: map[string]interface{}{"pii_key_id": 1}},
+		{Keys: map[string]interface{}{"username_held_until": 1}, Options: options.Index().SetSparse(true)},
 	}
 
 	_, err := d.Collection.Indexes().CreateMany(context.Background(), indexModels)
+	if err != nil {
+		return err
+	}
+
+	outboxIndexModels := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "published_at", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		{Keys: map[string]interface{}{"idempotency_key": 1}, Options: options.Index().SetUnique(true)},
+	}
+
+	_, err = d.Outbox.Indexes().CreateMany(context.Background(), outboxIndexModels)
+	if err != nil {
+		return err
+	}
+
+	auditIndexModels := []mongo.IndexModel{
+		{Keys: map[string]interface{}{"sequence": 1}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "target_user_id", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: 1}}},
+		// Erasure scrubs the events a user performed as well as those about them
+		{Keys: bson.D{{Key: "actor", Value: 1}}},
+	}
+
+	_, err = d.Audit.Indexes().CreateMany(context.Background(), auditIndexModels)
+	if err != nil {
+		return err
+	}
+
+	idempotencyIndexModels := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "caller", Value: 1}, {Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: map[string]interface{}{"expires_at": 1}, Options: options.Index().SetExpireAfterSeconds(0)},
+	}
+
+	_, err = d.Idempotency.Indexes().CreateMany(context.Background(), idempotencyIndexModels)
+	if err != nil {
+		return err
+	}
+
+	// Attempts expire after LoginAttemptRetention; lockout windows are far shorter
+	loginIndexModels := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "source_ip", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: map[string]interface{}{"created_at": 1}, Options: options.Index().SetExpireAfterSeconds(int32(LoginAttemptRetention.Seconds()))},
+	}
+
+	_, err = d.Logins.Indexes().CreateMany(context.Background(), loginIndexModels)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.Keys.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: map[string]interface{}{"created_at": 1}})
 	return err
 }
 
+// migrateToTenants moves users created before tenants existed into the default tenant and drops the
+// global unique indexes, which would otherwise stop two tenants from using the same email or username.
+func (d *Database) migrateToTenants() error {
+	_, err := d.Collection.UpdateMany(context.Background(),
+		bson.M{"tenant_id": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"tenant_id": tenant.DefaultTenantID}},
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{"username_1", "email_1"} {
+		_, err := d.Collection.Indexes().DropOne(context.Background(), name)
+		var commandError mongo.CommandError
+		if err != nil && !(errors.As(err, &commandError) && (commandError.Name == "IndexNotFound" || commandError.Name == "NamespaceNotFound")) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enablePreImages keeps the pre-image of each change to users, from which change streams read the
+// tenant of deleted users. It requires MongoDB 6.0 or later and the collMod privilege.
+func (d *Database) enablePreImages() error {
+	return d.Collection.Database().RunCommand(context.Background(), bson.D{
+		{Key: "collMod", Value: d.Collection.Name()},
+		{Key: "changeStreamPreAndPostImages", Value: bson.M{"enabled": true}},
+	}).Err()
+}
+
 func (d *Database) Disconnect() {
 	if err := d.Client.Disconnect(context.Background()); err != nil {
 		log.Fatal("Failed to disconnect from MongoDB", err)
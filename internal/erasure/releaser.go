@@ -0,0 +1,60 @@
+package erasure
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	DefaultBatchSize    = 100
+	DefaultPollInterval = time.Hour
+)
+
+// IUsernameReleaser releases the usernames of erased users once their hold period is over.
+type IUsernameReleaser interface {
+	ReleaseUsernames(ctx context.Context, now time.Time, limit int64) (int, error)
+}
+
+// Releaser makes the usernames of erased users available again, one batch per interval.
+type Releaser struct {
+	Users        IUsernameReleaser
+	BatchSize    int64
+	PollInterval time.Duration
+	Now          func() time.Time
+	Logger       *slog.Logger
+}
+
+// NewReleaser creates a new instance of Releaser with default batching settings.
+func NewReleaser(users IUsernameReleaser, logger *slog.Logger) *Releaser {
+	return &Releaser{
+		Users:        users,
+		BatchSize:    DefaultBatchSize,
+		PollInterval: DefaultPollInterval,
+		Now:          time.Now,
+		Logger:       logger,
+	}
+}
+
+// Run releases usernames until the context is done.
+func (r *Releaser) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.ReleaseOnce(ctx); err != nil {
+			r.Logger.ErrorContext(ctx, "Username release failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReleaseOnce releases one batch of usernames whose hold period is over and returns how many were released.
+func (r *Releaser) ReleaseOnce(ctx context.Context) (int, error) {
+	return r.Users.ReleaseUsernames(ctx, r.Now(), r.BatchSize)
+}
@@ -0,0 +1,60 @@
+package erasure_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/erasure"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIUsernameReleaser struct {
+	mock.Mock
+}
+
+// ReleaseUsernames implements erasure.IUsernameReleaser.
+func (m *MockIUsernameReleaser) ReleaseUsernames(ctx context.Context, now time.Time, limit int64) (int, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Int(0), args.Error(1)
+}
+
+// Ensure that MockIUsernameReleaser implements IUsernameReleaser.
+var _ erasure.IUsernameReleaser = &MockIUsernameReleaser{}
+
+func TestReleaseOnce(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	users := new(MockIUsernameReleaser)
+	users.On("ReleaseUsernames", ctx, now, int64(erasure.DefaultBatchSize)).Return(2, nil)
+	releaser := erasure.NewReleaser(users, logging.NewLogger(io.Discard, slog.LevelInfo))
+	releaser.Now = func() time.Time { return now }
+
+	// Test
+	released, err := releaser.ReleaseOnce(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, released)
+	users.AssertExpectations(t)
+}
+
+func TestRun_StopsWithContext(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	users := new(MockIUsernameReleaser)
+	users.On("ReleaseUsernames", ctx, mock.Anything, mock.Anything).Run(func(mock.Arguments) { cancel() }).Return(0, errors.New("unavailable"))
+
+	// Test
+	err := erasure.NewReleaser(users, logging.NewLogger(io.Discard, slog.LevelInfo)).Run(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	users.AssertNumberOfCalls(t, "ReleaseUsernames", 1)
+}
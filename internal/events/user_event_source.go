@@ -0,0 +1,33 @@
+package events
+
+import (
+	"context"
+	"errors"
+
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+)
+
+type UserEventType string
+
+const (
+	UserCreated UserEventType = "created"
+	UserUpdated UserEventType = "updated"
+	UserDeleted UserEventType = "deleted"
+)
+
+// ErrInvalidResumeToken is returned when a watcher resumes from a token the source cannot interpret.
+var ErrInvalidResumeToken = errors.New("invalid resume token")
+
+// UserChangeEvent describes a change to a user document. User is nil for deletions.
+type UserChangeEvent struct {
+	Type        UserEventType
+	UserID      string
+	User        *publicModel.PublicUserModel
+	ResumeToken string
+}
+
+// IUserEventSource streams user change events to a handler until the context is done or the handler fails.
+// An empty resume token starts from the current position; otherwise delivery resumes after the event carrying that token.
+type IUserEventSource interface {
+	Watch(ctx context.Context, resumeToken string, handle func(event *UserChangeEvent) error) error
+}
@@ -0,0 +1,72 @@
+package events
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// MemoryUserEventSource is an in-process implementation of IUserEventSource intended for tests.
+// Every published event is retained, and resume tokens are positions in that log.
+type MemoryUserEventSource struct {
+	mu     sync.Mutex
+	events []*UserChangeEvent
+	notify chan struct{}
+}
+
+// NewMemoryUserEventSource creates a new instance of MemoryUserEventSource.
+func NewMemoryUserEventSource() *MemoryUserEventSource {
+	return &MemoryUserEventSource{
+		notify: make(chan struct{}),
+	}
+}
+
+// Publish appends an event to the log, assigns its resume token and wakes any watchers.
+func (m *MemoryUserEventSource) Publish(event UserChangeEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	event.ResumeToken = strconv.Itoa(len(m.events) + 1)
+	m.events = append(m.events, &event)
+	close(m.notify)
+	m.notify = make(chan struct{})
+}
+
+// Watch implements IUserEventSource.
+func (m *MemoryUserEventSource) Watch(ctx context.Context, resumeToken string, handle func(event *UserChangeEvent) error) error {
+	m.mu.Lock()
+	next := len(m.events)
+	m.mu.Unlock()
+
+	if resumeToken != "" {
+		position, err := strconv.Atoi(resumeToken)
+		if err != nil || position < 0 || position > next {
+			return ErrInvalidResumeToken
+		}
+		next = position
+	}
+
+	for {
+		m.mu.Lock()
+		pending := append([]*UserChangeEvent(nil), m.events[next:]...)
+		notify := m.notify
+		m.mu.Unlock()
+
+		for _, event := range pending {
+			if err := handle(event); err != nil {
+				return err
+			}
+			next++
+		}
+
+		if len(pending) == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-notify:
+			}
+		}
+	}
+}
+
+var _ IUserEventSource = (*MemoryUserEventSource)(nil)
@@ -0,0 +1,74 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/events"
+	"github.com/stretchr/testify/assert"
+)
+
+var errStop = errors.New("stop")
+
+func TestMemoryUserEventSource_ResumesAfterToken(t *testing.T) {
+	source := events.NewMemoryUserEventSource()
+	source.Publish(events.UserChangeEvent{Type: events.UserCreated, UserID: "1"})
+	source.Publish(events.UserChangeEvent{Type: events.UserUpdated, UserID: "1"})
+	source.Publish(events.UserChangeEvent{Type: events.UserDeleted, UserID: "1"})
+
+	var received []*events.UserChangeEvent
+	err := source.Watch(context.Background(), "1", func(event *events.UserChangeEvent) error {
+		received = append(received, event)
+		if len(received) == 2 {
+			return errStop
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, events.UserUpdated, received[0].Type)
+	assert.Equal(t, events.UserDeleted, received[1].Type)
+	assert.Equal(t, "3", received[1].ResumeToken)
+}
+
+func TestMemoryUserEventSource_DeliversNewEvents(t *testing.T) {
+	source := events.NewMemoryUserEventSource()
+	source.Publish(events.UserChangeEvent{Type: events.UserCreated, UserID: "old"})
+
+	done := make(chan error)
+	var received *events.UserChangeEvent
+	go func() {
+		done <- source.Watch(context.Background(), "1", func(event *events.UserChangeEvent) error {
+			received = event
+			return errStop
+		})
+	}()
+
+	source.Publish(events.UserChangeEvent{Type: events.UserCreated, UserID: "new"})
+
+	assert.ErrorIs(t, <-done, errStop)
+	assert.Equal(t, "new", received.UserID)
+}
+
+func TestMemoryUserEventSource_StopsOnContextDone(t *testing.T) {
+	source := events.NewMemoryUserEventSource()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := source.Watch(ctx, "", func(event *events.UserChangeEvent) error {
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMemoryUserEventSource_InvalidResumeToken(t *testing.T) {
+	source := events.NewMemoryUserEventSource()
+
+	err := source.Watch(context.Background(), "42", func(event *events.UserChangeEvent) error {
+		return nil
+	})
+
+	assert.ErrorIs(t, err, events.ErrInvalidResumeToken)
+}
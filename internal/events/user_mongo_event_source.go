@@ -0,0 +1,130 @@
+package events
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IChangeStreamAdapter is the subset of *mongo.Collection needed to open a change stream.
+type IChangeStreamAdapter interface {
+	Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error)
+}
+
+// MongoUserEventSource is an implementation of IUserEventSource backed by MongoDB change streams.
+type MongoUserEventSource struct {
+	Collection IChangeStreamAdapter
+	// Cipher decrypts the display name when users are encrypted at rest
+	Cipher repository.IFieldCipher
+	// PreImages tells whether the collection keeps pre-images of changes. Without them the tenant of
+	// a deleted user is unknown, so deletes are only streamed to cross-tenant watchers.
+	PreImages bool
+}
+
+// NewMongoUserEventSource creates a new instance of MongoUserEventSource.
+func NewMongoUserEventSource(collection IChangeStreamAdapter) *MongoUserEventSource {
+	return &MongoUserEventSource{
+		Collection: collection,
+	}
+}
+
+// publicChangeFields lists the fields of change events read from the stream. It is an inclusion projection,
+// so that fields added to user documents stay private until they are added here. The profile is included
+// whole, as its fields are filtered by visibility once decoded. The _id carries the resume token.
+var publicChangeFields = bson.D{
+	{Key: "_id", Value: 1},
+	{Key: "operationType", Value: 1},
+	{Key: "documentKey", Value: 1},
+	{Key: "fullDocument._id", Value: 1},
+	{Key: "fullDocument.username", Value: 1},
+	{Key: "fullDocument.profile", Value: 1},
+	{Key: "fullDocument.created_at", Value: 1},
+	{Key: "fullDocument.updated_at", Value: 1},
+}
+
+type changeDocument struct {
+	OperationType string                  `bson:"operationType"`
+	FullDocument  *model.PrivateUserModel `bson:"fullDocument"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+}
+
+// Watch implements IUserEventSource.
+func (m *MongoUserEventSource) Watch(ctx context.Context, resumeToken string, handle func(event *UserChangeEvent) error) error {
+	// Deletes carry no document, so the tenant of a deleted user is read from its pre-image
+	changeStreamOptions := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if m.PreImages {
+		changeStreamOptions.SetFullDocumentBeforeChange(options.WhenAvailable)
+	}
+	if resumeToken != "" {
+		token, err := base64.RawURLEncoding.DecodeString(resumeToken)
+		if err != nil || bson.Raw(token).Validate() != nil {
+			return ErrInvalidResumeToken
+		}
+		changeStreamOptions.SetResumeAfter(bson.Raw(token))
+	}
+
+	// Only the public projection may leave the service, so only its fields are read from the stream.
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"operationType": bson.M{"$in": []string{"insert", "update", "replace", "delete"}}}}},
+	}
+	// Deletes without a pre-image, such as those from before pre-images were enabled, cannot be attributed
+	// to a tenant and are only seen in cross-tenant mode.
+	if !tenant.IsAllTenants(ctx) {
+		tenantID := tenant.FromContext(ctx)
+		tenantMatch := bson.A{bson.M{"operationType": bson.M{"$ne": "delete"}, "fullDocument.tenant_id": tenantID}}
+		if m.PreImages {
+			tenantMatch = append(tenantMatch, bson.M{"operationType": "delete", "fullDocumentBeforeChange.tenant_id": tenantID})
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$or": tenantMatch}}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$project", Value: publicChangeFields}})
+
+	stream, err := m.Collection.Watch(ctx, pipeline, changeStreamOptions)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(ctx)
+
+	for stream.Next(ctx) {
+		var change changeDocument
+		if err := stream.Decode(&change); err != nil {
+			return err
+		}
+
+		event := &UserChangeEvent{
+			UserID:      change.DocumentKey.ID.Hex(),
+			ResumeToken: base64.RawURLEncoding.EncodeToString(stream.ResumeToken()),
+		}
+		switch change.OperationType {
+		case "insert":
+			event.Type = UserCreated
+		case "delete":
+			event.Type = UserDeleted
+		default:
+			event.Type = UserUpdated
+		}
+		if change.FullDocument != nil && event.Type != UserDeleted {
+			if err := repository.OpenPII(m.Cipher, change.FullDocument); err != nil {
+				return err
+			}
+			event.User = change.FullDocument.ToPublicUserModel()
+		}
+
+		if err := handle(event); err != nil {
+			return err
+		}
+	}
+
+	return stream.Err()
+}
+
+var _ IUserEventSource = (*MongoUserEventSource)(nil)
@@ -0,0 +1,64 @@
+package fiberserver
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AccessLogMiddleware correlates each request and logs it once handled, with its status and latency.
+// Requests without an X-Request-ID header are given one, which is echoed in the response, and the trace id
+// is taken from the current span, or else from the traceparent header. Both are attached to the user context
+// so that later records carry them. It must be registered first but TracingMiddleware, so that it also logs
+// the responses of recovered panics.
+func AccessLogMiddleware(logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		requestID := c.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = logging.NewRequestID()
+			// Handlers read the request id from the header
+			c.Request().Header.Set(RequestIDHeader, requestID)
+		}
+		c.Set(RequestIDHeader, requestID)
+		ctx := logging.WithCorrelation(c.UserContext(), logging.Correlation{
+			RequestID: requestID,
+			TraceID:   logging.TraceID(c.UserContext(), c.Get(logging.TraceparentHeader)),
+		})
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		status := responseStatus(c, err)
+		level := slog.LevelInfo
+		if status >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.LogAttrs(ctx, level, "request",
+			slog.String("method", c.Method()),
+			slog.String("route", c.Route().Path),
+			slog.String("path", c.Path()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("ip", c.IP()),
+		)
+		return err
+	}
+}
+
+// responseStatus returns the status of the response to a request handled with err. The error handler
+// writes the response of a failed request only after the middleware returns.
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return fiber.StatusInternalServerError
+}
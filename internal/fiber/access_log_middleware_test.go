@@ -0,0 +1,72 @@
+package fiberserver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	fiberserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/fiber"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLogMiddleware(t *testing.T) {
+	// Setup
+	var logs bytes.Buffer
+	app := fiber.New()
+	app.Use(fiberserver.AccessLogMiddleware(logging.NewLogger(&logs, slog.LevelInfo)))
+	var seen logging.Correlation
+	app.Get("/user/:user_identifier", func(c *fiber.Ctx) error {
+		seen = logging.CorrelationFromContext(c.UserContext())
+		return fiber.NewError(fiber.StatusNotFound, "User not found")
+	})
+	req := httptest.NewRequest(fiber.MethodGet, "/user/test", nil)
+	req.Header.Set(fiberserver.RequestIDHeader, "request-1")
+	req.Header.Set(logging.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	// Test
+	resp, err := app.Test(req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "request-1", resp.Header.Get(fiberserver.RequestIDHeader))
+	assert.Equal(t, logging.Correlation{RequestID: "request-1", TraceID: "4bf92f3577b34da6a3ce929d0e0e4736"}, seen)
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &record))
+	assert.Equal(t, "request", record["msg"])
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "/user/:user_identifier", record["route"])
+	assert.Equal(t, "/user/test", record["path"])
+	assert.Equal(t, float64(fiber.StatusNotFound), record["status"])
+	assert.Contains(t, record, "latency")
+	assert.Equal(t, "request-1", record[logging.RequestIDKey])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record[logging.TraceIDKey])
+}
+
+func TestAccessLogMiddleware_GeneratesRequestID(t *testing.T) {
+	// Setup
+	var logs bytes.Buffer
+	app := fiber.New()
+	app.Use(fiberserver.AccessLogMiddleware(logging.NewLogger(&logs, slog.LevelInfo)))
+	var header string
+	app.Get("/", func(c *fiber.Ctx) error {
+		header = c.Get(fiberserver.RequestIDHeader)
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	// Test
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+
+	// Assert
+	require.NoError(t, err)
+	requestID := resp.Header.Get(fiberserver.RequestIDHeader)
+	assert.Len(t, requestID, 32)
+	assert.Equal(t, requestID, header)
+	assert.Contains(t, logs.String(), `"status":204`)
+	assert.Contains(t, logs.String(), `"request_id":"`+requestID+`"`)
+}
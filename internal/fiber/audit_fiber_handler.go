@@ -0,0 +1,44 @@
+package fiberserver
+
+import (
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditFiberHandler struct {
+	AuditService service.IAuditService
+}
+
+func NewAuditFiberHandler(auditService service.IAuditService) *AuditFiberHandler {
+	return &AuditFiberHandler{
+		AuditService: auditService,
+	}
+}
+
+func (handler *AuditFiberHandler) List(c *fiber.Ctx) error {
+	filter := repository.AuditFilter{
+		TargetUserID: c.Query("user_id"),
+		Limit:        int64(c.QueryInt("limit")),
+	}
+	var err error
+	if from := c.Query("from"); from != "" {
+		if filter.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "from must be an RFC 3339 timestamp")
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if filter.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "to must be an RFC 3339 timestamp")
+		}
+	}
+
+	auditEvents, err := handler.AuditService.List(requestContext(c), filter)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Something went wrong")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(auditEvents)
+}
@@ -0,0 +1,29 @@
+package fiberserver
+
+import (
+	"errors"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequirePermission returns middleware that authorizes the caller set by the JWT middleware
+// and hands the granted scope to the handler through the user context.
+func RequirePermission(authorizer authz.IAuthorizer, requirement authz.Requirement) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userId, _ := c.Locals("user_id").(string)
+		scope, err := authorizer.Authorize(c.UserContext(), userId, requirement)
+		if err != nil {
+			switch {
+			case errors.Is(err, authz.ErrUnauthenticated):
+				return fiber.NewError(fiber.StatusUnauthorized, "Unauthorized")
+			case errors.Is(err, authz.ErrForbidden):
+				return fiber.NewError(fiber.StatusForbidden, "Forbidden")
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, "Something went wrong")
+		}
+
+		c.SetUserContext(authz.WithScope(c.UserContext(), scope))
+		return c.Next()
+	}
+}
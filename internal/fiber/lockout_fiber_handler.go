@@ -0,0 +1,39 @@
+package fiberserver
+
+import (
+	"errors"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type LockoutFiberHandler struct {
+	LockoutService service.ILockoutService
+}
+
+func NewLockoutFiberHandler(lockoutService service.ILockoutService) *LockoutFiberHandler {
+	return &LockoutFiberHandler{
+		LockoutService: lockoutService,
+	}
+}
+
+func (handler *LockoutFiberHandler) Unlock(c *fiber.Ctx) error {
+	if err := handler.LockoutService.Unlock(requestContext(c), c.Params("user_id")); err != nil {
+		return selfServiceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (handler *LockoutFiberHandler) History(c *fiber.Ctx) error {
+	attempts, err := handler.LockoutService.History(requestContext(c), c.Params("user_id"), int64(c.QueryInt("limit")))
+	if err != nil {
+		if errors.Is(err, authz.ErrForbidden) {
+			return fiber.NewError(fiber.StatusForbidden, "Forbidden")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, "Something went wrong")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(attempts)
+}
@@ -0,0 +1,32 @@
+package fiberserver
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/metrics"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// MetricsPath is where SetupMetrics serves the metrics.
+const MetricsPath = "/metrics"
+
+// MetricsMiddleware records the RED metrics of each request by method and route template, so that
+// path parameters do not multiply the series. Requests that match no route are recorded under the path
+// of the middleware. It must be registered before RecoveryMiddleware, so that it also records recovered panics.
+func MetricsMiddleware(red *metrics.RED) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+
+		red.Observe(c.Method()+" "+c.Route().Path, strconv.Itoa(responseStatus(c, err)), start)
+		return err
+	}
+}
+
+// SetupMetrics serves the metrics of the default registry for Prometheus to scrape.
+func (server *UserFiberServer) SetupMetrics() {
+	server.App.Get(MetricsPath, adaptor.HTTPHandler(metrics.Handler()))
+}
@@ -0,0 +1,58 @@
+package fiberserver_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	fiberserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/fiber"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/metrics"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	// Setup
+	red := metrics.NewRED(prometheus.NewRegistry(), metrics.TransportHTTP, "route", "status")
+	app := fiber.New()
+	app.Use(fiberserver.MetricsMiddleware(red))
+	app.Get("/user/:user_identifier", func(c *fiber.Ctx) error {
+		if c.Params("user_identifier") == "missing" {
+			return fiber.NewError(fiber.StatusNotFound, "User not found")
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	// Test
+	for _, path := range []string{"/user/a", "/user/b", "/user/missing", "/unknown/a", "/unknown/b"} {
+		_, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+		require.NoError(t, err)
+	}
+
+	// Assert
+	assert.Equal(t, float64(2), testutil.ToFloat64(red.Requests.WithLabelValues("GET /user/:user_identifier", "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(red.Requests.WithLabelValues("GET /user/:user_identifier", "404")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(red.Requests.WithLabelValues("GET /", "404")))
+	assert.Equal(t, 3, testutil.CollectAndCount(red.Requests))
+}
+
+func TestSetupMetrics(t *testing.T) {
+	// Setup
+	server := newRoutedServer()
+	server.SetupMetrics()
+	_, err := server.App.Test(httptest.NewRequest(fiber.MethodGet, "/user/test", nil))
+	require.NoError(t, err)
+
+	// Test
+	resp, err := server.App.Test(httptest.NewRequest(fiber.MethodGet, fiberserver.MetricsPath, nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `user_service_http_requests_total{route="GET /user/:user_identifier",status="500"}`)
+}
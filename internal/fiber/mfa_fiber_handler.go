@@ -0,0 +1,98 @@
+package fiberserver
+
+import (
+	"errors"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"github.com/gofiber/fiber/v2"
+)
+
+type MFAFiberHandler struct {
+	MFAService service.IMFAService
+}
+
+func NewMFAFiberHandler(mfaService service.IMFAService) *MFAFiberHandler {
+	return &MFAFiberHandler{
+		MFAService: mfaService,
+	}
+}
+
+func (handler *MFAFiberHandler) EnrollMe(c *fiber.Ctx) error {
+	userId, _ := c.Locals("user_id").(string)
+
+	enrollment, err := handler.MFAService.Enroll(requestContext(c), userId)
+	if err != nil {
+		return mfaError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(enrollment)
+}
+
+func (handler *MFAFiberHandler) ConfirmMe(c *fiber.Ctx) error {
+	var codeModel publicModel.MFACodeModel
+	if err := c.BodyParser(&codeModel); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	userId, _ := c.Locals("user_id").(string)
+	recoveryCodes, err := handler.MFAService.Confirm(requestContext(c), userId, codeModel.Code)
+	if err != nil {
+		return mfaError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(recoveryCodes)
+}
+
+func (handler *MFAFiberHandler) DisableMe(c *fiber.Ctx) error {
+	var codeModel publicModel.MFACodeModel
+	if err := c.BodyParser(&codeModel); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	userId, _ := c.Locals("user_id").(string)
+	if err := handler.MFAService.Disable(requestContext(c), userId, codeModel.Code); err != nil {
+		return mfaError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (handler *MFAFiberHandler) Verify(c *fiber.Ctx) error {
+	var verifyMFAModel publicModel.VerifyMFAModel
+	if err := c.BodyParser(&verifyMFAModel); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	verification, err := handler.MFAService.Verify(requestContext(c), c.Params("user_id"), &verifyMFAModel)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidMFACode) {
+			return fiber.NewError(fiber.StatusUnauthorized, "Invalid code")
+		}
+		return mfaError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(verification)
+}
+
+// Disable lets administrators disable MFA for a user without a code.
+func (handler *MFAFiberHandler) Disable(c *fiber.Ctx) error {
+	if err := handler.MFAService.Disable(requestContext(c), c.Params("user_id"), ""); err != nil {
+		return mfaError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// mfaError maps errors from the MFA handlers to HTTP responses.
+func mfaError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidMFACode):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrMFAAlreadyEnabled), errors.Is(err, service.ErrMFANotEnabled), errors.Is(err, service.ErrMFANotEnrolled):
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrAccountLocked), errors.Is(err, service.ErrLoginThrottled):
+		return lockoutError(c, err)
+	}
+	return selfServiceError(err)
+}
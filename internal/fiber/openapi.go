@@ -0,0 +1,28 @@
+package fiberserver
+
+import (
+	_ "embed"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// OpenAPISpec is the OpenAPI 3 document of the routes in SetupRoutes. It is maintained by hand;
+// the contract test fails when a route has no entry in it.
+//
+//go:embed openapi.json
+var OpenAPISpec []byte
+
+//go:embed docs.html
+var docsPage []byte
+
+// SetupDocs serves OpenAPISpec at /openapi.json and an interactive docs page rendering it at /docs.
+func (server *UserFiberServer) SetupDocs() {
+	server.App.Get("/openapi.json", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.Send(OpenAPISpec)
+	})
+	server.App.Get("/docs", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.Send(docsPage)
+	})
+}
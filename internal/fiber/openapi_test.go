@@ -0,0 +1,134 @@
+package fiberserver_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	fiberserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/fiber"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type openAPIDocument struct {
+	OpenAPI string                                `json:"openapi"`
+	Paths   map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+var fiberParam = regexp.MustCompile(`:([a-zA-Z_]+)`)
+
+// newRoutedServer returns a server with the routes of SetupRoutes. The handlers are never called.
+func newRoutedServer() *fiberserver.UserFiberServer {
+	server := fiberserver.NewUserFiberServer(fiber.Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)), noop.NewTracerProvider())
+	server.SetupRoutes(
+		fiberserver.NewUserFiberHandler(nil),
+		fiberserver.NewAuditFiberHandler(nil),
+		fiberserver.NewLockoutFiberHandler(nil),
+		fiberserver.NewMFAFiberHandler(nil),
+		fiberserver.NewPrivacyFiberHandler(nil),
+		func(c *fiber.Ctx) error { return c.Next() },
+		nil,
+	)
+	return server
+}
+
+// routeOperations lists the routes of the server as "METHOD /path" in OpenAPI path syntax.
+func routeOperations(server *fiberserver.UserFiberServer) []string {
+	seen := map[string]bool{}
+	for _, route := range server.App.GetRoutes(true) {
+		// Fiber registers HEAD alongside every GET
+		if route.Method == fiber.MethodHead {
+			continue
+		}
+		seen[route.Method+" "+fiberParam.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+	return sortedKeys(seen)
+}
+
+// specOperations lists the operations of the spec as "METHOD /path".
+func specOperations(t *testing.T) []string {
+	var document openAPIDocument
+	require.NoError(t, json.Unmarshal(fiberserver.OpenAPISpec, &document))
+
+	seen := map[string]bool{}
+	for path, operations := range document.Paths {
+		for method := range operations {
+			seen[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+	return sortedKeys(seen)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestOpenAPISpec_CoversEveryRoute(t *testing.T) {
+	// Setup
+	server := newRoutedServer()
+
+	// Test
+	routes := routeOperations(server)
+	operations := specOperations(t)
+
+	// Assert
+	assert.NotEmpty(t, routes)
+	for _, route := range routes {
+		assert.Contains(t, operations, route, "route has no entry in openapi.json")
+	}
+	for _, operation := range operations {
+		assert.Contains(t, routes, operation, "openapi.json describes a route that SetupRoutes does not register")
+	}
+}
+
+func TestOpenAPISpec_ReferencesResolve(t *testing.T) {
+	// Setup
+	var document map[string]interface{}
+	require.NoError(t, json.Unmarshal(fiberserver.OpenAPISpec, &document))
+	references := regexp.MustCompile(`"\$ref":\s*"#/([^"]+)"`).FindAllStringSubmatch(string(fiberserver.OpenAPISpec), -1)
+
+	// Test & Assert
+	assert.Equal(t, "3.0.3", document["openapi"])
+	assert.NotEmpty(t, references)
+	for _, reference := range references {
+		var node interface{} = document
+		for _, segment := range strings.Split(reference[1], "/") {
+			object, ok := node.(map[string]interface{})
+			require.True(t, ok, reference[1])
+			node, ok = object[segment]
+			require.True(t, ok, "unresolved reference #/%s", reference[1])
+		}
+	}
+}
+
+func TestSetupDocs(t *testing.T) {
+	// Setup
+	server := fiberserver.NewUserFiberServer(fiber.Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)), noop.NewTracerProvider())
+	server.SetupDocs()
+
+	// Test
+	specResp, err := server.App.Test(httptest.NewRequest(fiber.MethodGet, "/openapi.json", nil))
+	require.NoError(t, err)
+	docsResp, err := server.App.Test(httptest.NewRequest(fiber.MethodGet, "/docs", nil))
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, fiber.StatusOK, specResp.StatusCode)
+	body, err := io.ReadAll(specResp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, fiberserver.OpenAPISpec, body)
+	assert.Equal(t, fiber.StatusOK, docsResp.StatusCode)
+	assert.Contains(t, docsResp.Header.Get(fiber.HeaderContentType), fiber.MIMETextHTML)
+}
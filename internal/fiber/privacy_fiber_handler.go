@@ -0,0 +1,41 @@
+package fiberserver
+
+import (
+	"errors"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PrivacyFiberHandler struct {
+	PrivacyService service.IPrivacyService
+}
+
+func NewPrivacyFiberHandler(privacyService service.IPrivacyService) *PrivacyFiberHandler {
+	return &PrivacyFiberHandler{
+		PrivacyService: privacyService,
+	}
+}
+
+// Export sends everything stored about a user as a JSON file download.
+func (handler *PrivacyFiberHandler) Export(c *fiber.Ctx) error {
+	export, err := handler.PrivacyService.Export(requestContext(c), c.Params("user_id"))
+	if err != nil {
+		return selfServiceError(err)
+	}
+
+	c.Attachment(export.Filename())
+	return c.Status(fiber.StatusOK).JSON(export)
+}
+
+func (handler *PrivacyFiberHandler) Erase(c *fiber.Ctx) error {
+	receipt, err := handler.PrivacyService.Erase(requestContext(c), c.Params("user_id"))
+	if err != nil {
+		if errors.Is(err, service.ErrUserErased) {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return selfServiceError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(receipt)
+}
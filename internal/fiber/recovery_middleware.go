@@ -0,0 +1,32 @@
+package fiberserver
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RecoveryMiddleware turns a panic in a later handler into a 500 response, logging the stack with
+// the request id and counting it in panics. It must be registered before any handler but AccessLogMiddleware
+// and MetricsMiddleware.
+func RecoveryMiddleware(logger *slog.Logger, panics prometheus.Counter) fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				logger.ErrorContext(c.UserContext(), "panic",
+					"method", c.Method(),
+					"path", c.Path(),
+					"panic", fmt.Sprint(recovered),
+					"stack", string(debug.Stack()),
+				)
+				panics.Inc()
+				err = fiber.NewError(fiber.StatusInternalServerError, "Something went wrong")
+			}
+		}()
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,59 @@
+package fiberserver_test
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	fiberserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/fiber"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	// Setup
+	var logs bytes.Buffer
+	panics := prometheus.NewCounter(prometheus.CounterOpts{Name: "panics_total"})
+	logger := logging.NewLogger(&logs, slog.LevelInfo)
+	app := fiber.New()
+	app.Use(fiberserver.AccessLogMiddleware(logger), fiberserver.RecoveryMiddleware(logger, panics))
+	app.Get("/private/user/:user_identifier", func(c *fiber.Ctx) error {
+		var handler *fiberserver.UserFiberHandler
+		return handler.FindMe(c)
+	})
+	req := httptest.NewRequest(fiber.MethodGet, "/private/user/test", nil)
+	req.Header.Set(fiberserver.RequestIDHeader, "request-1")
+
+	// Test
+	resp, err := app.Test(req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, float64(1), testutil.ToFloat64(panics))
+	assert.Contains(t, logs.String(), `"msg":"panic","method":"GET","path":"/private/user/test"`)
+	assert.Contains(t, logs.String(), `"request_id":"request-1"`)
+	assert.Contains(t, logs.String(), `"msg":"request","method":"GET","route":"/private/user/:user_identifier","path":"/private/user/test","status":500`)
+}
+
+func TestNewUserFiberServer_RecoversPanics(t *testing.T) {
+	// Setup
+	server := fiberserver.NewUserFiberServer(fiber.Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)), noop.NewTracerProvider())
+	server.App.Get("/panic", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+
+	// Test
+	resp, err := server.App.Test(httptest.NewRequest(fiber.MethodGet, "/panic", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
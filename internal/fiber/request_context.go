@@ -0,0 +1,27 @@
+package fiberserver
+
+import (
+	"context"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// RequestIDHeader is the header clients use to correlate a request with their own logs.
+	RequestIDHeader = "X-Request-ID"
+	// IdempotencyKeyHeader lets clients retry user creation safely.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// TenantIDHeader names the tenant a request operates on, or tenant.AllTenants for cross-tenant mode.
+	TenantIDHeader = "X-Tenant-ID"
+)
+
+// requestContext returns the context handed to services, carrying the caller set by the JWT middleware.
+func requestContext(c *fiber.Ctx) context.Context {
+	userId, _ := c.Locals("user_id").(string)
+	return service.WithActor(c.UserContext(), service.Actor{
+		UserID:    userId,
+		SourceIP:  c.IP(),
+		RequestID: c.Get(RequestIDHeader),
+	})
+}
@@ -0,0 +1,26 @@
+package fiberserver
+
+import (
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TenantMiddleware scopes the request to the tenant from the token's tenant_id claim or the X-Tenant-ID
+// header. On authenticated routes it must run after the JWT middleware, and marks requests that cross into
+// another tenant for authorization.
+func TenantMiddleware(c *fiber.Ctx) error {
+	claimed, _ := c.Locals("tenant_id").(string)
+	userId, _ := c.Locals("user_id").(string)
+
+	tenantID, err := tenant.Resolve(claimed, c.Get(TenantIDHeader), userId != "")
+	if err != nil {
+		return fiber.NewError(fiber.StatusForbidden, err.Error())
+	}
+
+	ctx := tenant.WithTenant(c.UserContext(), tenantID)
+	if tenant.Crosses(claimed, tenantID, userId != "") {
+		ctx = tenant.WithCrossing(ctx)
+	}
+	c.SetUserContext(ctx)
+	return c.Next()
+}
@@ -0,0 +1,63 @@
+package fiberserver
+
+import (
+	"net/http"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tracing"
+	"github.com/gofiber/fiber/v2"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// headerCarrier adapts the request headers of a Fiber context to a propagation.TextMapCarrier.
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (carrier headerCarrier) Get(key string) string {
+	return carrier.c.Get(key)
+}
+
+func (carrier headerCarrier) Set(key string, value string) {
+	carrier.c.Request().Header.Set(key, value)
+}
+
+func (carrier headerCarrier) Keys() []string {
+	var keys []string
+	carrier.c.Request().Header.VisitAll(func(key []byte, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
+
+// TracingMiddleware traces each request as a server span, continuing the trace of its traceparent header.
+// The span is named after the route template once the request is handled. The traceparent header is
+// rewritten to the span, so that the gateway passes it on to the gRPC server. It must be registered first.
+func TracingMiddleware(provider trace.TracerProvider) fiber.Handler {
+	tracer := provider.Tracer(tracing.InstrumentationName)
+	return func(c *fiber.Ctx) error {
+		carrier := headerCarrier{c: c}
+		ctx, span := tracer.Start(tracing.Propagator.Extract(c.UserContext(), carrier), c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				// Paths may identify users by their email address
+				semconv.URLPath(logging.RedactString(c.Path())),
+			),
+		)
+		tracing.Propagator.Inject(ctx, carrier)
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		status := responseStatus(c, err)
+		span.SetName(c.Method() + " " + c.Route().Path)
+		span.SetAttributes(semconv.HTTPRoute(c.Route().Path), semconv.HTTPResponseStatusCode(status))
+		if status >= fiber.StatusInternalServerError {
+			tracing.Fail(span, http.StatusText(status))
+		}
+		span.End()
+		return err
+	}
+}
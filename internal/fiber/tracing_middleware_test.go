@@ -0,0 +1,76 @@
+package fiberserver_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	fiberserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/fiber"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	// Setup
+	exporter := tracetest.NewInMemoryExporter()
+	app := fiber.New()
+	app.Use(fiberserver.TracingMiddleware(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))))
+	var seen trace.SpanContext
+	var forwarded string
+	app.Get("/user/:user_identifier", func(c *fiber.Ctx) error {
+		seen = trace.SpanContextFromContext(c.UserContext())
+		forwarded = c.Get(logging.TraceparentHeader)
+		return fiber.NewError(fiber.StatusNotFound, "User not found")
+	})
+	req := httptest.NewRequest(fiber.MethodGet, "/user/test@mail.com", nil)
+	req.Header.Set(logging.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	// Test
+	resp, err := app.Test(req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /user/:user_identifier", span.Name)
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent.SpanID().String())
+	assert.Equal(t, span.SpanContext, seen)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", logging.TraceIDFromTraceparent(forwarded))
+	assert.Contains(t, forwarded, span.SpanContext.SpanID().String())
+	assert.Contains(t, span.Attributes, semconv.HTTPRoute("/user/:user_identifier"))
+	assert.Contains(t, span.Attributes, semconv.HTTPResponseStatusCode(fiber.StatusNotFound))
+	assert.Contains(t, span.Attributes, attribute.String(string(semconv.URLPathKey), "/user/"+logging.Redacted))
+	assert.Equal(t, codes.Unset, span.Status.Code)
+}
+
+func TestTracingMiddleware_ServerError(t *testing.T) {
+	// Setup
+	exporter := tracetest.NewInMemoryExporter()
+	app := fiber.New()
+	app.Use(fiberserver.TracingMiddleware(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))))
+	app.Get("/user/:user_identifier", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusInternalServerError, "Internal error")
+	})
+
+	// Test
+	_, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/user/test", nil))
+
+	// Assert
+	require.NoError(t, err)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.False(t, spans[0].Parent.IsValid())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "Internal Server Error", spans[0].Status.Description)
+}
@@ -1,6 +1,14 @@
 package fiberserver
 
 import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/passwordpolicy"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
 	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
 	"github.com/gofiber/fiber/v2"
@@ -22,39 +30,193 @@ func (handler *UserFiberHandler) Create(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	user, err := handler.UserService.Create(c.Context(), &createUserModel)
+	ctx := service.WithIdempotencyKey(requestContext(c), c.Get(IdempotencyKeyHeader))
+	user, err := handler.UserService.Create(ctx, &createUserModel)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidIdempotencyKey), errors.Is(err, service.ErrInvalidProfile), errors.Is(err, passwordpolicy.ErrWeakPassword):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrIdempotencyKeyReused), errors.Is(err, service.ErrIdempotencyInProgress):
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, "Something went wrong")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(user.ToPublicUserModel())
+}
+
+func (handler *UserFiberHandler) Import(c *fiber.Ctx) error {
+	var importUserModel publicModel.ImportUserModel
+	if err := c.BodyParser(&importUserModel); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
 
+	user, err := handler.UserService.Import(requestContext(c), &importUserModel)
 	if err != nil {
+		var serviceError *common_error.ServiceError
+		switch {
+		case errors.Is(err, service.ErrUnsupportedPasswordHash), errors.Is(err, service.ErrInvalidProfile):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.As(err, &serviceError) && serviceError.Code == common_error.Conflict:
+			return fiber.NewError(fiber.StatusConflict, "Email or username is already taken")
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, "Something went wrong")
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(user)
+	return c.Status(fiber.StatusCreated).JSON(user.ToPublicUserModel())
+}
+
+func (handler *UserFiberHandler) VerifyCredentials(c *fiber.Ctx) error {
+	var verifyCredentialsModel publicModel.VerifyCredentialsModel
+	if err := c.BodyParser(&verifyCredentialsModel); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	user, err := handler.UserService.VerifyCredentials(requestContext(c), &verifyCredentialsModel)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			return fiber.NewError(fiber.StatusUnauthorized, "Invalid credentials")
+		}
+		return lockoutError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(user.ToAccountUserModel(time.Now()))
 }
 
 func (handler *UserFiberHandler) FindByIdentifierPublic(c *fiber.Ctx) error {
 	userIdentifier := c.Params("user_identifier")
 
-	user, err := handler.UserService.FindByIdentifier(c.Context(), userIdentifier)
+	ctx := requestContext(c)
+	user, err := handler.UserService.FindByIdentifier(ctx, userIdentifier)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Something went wrong")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(user.ToPublicUserModelFor(service.ActorFromContext(ctx).Audience()))
+}
+
+func (handler *UserFiberHandler) BatchFindByIdentifierPublic(c *fiber.Ctx) error {
+	var batchIdentifierModel publicModel.BatchIdentifierModel
+	if err := c.BodyParser(&batchIdentifierModel); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	ctx := requestContext(c)
+	users, err := handler.UserService.BatchFindByIdentifier(ctx, batchIdentifierModel.Identifiers)
 	if err != nil {
+		if errors.Is(err, service.ErrBatchTooLarge) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, "Something went wrong")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(user)
+	response := publicModel.BatchPublicUserModel{
+		Results: make([]publicModel.BatchPublicUserResultModel, len(users)),
+	}
+	for i, user := range users {
+		response.Results[i].Identifier = batchIdentifierModel.Identifiers[i]
+		if user != nil {
+			response.Results[i].Found = true
+			response.Results[i].User = user.ToPublicUserModelFor(service.ActorFromContext(ctx).Audience())
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
 }
 
 func (handler *UserFiberHandler) FindByIdentifierPrivate(c *fiber.Ctx) error {
 	userIdentifier := c.Params("user_identifier")
 
-	// Check if the user is authorized to access this resource
-	user_id, ok := c.Locals("user_id").(string)
-	if !ok || user_id == "" || user_id != "-1" {
-		return fiber.NewError(fiber.StatusUnauthorized, "Unauthorizedd")
+	user, err := handler.UserService.FindByIdentifierPrivate(requestContext(c), userIdentifier)
+	if err != nil {
+		if errors.Is(err, authz.ErrForbidden) {
+			return fiber.NewError(fiber.StatusForbidden, "Forbidden")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, "Something went wrong")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(user.ToAccountUserModel(time.Now()))
+}
+
+func (handler *UserFiberHandler) FindMe(c *fiber.Ctx) error {
+	userId, _ := c.Locals("user_id").(string)
+
+	user, err := handler.UserService.FindById(requestContext(c), userId)
+	if err != nil {
+		return selfServiceError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(user.ToSelfUserModel())
+}
+
+func (handler *UserFiberHandler) UpdateMe(c *fiber.Ctx) error {
+	var updateUserModel publicModel.UpdateUserModel
+	if err := c.BodyParser(&updateUserModel); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	user, err := handler.UserService.FindByIdentifier(c.Context(), userIdentifier)
+	userId, _ := c.Locals("user_id").(string)
+	user, err := handler.UserService.Update(requestContext(c), userId, &updateUserModel)
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, "Something went wrong")
+		return selfServiceError(err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(user.ToSelfUserModel())
+}
+
+func (handler *UserFiberHandler) DeleteMe(c *fiber.Ctx) error {
+	var deleteUserModel publicModel.DeleteUserModel
+	if err := c.BodyParser(&deleteUserModel); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	userId, _ := c.Locals("user_id").(string)
+	if err := handler.UserService.Delete(requestContext(c), userId, deleteUserModel.Password); err != nil {
+		return selfServiceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (handler *UserFiberHandler) ResetPassword(c *fiber.Ctx) error {
+	var resetPasswordModel publicModel.ResetPasswordModel
+	if err := c.BodyParser(&resetPasswordModel); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(user)
+	if err := handler.UserService.ResetPassword(requestContext(c), c.Params("user_id"), resetPasswordModel.Password); err != nil {
+		return selfServiceError(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// lockoutError maps refusals by the lockout service, setting Retry-After when throttled.
+func lockoutError(c *fiber.Ctx, err error) error {
+	var throttled *service.LoginThrottledError
+	switch {
+	case errors.Is(err, service.ErrAccountLocked):
+		return fiber.NewError(fiber.StatusLocked, err.Error())
+	case errors.As(err, &throttled):
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		return fiber.NewError(fiber.StatusTooManyRequests, err.Error())
+	}
+	return fiber.NewError(fiber.StatusInternalServerError, "Something went wrong")
+}
+
+// selfServiceError maps errors from the /me and password reset handlers to HTTP responses.
+func selfServiceError(err error) error {
+	var serviceError *common_error.ServiceError
+	switch {
+	case errors.Is(err, service.ErrInvalidUserUpdate), errors.Is(err, service.ErrInvalidProfile), errors.Is(err, passwordpolicy.ErrWeakPassword):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrPasswordConfirmation), errors.Is(err, authz.ErrForbidden):
+		return fiber.NewError(fiber.StatusForbidden, err.Error())
+	case errors.As(err, &serviceError) && serviceError.Code == common_error.NotFound:
+		return fiber.NewError(fiber.StatusNotFound, "User not found")
+	case errors.As(err, &serviceError) && serviceError.Code == common_error.Conflict:
+		return fiber.NewError(fiber.StatusConflict, "Email or username is already taken")
+	}
+	return fiber.NewError(fiber.StatusInternalServerError, "Something went wrong")
 }
@@ -1,14 +1,28 @@
 package fiberserver
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/metrics"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"go.opentelemetry.io/otel/trace"
+)
 
 type UserFiberServer struct {
 	App *fiber.App
 }
 
-func NewUserFiberServer(config fiber.Config) *UserFiberServer {
+func NewUserFiberServer(config fiber.Config, logger *slog.Logger, tracerProvider trace.TracerProvider) *UserFiberServer {
+	app := fiber.New(config)
+	app.Use(TracingMiddleware(tracerProvider))
+	app.Use(AccessLogMiddleware(logger))
+	app.Use(MetricsMiddleware(metrics.HTTP))
+	app.Use(RecoveryMiddleware(logger, metrics.Panics.WithLabelValues(metrics.TransportHTTP)))
 	return &UserFiberServer{
-		App: fiber.New(config),
+		App: app,
 	}
 }
 
@@ -20,11 +34,37 @@ func (server *UserFiberServer) Stop() error {
 	return server.App.Shutdown()
 }
 
-func (server *UserFiberServer) SetupRoutes(userHandler *UserFiberHandler, authMiddleware func(c *fiber.Ctx) error) {
+func (server *UserFiberServer) SetupRoutes(userHandler *UserFiberHandler, auditHandler *AuditFiberHandler, lockoutHandler *LockoutFiberHandler, mfaHandler *MFAFiberHandler, privacyHandler *PrivacyFiberHandler, authMiddleware func(c *fiber.Ctx) error, authorizer authz.IAuthorizer) {
 	private := server.App.Group("/private")
-	private.Use(authMiddleware)
-	private.Get("/user/:user_identifier", userHandler.FindByIdentifierPrivate)
-	private.Post("/user", userHandler.Create)
+	private.Use(authMiddleware, TenantMiddleware)
+	private.Get("/user/:user_identifier", RequirePermission(authorizer, authz.Requirement{Any: authz.PermissionReadPrivate, Self: authz.PermissionReadSelf}), userHandler.FindByIdentifierPrivate)
+	private.Post("/user", RequirePermission(authorizer, authz.Requirement{Any: authz.PermissionCreate}), userHandler.Create)
+	private.Post("/user/import", RequirePermission(authorizer, authz.Requirement{Any: authz.PermissionImport}), userHandler.Import)
+	private.Put("/user/:user_id/password", RequirePermission(authorizer, authz.Requirement{Any: authz.PermissionUpdate}), userHandler.ResetPassword)
+	private.Post("/user/verify", RequirePermission(authorizer, authz.Requirement{Any: authz.PermissionVerifyCredentials}), userHandler.VerifyCredentials)
+	private.Post("/user/:user_id/unlock", RequirePermission(authorizer, authz.Requirement{Any: authz.PermissionUnlock}), lockoutHandler.Unlock)
+	private.Get("/user/:user_id/logins", RequirePermission(authorizer, authz.Requirement{Any: authz.PermissionReadLoginHistory}), lockoutHandler.History)
+	private.Post("/user/:user_id/mfa/verify", RequirePermission(authorizer, authz.Requirement{Any: authz.PermissionVerifyMFA}), mfaHandler.Verify)
+	private.Delete("/user/:user_id/mfa", RequirePermission(authorizer, authz.Requirement{Any: authz.PermissionManageMFA}), mfaHandler.Disable)
+	private.Get("/user/:user_id/export", RequirePermission(authorizer, authz.Requirement{Any: authz.PermissionExportData}), privacyHandler.Export)
+	private.Post("/user/:user_id/erase", RequirePermission(authorizer, authz.Requirement{Any: authz.PermissionErase}), privacyHandler.Erase)
+	private.Get("/audit", RequirePermission(authorizer, authz.Requirement{Any: authz.PermissionReadAudit}), auditHandler.List)
+
+	me := server.App.Group("/me")
+	me.Use(authMiddleware, TenantMiddleware)
+	me.Get("", RequirePermission(authorizer, authz.Requirement{Self: authz.PermissionReadSelf}), userHandler.FindMe)
+	me.Patch("", RequirePermission(authorizer, authz.Requirement{Self: authz.PermissionUpdateSelf}), userHandler.UpdateMe)
+	me.Delete("", RequirePermission(authorizer, authz.Requirement{Self: authz.PermissionDeleteSelf}), userHandler.DeleteMe)
+	me.Post("/mfa", RequirePermission(authorizer, authz.Requirement{Self: authz.PermissionManageMFASelf}), mfaHandler.EnrollMe)
+	me.Post("/mfa/confirm", RequirePermission(authorizer, authz.Requirement{Self: authz.PermissionManageMFASelf}), mfaHandler.ConfirmMe)
+	me.Delete("/mfa", RequirePermission(authorizer, authz.Requirement{Self: authz.PermissionManageMFASelf}), mfaHandler.DisableMe)
+
+	server.App.Get("/user/:user_identifier", TenantMiddleware, userHandler.FindByIdentifierPublic)
+	server.App.Post("/users/batch", TenantMiddleware, userHandler.BatchFindByIdentifierPublic)
+}
 
-	server.App.Get("/user/:user_identifier", userHandler.FindByIdentifierPublic)
+// SetupGateway serves the HTTP/JSON routes transcoded to gRPC under prefix, alongside the routes above.
+// The gateway handles authentication and tenancy through the gRPC interceptors.
+func (server *UserFiberServer) SetupGateway(prefix string, handler http.Handler) {
+	server.App.Use(prefix, adaptor.HTTPHandler(handler))
 }
@@ -0,0 +1,55 @@
+// Package gateway serves the HTTP/JSON routes annotated on the v2 API by transcoding them to gRPC.
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/textproto"
+
+	pbv2 "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb/v2"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// PathPrefix is the prefix of every route annotated on the v2 API.
+const PathPrefix = "/v2"
+
+// forwardedHeaders maps the HTTP headers of the hand-written routes to the metadata keys the gRPC
+// interceptors read. Authorization is forwarded by the gateway itself.
+var forwardedHeaders = map[string]string{
+	"X-Request-Id":    "x-request-id",
+	"Idempotency-Key": "idempotency-key",
+	"X-Tenant-Id":     "x-tenant-id",
+	"Traceparent":     "traceparent",
+}
+
+// HeaderMatcher forwards the headers in forwardedHeaders, and otherwise behaves like the gateway default.
+func HeaderMatcher(key string) (string, bool) {
+	if metadataKey, ok := forwardedHeaders[textproto.CanonicalMIMEHeaderKey(key)]; ok {
+		return metadataKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// NewHandler returns a handler for the v2 HTTP/JSON routes that calls the gRPC server at endpoint.
+// Going through the server rather than calling it in process keeps authentication, tenancy and
+// authorization in the interceptors, the same as for gRPC clients. JSON uses the proto field names,
+// like the hand-written routes.
+func NewHandler(ctx context.Context, endpoint string) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(HeaderMatcher),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+	)
+
+	dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if err := pbv2.RegisterUserServiceHandlerFromEndpoint(ctx, mux, endpoint, dialOptions); err != nil {
+		return nil, err
+	}
+
+	return mux, nil
+}
@@ -0,0 +1,111 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/gateway"
+	pbv2 "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeUserServer answers GetPublicUserByIdentifier and records the metadata it was called with.
+type fakeUserServer struct {
+	pbv2.UnimplementedUserServiceServer
+	metadata metadata.MD
+	request  *pbv2.GetPublicUserByIdentifierRequest
+}
+
+func (server *fakeUserServer) GetPublicUserByIdentifier(ctx context.Context, req *pbv2.GetPublicUserByIdentifierRequest) (*pbv2.PublicUser, error) {
+	server.metadata, _ = metadata.FromIncomingContext(ctx)
+	server.request = req
+	return &pbv2.PublicUser{
+		Id:        "1",
+		Username:  req.UserIdentifier,
+		CreatedAt: timestamppb.New(time.Date(2023, 11, 1, 12, 0, 0, 0, time.UTC)),
+	}, nil
+}
+
+// startGateway serves fake on a local gRPC server and returns a gateway handler for it.
+func startGateway(t *testing.T, fake *fakeUserServer) http.Handler {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	gRPCServer := grpc.NewServer()
+	pbv2.RegisterUserServiceServer(gRPCServer, fake)
+	go gRPCServer.Serve(listener)
+	t.Cleanup(gRPCServer.Stop)
+
+	handler, err := gateway.NewHandler(context.Background(), listener.Addr().String())
+	require.NoError(t, err)
+	return handler
+}
+
+func TestHeaderMatcher(t *testing.T) {
+	// Setup
+	cases := map[string]string{
+		"X-Request-ID":    "x-request-id",
+		"idempotency-key": "idempotency-key",
+		"X-Tenant-ID":     "x-tenant-id",
+		"traceparent":     "traceparent",
+		"Content-Type":    "grpcgateway-Content-Type",
+	}
+
+	for header, expected := range cases {
+		// Test
+		key, ok := gateway.HeaderMatcher(header)
+
+		// Assert
+		assert.True(t, ok, header)
+		assert.Equal(t, expected, key, header)
+	}
+
+	_, ok := gateway.HeaderMatcher("X-Unknown")
+	assert.False(t, ok)
+}
+
+func TestHandler_TranscodesToGRPC(t *testing.T) {
+	// Setup
+	fake := &fakeUserServer{}
+	handler := startGateway(t, fake)
+	req := httptest.NewRequest(http.MethodGet, "/v2/user/test", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Tenant-ID", "acme")
+	req.Header.Set("X-Request-ID", "request-1")
+	recorder := httptest.NewRecorder()
+
+	// Test
+	handler.ServeHTTP(recorder, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, "test", body["username"])
+	assert.Equal(t, "2023-11-01T12:00:00Z", body["created_at"])
+	assert.Contains(t, body, "updated_at")
+	assert.Equal(t, "test", fake.request.UserIdentifier)
+	assert.Equal(t, []string{"Bearer token"}, fake.metadata.Get("authorization"))
+	assert.Equal(t, []string{"acme"}, fake.metadata.Get("x-tenant-id"))
+	assert.Equal(t, []string{"request-1"}, fake.metadata.Get("x-request-id"))
+}
+
+func TestHandler_UnimplementedMethod(t *testing.T) {
+	// Setup
+	handler := startGateway(t, &fakeUserServer{})
+	recorder := httptest.NewRecorder()
+
+	// Test
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v2/me", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusNotImplemented, recorder.Code)
+}
@@ -0,0 +1,87 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AccessLogUnaryInterceptor correlates each call and logs it once handled, with its code and latency.
+// Calls without x-request-id metadata are given one, which is sent back in the response header, and the
+// trace id is taken from the current span, or else from the traceparent metadata. Both are attached to the
+// context so that later records carry them. It must be the first interceptor but TracingUnaryInterceptor,
+// so that it also logs the errors of recovered panics.
+func AccessLogUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx = withCorrelation(ctx)
+
+		resp, err := handler(ctx, req)
+
+		logCall(ctx, logger, info.FullMethod, err, start)
+		return resp, err
+	}
+}
+
+// AccessLogStreamInterceptor is the streaming equivalent of AccessLogUnaryInterceptor.
+func AccessLogStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := withCorrelation(ss.Context())
+
+		err := handler(srv, withStreamContext(ss, ctx))
+
+		logCall(ctx, logger, info.FullMethod, err, start)
+		return err
+	}
+}
+
+// withCorrelation attaches the correlation of a call to its context, adding a request id to the incoming
+// metadata when the client sent none so that the actor carries it too.
+func withCorrelation(ctx context.Context) context.Context {
+	requestID := requestIDFromContext(ctx)
+	if requestID == "" {
+		requestID = logging.NewRequestID()
+		md, _ := metadata.FromIncomingContext(ctx)
+		md = md.Copy()
+		md.Set(RequestIDMetadataKey, requestID)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	// Fails only outside of a server transport, where there is nobody to tell
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, requestID))
+
+	var traceparent string
+	if values := metadata.ValueFromIncomingContext(ctx, logging.TraceparentHeader); len(values) > 0 {
+		traceparent = values[0]
+	}
+	return logging.WithCorrelation(ctx, logging.Correlation{RequestID: requestID, TraceID: logging.TraceID(ctx, traceparent)})
+}
+
+// logCall writes the access log record of a call, at error level for server faults.
+func logCall(ctx context.Context, logger *slog.Logger, method string, err error, start time.Time) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	if isServerFault(code) {
+		level = slog.LevelError
+	}
+	logger.LogAttrs(ctx, level, "call",
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("latency", time.Since(start)),
+	)
+}
+
+// isServerFault tells whether a code reports a fault of the server rather than of the request.
+func isServerFault(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.Unimplemented, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
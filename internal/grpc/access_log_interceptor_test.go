@@ -0,0 +1,91 @@
+package grpcserver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	grpcserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/grpc"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAccessLogUnaryInterceptor(t *testing.T) {
+	// Setup
+	var logs bytes.Buffer
+	interceptor := grpcserver.AccessLogUnaryInterceptor(logging.NewLogger(&logs, slog.LevelInfo))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		grpcserver.RequestIDMetadataKey, "request-1",
+		logging.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	))
+	var seen logging.Correlation
+
+	// Test
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/UserService/GetPrivateUserByIdentifier"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen = logging.CorrelationFromContext(ctx)
+		return nil, status.Error(codes.Internal, "Internal error")
+	})
+
+	// Assert
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, logging.Correlation{RequestID: "request-1", TraceID: "4bf92f3577b34da6a3ce929d0e0e4736"}, seen)
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &record))
+	assert.Equal(t, "call", record["msg"])
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, "/UserService/GetPrivateUserByIdentifier", record["method"])
+	assert.Equal(t, "Internal", record["code"])
+	assert.Contains(t, record, "latency")
+	assert.Equal(t, "request-1", record[logging.RequestIDKey])
+}
+
+func TestAccessLogUnaryInterceptor_GeneratesRequestID(t *testing.T) {
+	// Setup
+	var logs bytes.Buffer
+	interceptor := grpcserver.AccessLogUnaryInterceptor(logging.NewLogger(&logs, slog.LevelInfo))
+	var seen logging.Correlation
+	var actor service.Actor
+
+	// Test
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/UserService/GetPublicUserByIdentifier"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen = logging.CorrelationFromContext(ctx)
+		return grpcserver.ActorUnaryInterceptor(ctx, req, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			actor = service.ActorFromContext(ctx)
+			return nil, nil
+		})
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, seen.RequestID, 32)
+	assert.Equal(t, seen.RequestID, actor.RequestID)
+	assert.Contains(t, logs.String(), `"level":"INFO"`)
+	assert.Contains(t, logs.String(), `"code":"OK"`)
+}
+
+func TestAccessLogStreamInterceptor(t *testing.T) {
+	// Setup
+	var logs bytes.Buffer
+	interceptor := grpcserver.AccessLogStreamInterceptor(logging.NewLogger(&logs, slog.LevelInfo))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcserver.RequestIDMetadataKey, "request-1"))
+	var seen logging.Correlation
+
+	// Test
+	err := interceptor(nil, &MockContextServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/UserService/WatchUsers"}, func(srv interface{}, stream grpc.ServerStream) error {
+		seen = logging.CorrelationFromContext(stream.Context())
+		return status.Error(codes.PermissionDenied, "Forbidden")
+	})
+
+	// Assert
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, "request-1", seen.RequestID)
+	assert.Contains(t, logs.String(), `"method":"/UserService/WatchUsers","code":"PermissionDenied"`)
+}
@@ -0,0 +1,73 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// RequestIDMetadataKey is the metadata key clients use to correlate a call with their own logs.
+	RequestIDMetadataKey = "x-request-id"
+	// IdempotencyKeyMetadataKey lets clients retry user creation safely.
+	IdempotencyKeyMetadataKey = "idempotency-key"
+	// ForwardedForMetadataKey carries the client address of calls transcoded by the HTTP gateway, which
+	// appends the address it received the request from.
+	ForwardedForMetadataKey = "x-forwarded-for"
+)
+
+// ActorUnaryInterceptor attaches the caller to the context so that services can attribute their actions.
+// It must run after the auth interceptor, which stores the token's user_id under the "user_id" context key.
+func ActorUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(service.WithActor(ctx, actorFromContext(ctx)), req)
+}
+
+// ActorStreamInterceptor is the streaming equivalent of ActorUnaryInterceptor.
+func ActorStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, withStreamContext(ss, service.WithActor(ss.Context(), actorFromContext(ss.Context()))))
+}
+
+func actorFromContext(ctx context.Context) service.Actor {
+	var actor service.Actor
+	actor.UserID, _ = ctx.Value("user_id").(string)
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		actor.SourceIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(actor.SourceIP); err == nil {
+			actor.SourceIP = host
+		}
+	}
+
+	actor.RequestID = requestIDFromContext(ctx)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		// Only the gateway, which runs in process, may speak for the client; earlier entries are client supplied
+		if values := md.Get(ForwardedForMetadataKey); len(values) > 0 && isLoopback(actor.SourceIP) {
+			entries := strings.Split(values[len(values)-1], ",")
+			if forwarded := strings.TrimSpace(entries[len(entries)-1]); forwarded != "" {
+				actor.SourceIP = forwarded
+			}
+		}
+	}
+
+	return actor
+}
+
+func isLoopback(host string) bool {
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// requestIDFromContext returns the request id the client sent with the call, if any.
+func requestIDFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package grpcserver_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	grpcserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/grpc"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestActorUnaryInterceptor(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "user_id", "42")
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(grpcserver.RequestIDMetadataKey, "request-1"))
+
+	var actor service.Actor
+	_, err := grpcserver.ActorUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		actor = service.ActorFromContext(ctx)
+		return nil, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, service.Actor{UserID: "42", SourceIP: "10.0.0.1", RequestID: "request-1"}, actor)
+}
+
+func TestActorUnaryInterceptor_ForwardedByGateway(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 5000}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(grpcserver.ForwardedForMetadataKey, "192.0.2.1, 203.0.113.7"))
+
+	var actor service.Actor
+	_, err := grpcserver.ActorUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		actor = service.ActorFromContext(ctx)
+		return nil, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", actor.SourceIP)
+}
+
+func TestActorUnaryInterceptor_ForwardedByRemotePeerIgnored(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(grpcserver.ForwardedForMetadataKey, "203.0.113.7"))
+
+	var actor service.Actor
+	_, err := grpcserver.ActorUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		actor = service.ActorFromContext(ctx)
+		return nil, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", actor.SourceIP)
+}
@@ -0,0 +1,63 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorizationUnaryInterceptor enforces the requirement declared for each method and hands the
+// granted scope to the handler through the context. Public methods are skipped, and any other
+// method without a declared requirement is refused. It must run after ActorUnaryInterceptor.
+func AuthorizationUnaryInterceptor(authorizer authz.IAuthorizer, publicMethods map[string]struct{}, methodRequirements map[string]authz.Requirement) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authorize(ctx, info.FullMethod, authorizer, publicMethods, methodRequirements)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthorizationStreamInterceptor is the streaming equivalent of AuthorizationUnaryInterceptor.
+func AuthorizationStreamInterceptor(authorizer authz.IAuthorizer, publicMethods map[string]struct{}, methodRequirements map[string]authz.Requirement) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), info.FullMethod, authorizer, publicMethods, methodRequirements)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, withStreamContext(ss, ctx))
+	}
+}
+
+// authorize returns the context of an authorized call to method, carrying the granted scope.
+func authorize(ctx context.Context, method string, authorizer authz.IAuthorizer, publicMethods map[string]struct{}, methodRequirements map[string]authz.Requirement) (context.Context, error) {
+	if _, ok := publicMethods[method]; ok {
+		return ctx, nil
+	}
+
+	requirement, ok := methodRequirements[method]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "Method has no declared permission")
+	}
+
+	scope, err := authorizer.Authorize(ctx, service.ActorFromContext(ctx).UserID, requirement)
+	if err != nil {
+		switch {
+		case errors.Is(err, authz.ErrUnauthenticated):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case errors.Is(err, authz.ErrForbidden):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, err
+	}
+
+	return authz.WithScope(ctx, scope), nil
+}
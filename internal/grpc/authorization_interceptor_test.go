@@ -0,0 +1,99 @@
+package grpcserver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	grpcserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/grpc"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MockIAuthorizer struct {
+	mock.Mock
+}
+
+func (m *MockIAuthorizer) Authorize(ctx context.Context, userID string, requirement authz.Requirement) (authz.Scope, error) {
+	args := m.Called(ctx, userID, requirement)
+	return args.Get(0).(authz.Scope), args.Error(1)
+}
+
+var (
+	publicMethods      = map[string]struct{}{"/public": {}}
+	methodRequirements = map[string]authz.Requirement{
+		"/private": {Any: authz.PermissionReadPrivate, Self: authz.PermissionReadSelf},
+	}
+)
+
+func TestAuthorizationUnaryInterceptor_PassesScope(t *testing.T) {
+	// Arrange
+	mockAuthorizer := new(MockIAuthorizer)
+	interceptor := grpcserver.AuthorizationUnaryInterceptor(mockAuthorizer, publicMethods, methodRequirements)
+	ctx := service.WithActor(context.Background(), service.Actor{UserID: "1"})
+	mockAuthorizer.On("Authorize", ctx, "1", methodRequirements["/private"]).Return(authz.Scope{UserID: "1", SelfOnly: true}, nil)
+
+	// Act
+	var scope authz.Scope
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/private"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		scope, _ = authz.ScopeFromContext(ctx)
+		return nil, nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, authz.Scope{UserID: "1", SelfOnly: true}, scope)
+}
+
+func TestAuthorizationUnaryInterceptor_SkipsPublicMethods(t *testing.T) {
+	mockAuthorizer := new(MockIAuthorizer)
+	interceptor := grpcserver.AuthorizationUnaryInterceptor(mockAuthorizer, publicMethods, methodRequirements)
+
+	called := false
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/public"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, called)
+	mockAuthorizer.AssertNotCalled(t, "Authorize", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthorizationUnaryInterceptor_DeniesUndeclaredMethods(t *testing.T) {
+	mockAuthorizer := new(MockIAuthorizer)
+	interceptor := grpcserver.AuthorizationUnaryInterceptor(mockAuthorizer, publicMethods, methodRequirements)
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/undeclared"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		t.Fatal("handler must not run")
+		return nil, nil
+	})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuthorizationUnaryInterceptor_MapsErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{authz.ErrUnauthenticated, codes.Unauthenticated},
+		{authz.ErrForbidden, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		mockAuthorizer := new(MockIAuthorizer)
+		interceptor := grpcserver.AuthorizationUnaryInterceptor(mockAuthorizer, publicMethods, methodRequirements)
+		mockAuthorizer.On("Authorize", mock.Anything, "", mock.Anything).Return(authz.Scope{}, tt.err)
+
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/private"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+
+		assert.Equal(t, tt.code, status.Code(err))
+	}
+}
@@ -0,0 +1,36 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsUnaryInterceptor records the RED metrics of each call by full method name and code.
+// It must come before RecoveryUnaryInterceptor, so that it also records recovered panics.
+func MetricsUnaryInterceptor(red *metrics.RED) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		red.Observe(info.FullMethod, status.Code(err).String(), start)
+		return resp, err
+	}
+}
+
+// MetricsStreamInterceptor is the streaming equivalent of MetricsUnaryInterceptor. The duration of a
+// stream is its lifetime.
+func MetricsStreamInterceptor(red *metrics.RED) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		red.Observe(info.FullMethod, status.Code(err).String(), start)
+		return err
+	}
+}
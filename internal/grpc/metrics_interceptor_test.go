@@ -0,0 +1,52 @@
+package grpcserver_test
+
+import (
+	"context"
+	"testing"
+
+	grpcserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/grpc"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetricsUnaryInterceptor(t *testing.T) {
+	// Setup
+	red := metrics.NewRED(prometheus.NewRegistry(), metrics.TransportGRPC, "method", "code")
+	interceptor := grpcserver.MetricsUnaryInterceptor(red)
+	info := &grpc.UnaryServerInfo{FullMethod: "/UserService/CreateUser"}
+
+	// Test
+	_, okErr := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	_, conflictErr := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.AlreadyExists, "User creation failed")
+	})
+
+	// Assert
+	assert.NoError(t, okErr)
+	assert.Equal(t, codes.AlreadyExists, status.Code(conflictErr))
+	assert.Equal(t, float64(1), testutil.ToFloat64(red.Requests.WithLabelValues("/UserService/CreateUser", "OK")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(red.Requests.WithLabelValues("/UserService/CreateUser", "AlreadyExists")))
+	assert.Equal(t, 1, testutil.CollectAndCount(red.Duration))
+}
+
+func TestMetricsStreamInterceptor(t *testing.T) {
+	// Setup
+	red := metrics.NewRED(prometheus.NewRegistry(), metrics.TransportGRPC, "method", "code")
+	interceptor := grpcserver.MetricsStreamInterceptor(red)
+
+	// Test
+	err := interceptor(nil, &MockServerStream{}, &grpc.StreamServerInfo{FullMethod: "/UserService/WatchUsers"}, func(srv interface{}, stream grpc.ServerStream) error {
+		return status.Error(codes.Canceled, "context canceled")
+	})
+
+	// Assert
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	assert.Equal(t, float64(1), testutil.ToFloat64(red.Requests.WithLabelValues("/UserService/WatchUsers", "Canceled")))
+}
@@ -0,0 +1,52 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryUnaryInterceptor turns a panic in a handler or a later interceptor into an Internal error,
+// logging the stack with the request id and counting it in panics. It must come right after
+// AccessLogUnaryInterceptor and MetricsUnaryInterceptor.
+func RecoveryUnaryInterceptor(logger *slog.Logger, panics prometheus.Counter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = recoverPanic(ctx, info.FullMethod, recovered, logger, panics)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor is the streaming equivalent of RecoveryUnaryInterceptor.
+func RecoveryStreamInterceptor(logger *slog.Logger, panics prometheus.Counter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = recoverPanic(ss.Context(), info.FullMethod, recovered, logger, panics)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+// recoverPanic reports a recovered panic and returns the error sent to the client, which reveals nothing about it.
+func recoverPanic(ctx context.Context, method string, recovered interface{}, logger *slog.Logger, panics prometheus.Counter) error {
+	logger.ErrorContext(ctx, "panic",
+		"method", method,
+		"panic", fmt.Sprint(recovered),
+		"stack", string(debug.Stack()),
+	)
+	panics.Inc()
+	return status.Error(codes.Internal, "Internal error")
+}
@@ -0,0 +1,72 @@
+package grpcserver_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	grpcserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/grpc"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryUnaryInterceptor(t *testing.T) {
+	// Setup
+	var logs bytes.Buffer
+	panics := prometheus.NewCounter(prometheus.CounterOpts{Name: "panics_total"})
+	interceptor := grpcserver.RecoveryUnaryInterceptor(logging.NewLogger(&logs, slog.LevelInfo), panics)
+	ctx := logging.WithCorrelation(context.Background(), logging.Correlation{RequestID: "request-1"})
+
+	// Test
+	resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/UserService/GetPrivateUserByIdentifier"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		var user *struct{ ID string }
+		return user.ID, nil
+	})
+
+	// Assert
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.NotContains(t, status.Convert(err).Message(), "nil pointer")
+	assert.Equal(t, float64(1), testutil.ToFloat64(panics))
+	assert.Contains(t, logs.String(), `"method":"/UserService/GetPrivateUserByIdentifier"`)
+	assert.Contains(t, logs.String(), `"request_id":"request-1"`)
+	assert.Contains(t, logs.String(), "recovery_interceptor_test.go")
+}
+
+func TestRecoveryUnaryInterceptor_PassesErrors(t *testing.T) {
+	// Setup
+	panics := prometheus.NewCounter(prometheus.CounterOpts{Name: "panics_total"})
+	interceptor := grpcserver.RecoveryUnaryInterceptor(logging.NewLogger(&bytes.Buffer{}, slog.LevelInfo), panics)
+
+	// Test
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "User not found")
+	})
+
+	// Assert
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, float64(0), testutil.ToFloat64(panics))
+}
+
+func TestRecoveryStreamInterceptor(t *testing.T) {
+	// Setup
+	var logs bytes.Buffer
+	panics := prometheus.NewCounter(prometheus.CounterOpts{Name: "panics_total"})
+	interceptor := grpcserver.RecoveryStreamInterceptor(logging.NewLogger(&logs, slog.LevelInfo), panics)
+
+	// Test
+	err := interceptor(nil, &MockServerStream{}, &grpc.StreamServerInfo{FullMethod: "/UserService/WatchUsers"}, func(srv interface{}, stream grpc.ServerStream) error {
+		panic("boom")
+	})
+
+	// Assert
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, float64(1), testutil.ToFloat64(panics))
+	assert.Contains(t, logs.String(), `"method":"/UserService/WatchUsers","panic":"boom"`)
+}
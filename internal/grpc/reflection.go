@@ -0,0 +1,13 @@
+package grpcserver
+
+import (
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// ReflectionMethods are the methods of the server reflection service, in both versions that
+// UserGrpcServer registers when Reflection is set. They need a declared requirement like any other method.
+var ReflectionMethods = []string{
+	reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName,
+	reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+}
@@ -0,0 +1,35 @@
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// StreamFromUnaryInterceptor runs a unary interceptor around a streaming handler, so that
+// authentication and error mapping from the common library, which are unary only, also guard streaming RPCs.
+// The wrapped interceptor receives a nil request and the stream sees any context it derives.
+func StreamFromUnaryInterceptor(interceptor grpc.UnaryServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		unaryInfo := &grpc.UnaryServerInfo{Server: srv, FullMethod: info.FullMethod}
+		_, err := interceptor(ss.Context(), nil, unaryInfo, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			return nil, handler(srv, withStreamContext(ss, ctx))
+		})
+		return err
+	}
+}
+
+// withStreamContext returns the stream with its context replaced by ctx.
+func withStreamContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &contextServerStream{ServerStream: ss, ctx: ctx}
+}
+
+// contextServerStream overrides the context of a grpc.ServerStream.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,181 @@
+package grpcserver_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	grpcserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/grpc"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type contextKey string
+
+type MockServerStream struct {
+	grpc.ServerStream
+}
+
+func (m *MockServerStream) Context() context.Context {
+	return context.Background()
+}
+
+// MockContextServerStream is a stream whose context the test sets.
+type MockContextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (m *MockContextServerStream) Context() context.Context {
+	return m.ctx
+}
+
+func TestStreamFromUnaryInterceptor_PassesContext(t *testing.T) {
+	var seenMethod string
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		seenMethod = info.FullMethod
+		return handler(context.WithValue(ctx, contextKey("user_id"), "1"), req)
+	}
+
+	var seenUserId interface{}
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		seenUserId = stream.Context().Value(contextKey("user_id"))
+		return nil
+	}
+
+	interceptor := grpcserver.StreamFromUnaryInterceptor(unary)
+	err := interceptor(nil, &MockServerStream{}, &grpc.StreamServerInfo{FullMethod: "/UserService/WatchUsers"}, handler)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/UserService/WatchUsers", seenMethod)
+	assert.Equal(t, "1", seenUserId)
+}
+
+func TestStreamFromUnaryInterceptor_Rejects(t *testing.T) {
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}
+
+	called := false
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		called = true
+		return nil
+	}
+
+	interceptor := grpcserver.StreamFromUnaryInterceptor(unary)
+	err := interceptor(nil, &MockServerStream{}, &grpc.StreamServerInfo{FullMethod: "/UserService/WatchUsers"}, handler)
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.False(t, called)
+}
+
+func TestActorStreamInterceptor(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "user_id", "42")
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+
+	var actor service.Actor
+	err := grpcserver.ActorStreamInterceptor(nil, &MockContextServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		actor = service.ActorFromContext(stream.Context())
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, service.Actor{UserID: "42", SourceIP: "10.0.0.1"}, actor)
+}
+
+func TestTenantStreamInterceptor(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcserver.TenantIDMetadataKey, "globex"))
+
+	var tenantID string
+	err := grpcserver.TenantStreamInterceptor(nil, &MockContextServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		tenantID = tenant.FromContext(stream.Context())
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "globex", tenantID)
+}
+
+func TestTenantStreamInterceptor_Mismatch(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "tenant_id", "acme")
+	ctx = service.WithActor(ctx, service.Actor{UserID: "1"})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(grpcserver.TenantIDMetadataKey, "globex"))
+
+	called := false
+	err := grpcserver.TenantStreamInterceptor(nil, &MockContextServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		called = true
+		return nil
+	})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.False(t, called)
+}
+
+func TestAuthorizationStreamInterceptor_PassesScope(t *testing.T) {
+	// Arrange
+	mockAuthorizer := new(MockIAuthorizer)
+	interceptor := grpcserver.AuthorizationStreamInterceptor(mockAuthorizer, publicMethods, methodRequirements)
+	ctx := service.WithActor(context.Background(), service.Actor{UserID: "1"})
+	mockAuthorizer.On("Authorize", ctx, "1", methodRequirements["/private"]).Return(authz.Scope{UserID: "1"}, nil)
+
+	// Act
+	var scope authz.Scope
+	err := interceptor(nil, &MockContextServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/private"}, func(srv interface{}, stream grpc.ServerStream) error {
+		scope, _ = authz.ScopeFromContext(stream.Context())
+		return nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, authz.Scope{UserID: "1"}, scope)
+}
+
+func TestAuthorizationStreamInterceptor_DeniesUndeclaredMethods(t *testing.T) {
+	interceptor := grpcserver.AuthorizationStreamInterceptor(new(MockIAuthorizer), publicMethods, methodRequirements)
+
+	err := interceptor(nil, &MockServerStream{}, &grpc.StreamServerInfo{FullMethod: "/undeclared"}, func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestNewServer_StreamInterceptors(t *testing.T) {
+	// Setup
+	var seenMethod string
+	reject := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		seenMethod = info.FullMethod
+		return status.Error(codes.Unauthenticated, "missing token")
+	}
+	server := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{}, reject)
+	listener := bufconn.Listen(1 << 20)
+	gRPCServer := server.NewServer()
+	go gRPCServer.Serve(listener)
+	defer gRPCServer.Stop()
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Test
+	stream, err := pb.NewUserServiceClient(conn).WatchUsers(context.Background(), &pb.WatchUsersRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+
+	// Assert
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, pb.UserService_WatchUsers_FullMethodName, seenMethod)
+}
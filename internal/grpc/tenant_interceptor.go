@@ -0,0 +1,60 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TenantIDMetadataKey names the tenant a call operates on, or tenant.AllTenants for cross-tenant mode.
+const TenantIDMetadataKey = "x-tenant-id"
+
+// TenantUnaryInterceptor scopes the call to the tenant from the token's tenant_id claim or the
+// x-tenant-id metadata. It must run after ActorUnaryInterceptor and before authorization, and marks calls
+// that cross into another tenant for it.
+func TenantUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := withTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// TenantStreamInterceptor is the streaming equivalent of TenantUnaryInterceptor.
+func TenantStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := withTenant(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, withStreamContext(ss, ctx))
+}
+
+func withTenant(ctx context.Context) (context.Context, error) {
+	claimed, _ := ctx.Value("tenant_id").(string)
+
+	var requested string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(TenantIDMetadataKey); len(values) > 0 {
+			requested = values[0]
+		}
+	}
+
+	authenticated := service.ActorFromContext(ctx).UserID != ""
+	tenantID, err := tenant.Resolve(claimed, requested, authenticated)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	ctx = tenant.WithTenant(ctx, tenantID)
+	if tenant.Crosses(claimed, tenantID, authenticated) {
+		ctx = tenant.WithCrossing(ctx)
+	}
+	return ctx, nil
+}
@@ -0,0 +1,71 @@
+package grpcserver_test
+
+import (
+	"context"
+	"testing"
+
+	grpcserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/grpc"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTenantUnaryInterceptor_ClaimedTenant(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "tenant_id", "acme")
+	ctx = service.WithActor(ctx, service.Actor{UserID: "1"})
+
+	var tenantID string
+	_, err := grpcserver.TenantUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		tenantID = tenant.FromContext(ctx)
+		return nil, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", tenantID)
+}
+
+func TestTenantUnaryInterceptor_MetadataTenant(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcserver.TenantIDMetadataKey, "globex"))
+
+	var tenantID string
+	_, err := grpcserver.TenantUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		tenantID = tenant.FromContext(ctx)
+		return nil, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "globex", tenantID)
+}
+
+func TestTenantUnaryInterceptor_Mismatch(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "tenant_id", "acme")
+	ctx = service.WithActor(ctx, service.Actor{UserID: "1"})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(grpcserver.TenantIDMetadataKey, "globex"))
+
+	_, err := grpcserver.TenantUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		t.Fatal("handler must not run")
+		return nil, nil
+	})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestTenantUnaryInterceptor_MetadataTenantWithoutTokenTenant(t *testing.T) {
+	ctx := service.WithActor(context.Background(), service.Actor{UserID: "1"})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(grpcserver.TenantIDMetadataKey, "globex"))
+
+	var tenantID string
+	var crossing bool
+	_, err := grpcserver.TenantUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		tenantID, crossing = tenant.FromContext(ctx), tenant.IsCrossing(ctx)
+		return nil, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "globex", tenantID)
+	assert.True(t, crossing)
+}
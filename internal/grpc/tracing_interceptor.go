@@ -0,0 +1,83 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataCarrier adapts incoming metadata to a propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+func (carrier metadataCarrier) Get(key string) string {
+	if values := metadata.MD(carrier).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (carrier metadataCarrier) Set(key string, value string) {
+	metadata.MD(carrier).Set(key, value)
+}
+
+func (carrier metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier))
+	for key := range carrier {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// TracingUnaryInterceptor traces each call as a server span named after its full method, continuing the
+// trace of its traceparent metadata. It must be the first interceptor.
+func TracingUnaryInterceptor(provider trace.TracerProvider) grpc.UnaryServerInterceptor {
+	tracer := provider.Tracer(tracing.InstrumentationName)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := startCallSpan(ctx, tracer, info.FullMethod)
+
+		resp, err := handler(ctx, req)
+
+		endCallSpan(span, err)
+		return resp, err
+	}
+}
+
+// TracingStreamInterceptor is the streaming equivalent of TracingUnaryInterceptor. The span lasts as long
+// as the stream.
+func TracingStreamInterceptor(provider trace.TracerProvider) grpc.StreamServerInterceptor {
+	tracer := provider.Tracer(tracing.InstrumentationName)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startCallSpan(ss.Context(), tracer, info.FullMethod)
+
+		err := handler(srv, withStreamContext(ss, ctx))
+
+		endCallSpan(span, err)
+		return err
+	}
+}
+
+func startCallSpan(ctx context.Context, tracer trace.Tracer, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return tracer.Start(tracing.Propagator.Extract(ctx, metadataCarrier(md)), fullMethod,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.RPCSystemGRPC, semconv.RPCService(service), semconv.RPCMethod(method)),
+	)
+}
+
+// endCallSpan ends the span of a call, marking it failed for server faults. Only the code is recorded,
+// since error messages may quote the request.
+func endCallSpan(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	if isServerFault(code) {
+		tracing.Fail(span, code.String())
+	}
+	span.End()
+}
@@ -0,0 +1,73 @@
+package grpcserver_test
+
+import (
+	"context"
+	"testing"
+
+	grpcserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/grpc"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTracingUnaryInterceptor(t *testing.T) {
+	// Setup
+	exporter := tracetest.NewInMemoryExporter()
+	interceptor := grpcserver.TracingUnaryInterceptor(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		logging.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	))
+	var seen trace.SpanContext
+
+	// Test
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/UserService/CreateUser"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen = trace.SpanContextFromContext(ctx)
+		return nil, status.Error(codes.AlreadyExists, "User test@mail.com already exists")
+	})
+
+	// Assert
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "/UserService/CreateUser", span.Name)
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+	assert.Equal(t, span.SpanContext, seen)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent.SpanID().String())
+	assert.Contains(t, span.Attributes, semconv.RPCService("UserService"))
+	assert.Contains(t, span.Attributes, semconv.RPCMethod("CreateUser"))
+	assert.Contains(t, span.Attributes, semconv.RPCGRPCStatusCodeKey.Int(int(codes.AlreadyExists)))
+	assert.Equal(t, otelcodes.Unset, span.Status.Code)
+}
+
+func TestTracingStreamInterceptor(t *testing.T) {
+	// Setup
+	exporter := tracetest.NewInMemoryExporter()
+	interceptor := grpcserver.TracingStreamInterceptor(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	var seen trace.SpanContext
+
+	// Test
+	err := interceptor(nil, &MockServerStream{}, &grpc.StreamServerInfo{FullMethod: "/UserService/WatchUsers"}, func(srv interface{}, stream grpc.ServerStream) error {
+		seen = trace.SpanContextFromContext(stream.Context())
+		return status.Error(codes.Unavailable, "Change streams unavailable")
+	})
+
+	// Assert
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, spans[0].SpanContext, seen)
+	assert.False(t, spans[0].Parent.IsValid())
+	assert.Equal(t, otelcodes.Error, spans[0].Status.Code)
+	assert.Equal(t, codes.Unavailable.String(), spans[0].Status.Description)
+}
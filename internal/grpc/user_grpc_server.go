@@ -2,46 +2,108 @@ package grpcserver
 
 import (
 	"context"
-	"log"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net"
+	"time"
 
 	common_grpc "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/grpc"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/events"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/mapping"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/passwordpolicy"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb"
+	pbv2 "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb/v2"
 	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type IUserGrpcServer interface {
 	CreateUser(ctx context.Context, createUserModel *pb.CreateUserRequest) (*pb.PublicUserResponse, error)
 	GetPrivateUserByIdentifier(ctx context.Context, getUserByIdentifierModel *pb.IdentifierRequest) (*pb.UserResponse, error)
 	GetPublicUserByIdentifier(ctx context.Context, getPublicUserByIdentifierModel *pb.IdentifierRequest) (*pb.PublicUserResponse, error)
+	BatchGetPublicUsers(ctx context.Context, batchIdentifierModel *pb.BatchIdentifierRequest) (*pb.BatchPublicUserResponse, error)
+	WatchUsers(watchUsersModel *pb.WatchUsersRequest, stream pb.UserService_WatchUsersServer) error
+	ListAuditEvents(ctx context.Context, listAuditEventsModel *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error)
+	GetMe(ctx context.Context, getMeModel *pb.GetMeRequest) (*pb.MeResponse, error)
+	UpdateMe(ctx context.Context, updateMeModel *pb.UpdateMeRequest) (*pb.MeResponse, error)
+	ImportUser(ctx context.Context, importUserModel *pb.ImportUserRequest) (*pb.PublicUserResponse, error)
+	VerifyCredentials(ctx context.Context, verifyCredentialsModel *pb.VerifyCredentialsRequest) (*pb.UserResponse, error)
+	ResetPassword(ctx context.Context, resetPasswordModel *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error)
+	UnlockUser(ctx context.Context, unlockUserModel *pb.UnlockUserRequest) (*pb.UnlockUserResponse, error)
+	GetLoginHistory(ctx context.Context, getLoginHistoryModel *pb.GetLoginHistoryRequest) (*pb.GetLoginHistoryResponse, error)
+	EnrollMFA(ctx context.Context, enrollMFAModel *pb.EnrollMFARequest) (*pb.EnrollMFAResponse, error)
+	ConfirmMFA(ctx context.Context, confirmMFAModel *pb.ConfirmMFARequest) (*pb.ConfirmMFAResponse, error)
+	VerifyMFA(ctx context.Context, verifyMFAModel *pb.VerifyMFARequest) (*pb.VerifyMFAResponse, error)
+	DisableMFA(ctx context.Context, disableMFAModel *pb.DisableMFARequest) (*pb.DisableMFAResponse, error)
+	ExportUserData(ctx context.Context, exportUserDataModel *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error)
+	EraseUser(ctx context.Context, eraseUserModel *pb.EraseUserRequest) (*pb.EraseUserResponse, error)
 }
 
 type UserGrpcServer struct {
 	UserService  service.IUserService
+	AuditService service.IAuditService
 	Interceptors []grpc.UnaryServerInterceptor
+	// StreamInterceptors guard streaming RPCs. Without them, the unary interceptors are adapted
+	// with StreamFromUnaryInterceptor.
+	StreamInterceptors []grpc.StreamServerInterceptor
+	// LockoutService backs UnlockUser and GetLoginHistory, which are unimplemented without it
+	LockoutService service.ILockoutService
+	// MFAService backs the MFA methods, which are unimplemented without it
+	MFAService service.IMFAService
+	// PrivacyService backs ExportUserData and EraseUser, which are unimplemented without it
+	PrivacyService service.IPrivacyService
+	// Reflection registers the server reflection service, so that tools such as grpcurl can list the methods
+	Reflection bool
 	pb.UnimplementedUserServiceServer
 }
 
-func NewUserGrpcServer(userService service.IUserService, interceptors []grpc.UnaryServerInterceptor) *UserGrpcServer {
+func NewUserGrpcServer(userService service.IUserService, auditService service.IAuditService, interceptors []grpc.UnaryServerInterceptor, streamInterceptors ...grpc.StreamServerInterceptor) *UserGrpcServer {
 	return &UserGrpcServer{
-		UserService:  userService,
-		Interceptors: interceptors,
+		UserService:        userService,
+		AuditService:       auditService,
+		Interceptors:       interceptors,
+		StreamInterceptors: streamInterceptors,
 	}
 }
 
 func (s *UserGrpcServer) Run(port string) error {
 	lis, err := net.Listen("tcp", port)
 	if err != nil {
-		log.Fatalf("Failed to listen on port: %v", err)
-		return err
+		return fmt.Errorf("failed to listen on port %s: %w", port, err)
+	}
+	return s.NewServer().Serve(lis)
+}
+
+// NewServer returns a gRPC server with the interceptors and the services registered.
+func (s *UserGrpcServer) NewServer() *grpc.Server {
+	streamInterceptors := s.StreamInterceptors
+	if len(streamInterceptors) == 0 {
+		streamInterceptors = make([]grpc.StreamServerInterceptor, len(s.Interceptors))
+		for i, interceptor := range s.Interceptors {
+			streamInterceptors[i] = StreamFromUnaryInterceptor(interceptor)
+		}
 	}
 	gRPCServer := grpc.NewServer(
 		grpc.UnaryInterceptor(common_grpc.ChainUnaryInterceptors(s.Interceptors...)),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 	)
+	// v1 and v2 are served side by side while clients migrate to v2
 	pb.RegisterUserServiceServer(gRPCServer, s)
-	return gRPCServer.Serve(lis)
+	pbv2.RegisterUserServiceServer(gRPCServer, NewUserGrpcServerV2(s))
+	if s.Reflection {
+		reflection.Register(gRPCServer)
+	}
+	return gRPCServer
 }
 
 func (s *UserGrpcServer) CreateUser(ctx context.Context, createUserModel *pb.CreateUserRequest) (*pb.PublicUserResponse, error) {
@@ -49,35 +111,24 @@ func (s *UserGrpcServer) CreateUser(ctx context.Context, createUserModel *pb.Cre
 		Email:    createUserModel.Email,
 		Username: createUserModel.Username,
 		Password: createUserModel.Password,
+		Profile:  publicModel.NewProfileModel(createUserModel.Profile),
 	}
 
-	publicUserResponse, err := s.UserService.Create(ctx, createUserModelInternal)
+	publicUserResponse, err := s.UserService.Create(withIdempotencyKey(ctx), createUserModelInternal)
 	if err != nil {
-		return nil, err
+		return nil, createUserError(err)
 	}
 
-	return &pb.PublicUserResponse{
-		Id:        publicUserResponse.ID.Hex(),
-		Username:  publicUserResponse.Username,
-		CreatedAt: publicUserResponse.CreatedAt.String(),
-		UpdatedAt: publicUserResponse.UpdatedAt.String(),
-	}, nil
+	return mapping.ToPublicUserResponse(publicUserResponse.ToPublicUserModel()), nil
 }
 
 func (s *UserGrpcServer) GetPrivateUserByIdentifier(ctx context.Context, getUserByIdentifierModel *pb.IdentifierRequest) (*pb.UserResponse, error) {
-	userResponse, err := s.UserService.FindByIdentifier(ctx, getUserByIdentifierModel.UserIdentifier)
+	userResponse, err := s.UserService.FindByIdentifierPrivate(ctx, getUserByIdentifierModel.UserIdentifier)
 	if err != nil {
-		return nil, err
+		return nil, forbiddenError(err)
 	}
 
-	return &pb.UserResponse{
-		Id:        userResponse.ID.Hex(),
-		Email:     userResponse.Email,
-		Username:  userResponse.Username,
-		Hash:      userResponse.Hash,
-		CreatedAt: userResponse.CreatedAt.String(),
-		UpdatedAt: userResponse.UpdatedAt.String(),
-	}, nil
+	return mapping.ToUserResponse(userResponse.ToAccountUserModel(time.Now())), nil
 }
 
 func (s *UserGrpcServer) GetPublicUserByIdentifier(ctx context.Context, getPublicUserByIdentifierModel *pb.IdentifierRequest) (*pb.PublicUserResponse, error) {
@@ -86,10 +137,433 @@ func (s *UserGrpcServer) GetPublicUserByIdentifier(ctx context.Context, getPubli
 		return nil, err
 	}
 
-	return &pb.PublicUserResponse{
-		Id:        publicUserResponse.ID.Hex(),
-		Username:  publicUserResponse.Username,
-		CreatedAt: publicUserResponse.CreatedAt.String(),
-		UpdatedAt: publicUserResponse.UpdatedAt.String(),
+	return mapping.ToPublicUserResponse(publicUserResponse.ToPublicUserModelFor(service.ActorFromContext(ctx).Audience())), nil
+}
+
+func (s *UserGrpcServer) BatchGetPublicUsers(ctx context.Context, batchIdentifierModel *pb.BatchIdentifierRequest) (*pb.BatchPublicUserResponse, error) {
+	users, err := s.UserService.BatchFindByIdentifier(ctx, batchIdentifierModel.UserIdentifiers)
+	if err != nil {
+		if errors.Is(err, service.ErrBatchTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	audience := service.ActorFromContext(ctx).Audience()
+	results := make([]*pb.BatchPublicUserResult, len(users))
+	for i, user := range users {
+		results[i] = &pb.BatchPublicUserResult{UserIdentifier: batchIdentifierModel.UserIdentifiers[i]}
+		if user != nil {
+			results[i].Found = true
+			results[i].User = mapping.ToPublicUserResponse(user.ToPublicUserModelFor(audience))
+		}
+	}
+
+	return &pb.BatchPublicUserResponse{Results: results}, nil
+}
+
+var userEventTypes = map[events.UserEventType]pb.UserEventType{
+	events.UserCreated: pb.UserEventType_USER_EVENT_TYPE_CREATED,
+	events.UserUpdated: pb.UserEventType_USER_EVENT_TYPE_UPDATED,
+	events.UserDeleted: pb.UserEventType_USER_EVENT_TYPE_DELETED,
+}
+
+func (s *UserGrpcServer) WatchUsers(watchUsersModel *pb.WatchUsersRequest, stream pb.UserService_WatchUsersServer) error {
+	err := s.UserService.WatchUsers(stream.Context(), watchUsersModel.ResumeToken, func(event *events.UserChangeEvent) error {
+		userEvent := &pb.UserEvent{
+			Type:        userEventTypes[event.Type],
+			UserId:      event.UserID,
+			ResumeToken: event.ResumeToken,
+		}
+		if event.User != nil {
+			userEvent.User = mapping.ToPublicUserResponse(event.User)
+		}
+		return stream.Send(userEvent)
+	})
+
+	return watchUsersError(err)
+}
+
+func (s *UserGrpcServer) ListAuditEvents(ctx context.Context, listAuditEventsModel *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	filter := repository.AuditFilter{
+		TargetUserID: listAuditEventsModel.TargetUserId,
+		Limit:        listAuditEventsModel.Limit,
+	}
+	var err error
+	if listAuditEventsModel.From != "" {
+		if filter.From, err = time.Parse(time.RFC3339, listAuditEventsModel.From); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "from must be an RFC 3339 timestamp")
+		}
+	}
+	if listAuditEventsModel.To != "" {
+		if filter.To, err = time.Parse(time.RFC3339, listAuditEventsModel.To); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "to must be an RFC 3339 timestamp")
+		}
+	}
+
+	auditEvents, err := s.AuditService.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListAuditEventsResponse{Events: make([]*pb.AuditEvent, len(auditEvents))}
+	for i, auditEvent := range auditEvents {
+		changes := make([]*pb.AuditFieldChange, len(auditEvent.Changes))
+		for j, change := range auditEvent.Changes {
+			changes[j] = &pb.AuditFieldChange{Field: change.Field, OldValue: change.Old, NewValue: change.New}
+		}
+		response.Events[i] = &pb.AuditEvent{
+			Id:           auditEvent.ID.Hex(),
+			Sequence:     auditEvent.Sequence,
+			Actor:        auditEvent.Actor,
+			Action:       auditEvent.Action,
+			TargetUserId: auditEvent.TargetUserID,
+			SourceIp:     auditEvent.SourceIP,
+			RequestId:    auditEvent.RequestID,
+			Changes:      changes,
+			CreatedAt:    mapping.FormatTime(auditEvent.CreatedAt),
+			PrevHash:     auditEvent.PrevHash,
+			Hash:         auditEvent.Hash,
+		}
+	}
+
+	return response, nil
+}
+
+func (s *UserGrpcServer) GetMe(ctx context.Context, getMeModel *pb.GetMeRequest) (*pb.MeResponse, error) {
+	user, err := s.UserService.FindById(ctx, service.ActorFromContext(ctx).UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.ToMeResponse(user.ToSelfUserModel()), nil
+}
+
+func (s *UserGrpcServer) UpdateMe(ctx context.Context, updateMeModel *pb.UpdateMeRequest) (*pb.MeResponse, error) {
+	updateUserModel := &publicModel.UpdateUserModel{
+		Email:           updateMeModel.Email,
+		Username:        updateMeModel.Username,
+		Password:        updateMeModel.Password,
+		Profile:         publicModel.NewProfileModel(updateMeModel.Profile),
+		CurrentPassword: updateMeModel.CurrentPassword,
+	}
+
+	user, err := s.UserService.Update(ctx, service.ActorFromContext(ctx).UserID, updateUserModel)
+	if err != nil {
+		return nil, updateMeError(err)
+	}
+
+	return mapping.ToMeResponse(user.ToSelfUserModel()), nil
+}
+
+func (s *UserGrpcServer) ImportUser(ctx context.Context, importUserModel *pb.ImportUserRequest) (*pb.PublicUserResponse, error) {
+	importUserModelInternal := &publicModel.ImportUserModel{
+		Email:        importUserModel.Email,
+		Username:     importUserModel.Username,
+		PasswordHash: importUserModel.PasswordHash,
+		Profile:      publicModel.NewProfileModel(importUserModel.Profile),
+	}
+
+	user, err := s.UserService.Import(ctx, importUserModelInternal)
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedPasswordHash) || errors.Is(err, service.ErrInvalidProfile) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	return mapping.ToPublicUserResponse(user.ToPublicUserModel()), nil
+}
+
+func (s *UserGrpcServer) VerifyCredentials(ctx context.Context, verifyCredentialsModel *pb.VerifyCredentialsRequest) (*pb.UserResponse, error) {
+	user, err := s.UserService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{
+		Identifier: verifyCredentialsModel.UserIdentifier,
+		Password:   verifyCredentialsModel.Password,
+		SourceIP:   verifyCredentialsModel.SourceIp,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, lockoutError(err)
+	}
+
+	return mapping.ToUserResponse(user.ToAccountUserModel(time.Now())), nil
+}
+
+func (s *UserGrpcServer) UnlockUser(ctx context.Context, unlockUserModel *pb.UnlockUserRequest) (*pb.UnlockUserResponse, error) {
+	if s.LockoutService == nil {
+		return nil, status.Error(codes.Unimplemented, "login tracking is not enabled")
+	}
+
+	if err := s.LockoutService.Unlock(ctx, unlockUserModel.UserId); err != nil {
+		return nil, forbiddenError(err)
+	}
+
+	return &pb.UnlockUserResponse{}, nil
+}
+
+func (s *UserGrpcServer) GetLoginHistory(ctx context.Context, getLoginHistoryModel *pb.GetLoginHistoryRequest) (*pb.GetLoginHistoryResponse, error) {
+	if s.LockoutService == nil {
+		return nil, status.Error(codes.Unimplemented, "login tracking is not enabled")
+	}
+
+	attempts, err := s.LockoutService.History(ctx, getLoginHistoryModel.UserId, getLoginHistoryModel.Limit)
+	if err != nil {
+		return nil, forbiddenError(err)
+	}
+
+	response := &pb.GetLoginHistoryResponse{Attempts: make([]*pb.LoginAttempt, len(attempts))}
+	for i, attempt := range attempts {
+		response.Attempts[i] = &pb.LoginAttempt{
+			Id:        attempt.ID.Hex(),
+			UserId:    attempt.UserID,
+			SourceIp:  attempt.SourceIP,
+			Result:    string(attempt.Result),
+			CreatedAt: mapping.FormatTime(attempt.CreatedAt),
+		}
+	}
+
+	return response, nil
+}
+
+func (s *UserGrpcServer) EnrollMFA(ctx context.Context, enrollMFAModel *pb.EnrollMFARequest) (*pb.EnrollMFAResponse, error) {
+	if s.MFAService == nil {
+		return nil, status.Error(codes.Unimplemented, "multi-factor authentication is not enabled")
+	}
+
+	enrollment, err := s.MFAService.Enroll(ctx, service.ActorFromContext(ctx).UserID)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &pb.EnrollMFAResponse{Secret: enrollment.Secret, Uri: enrollment.URI}, nil
+}
+
+func (s *UserGrpcServer) ConfirmMFA(ctx context.Context, confirmMFAModel *pb.ConfirmMFARequest) (*pb.ConfirmMFAResponse, error) {
+	if s.MFAService == nil {
+		return nil, status.Error(codes.Unimplemented, "multi-factor authentication is not enabled")
+	}
+
+	recoveryCodes, err := s.MFAService.Confirm(ctx, service.ActorFromContext(ctx).UserID, confirmMFAModel.Code)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &pb.ConfirmMFAResponse{RecoveryCodes: recoveryCodes.RecoveryCodes}, nil
+}
+
+func (s *UserGrpcServer) VerifyMFA(ctx context.Context, verifyMFAModel *pb.VerifyMFARequest) (*pb.VerifyMFAResponse, error) {
+	if s.MFAService == nil {
+		return nil, status.Error(codes.Unimplemented, "multi-factor authentication is not enabled")
+	}
+
+	verification, err := s.MFAService.Verify(ctx, verifyMFAModel.UserId, &publicModel.VerifyMFAModel{
+		Code:     verifyMFAModel.Code,
+		SourceIP: verifyMFAModel.SourceIp,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidMFACode) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, mfaError(err)
+	}
+
+	return &pb.VerifyMFAResponse{
+		Method:                 verification.Method,
+		RemainingRecoveryCodes: int32(verification.RemainingRecoveryCodes),
 	}, nil
 }
+
+// DisableMFA disables MFA for the given user, or for the caller when no user is given.
+func (s *UserGrpcServer) DisableMFA(ctx context.Context, disableMFAModel *pb.DisableMFARequest) (*pb.DisableMFAResponse, error) {
+	if s.MFAService == nil {
+		return nil, status.Error(codes.Unimplemented, "multi-factor authentication is not enabled")
+	}
+
+	userId := disableMFAModel.UserId
+	if userId == "" {
+		userId = service.ActorFromContext(ctx).UserID
+	}
+	if err := s.MFAService.Disable(ctx, userId, disableMFAModel.Code); err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &pb.DisableMFAResponse{}, nil
+}
+
+func (s *UserGrpcServer) ExportUserData(ctx context.Context, exportUserDataModel *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	if s.PrivacyService == nil {
+		return nil, status.Error(codes.Unimplemented, "data subject requests are not enabled")
+	}
+
+	export, err := s.PrivacyService.Export(ctx, exportUserDataModel.UserId)
+	if err != nil {
+		return nil, privacyError(err)
+	}
+
+	archive, err := json.Marshal(export)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ExportUserDataResponse{Archive: archive, Filename: export.Filename()}, nil
+}
+
+func (s *UserGrpcServer) EraseUser(ctx context.Context, eraseUserModel *pb.EraseUserRequest) (*pb.EraseUserResponse, error) {
+	if s.PrivacyService == nil {
+		return nil, status.Error(codes.Unimplemented, "data subject requests are not enabled")
+	}
+
+	receipt, err := s.PrivacyService.Erase(ctx, eraseUserModel.UserId)
+	if err != nil {
+		return nil, privacyError(err)
+	}
+
+	return &pb.EraseUserResponse{
+		ErasedAt:          mapping.FormatTime(receipt.ErasedAt),
+		UsernameReleaseAt: mapping.FormatTime(receipt.UsernameReleaseAt),
+		ErasureRef:        receipt.ErasureRef,
+	}, nil
+}
+
+func (s *UserGrpcServer) ResetPassword(ctx context.Context, resetPasswordModel *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	if err := s.UserService.ResetPassword(ctx, resetPasswordModel.UserId, resetPasswordModel.Password); err != nil {
+		return nil, resetPasswordError(err)
+	}
+
+	return &pb.ResetPasswordResponse{}, nil
+}
+
+// withIdempotencyKey passes the idempotency key from the request metadata, if any, to the service.
+func withIdempotencyKey(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(IdempotencyKeyMetadataKey); len(keys) > 0 {
+			return service.WithIdempotencyKey(ctx, keys[0])
+		}
+	}
+	return ctx
+}
+
+// createUserError maps errors from creating a user. Other errors are returned unchanged.
+func createUserError(err error) error {
+	switch {
+	case errors.Is(err, passwordpolicy.ErrWeakPassword):
+		return weakPasswordError(err)
+	case errors.Is(err, service.ErrInvalidIdempotencyKey), errors.Is(err, service.ErrInvalidProfile):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrIdempotencyInProgress):
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return err
+}
+
+// watchUsersError maps the error that ended a watch. A cancelled watch ends without an error.
+func watchUsersError(err error) error {
+	switch {
+	case errors.Is(err, events.ErrInvalidResumeToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrWatchUnavailable):
+		return status.Error(codes.Unimplemented, err.Error())
+	case errors.Is(err, context.Canceled):
+		return nil
+	}
+	return err
+}
+
+// updateMeError maps errors from a self-service update. Other errors are returned unchanged.
+func updateMeError(err error) error {
+	switch {
+	case errors.Is(err, passwordpolicy.ErrWeakPassword):
+		return weakPasswordError(err)
+	case errors.Is(err, service.ErrInvalidUserUpdate), errors.Is(err, service.ErrInvalidProfile):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrPasswordConfirmation), errors.Is(err, authz.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return err
+}
+
+// resetPasswordError maps errors from a password reset. Other errors are returned unchanged.
+func resetPasswordError(err error) error {
+	switch {
+	case errors.Is(err, passwordpolicy.ErrWeakPassword):
+		return weakPasswordError(err)
+	case errors.Is(err, authz.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return err
+}
+
+// forbiddenError maps authz.ErrForbidden to PermissionDenied. Other errors are returned unchanged.
+func forbiddenError(err error) error {
+	if errors.Is(err, authz.ErrForbidden) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return err
+}
+
+// lockoutError maps refusals by the lockout service, with a RetryInfo detail when throttled.
+// Other errors are returned unchanged.
+func lockoutError(err error) error {
+	var throttled *service.LoginThrottledError
+	switch {
+	case errors.Is(err, service.ErrAccountLocked):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.As(err, &throttled):
+		st := status.New(codes.ResourceExhausted, err.Error())
+		if detailed, detailErr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(throttled.RetryAfter)}); detailErr == nil {
+			return detailed.Err()
+		}
+		return st.Err()
+	}
+	return err
+}
+
+// mfaError maps errors from the MFA service. A wrong code on enrolment or disabling is an invalid argument.
+func mfaError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidMFACode):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrMFAAlreadyEnabled), errors.Is(err, service.ErrMFANotEnabled), errors.Is(err, service.ErrMFANotEnrolled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, authz.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return lockoutError(err)
+}
+
+// privacyError maps errors from the privacy service. Other errors are returned unchanged.
+func privacyError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrUserErased):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, authz.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return err
+}
+
+// weakPasswordError reports a password policy violation as InvalidArgument, with one field violation per reason.
+func weakPasswordError(err error) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+
+	var validationError *passwordpolicy.ValidationError
+	if !errors.As(err, &validationError) {
+		return st.Err()
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, reason := range validationError.Reasons {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       "password",
+			Description: string(reason.Code) + ": " + reason.Message,
+		})
+	}
+	if detailed, detailErr := st.WithDetails(badRequest); detailErr == nil {
+		return detailed.Err()
+	}
+	return st.Err()
+}
@@ -5,19 +5,28 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/events"
 	grpcserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/grpc"
 	privateModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/passwordpolicy"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb"
+	pbv2 "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb/v2"
 	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type MockIUserService struct {
 	mock.Mock
+	Events []*events.UserChangeEvent
 }
 
 // Create implements service.IUserService.
@@ -68,6 +77,18 @@ func (m *MockIUserService) FindByIdentifier(ctx context.Context, identifier stri
 	return userModelArgs, args.Error(1)
 }
 
+// FindByIdentifierPrivate implements service.IUserService.
+func (m *MockIUserService) FindByIdentifierPrivate(ctx context.Context, identifier string) (*privateModel.PrivateUserModel, error) {
+	args := m.Called(ctx, identifier)
+
+	userModelArgs, ok := args.Get(0).(*privateModel.PrivateUserModel)
+	if !ok && args.Get(0) != nil {
+		return nil, args.Error(1)
+	}
+
+	return userModelArgs, args.Error(1)
+}
+
 // FindByUsername implements service.IUserService.
 func (m *MockIUserService) FindByUsername(ctx context.Context, username string) (*privateModel.PrivateUserModel, error) {
 	args := m.Called(ctx, username)
@@ -80,9 +101,102 @@ func (m *MockIUserService) FindByUsername(ctx context.Context, username string)
 	return userModelArgs, args.Error(1)
 }
 
+// BatchFindByIdentifier implements service.IUserService.
+func (m *MockIUserService) BatchFindByIdentifier(ctx context.Context, identifiers []string) ([]*privateModel.PrivateUserModel, error) {
+	args := m.Called(ctx, identifiers)
+
+	userModelArgs, ok := args.Get(0).([]*privateModel.PrivateUserModel)
+	if !ok && args.Get(0) != nil {
+		return nil, args.Error(1)
+	}
+
+	return userModelArgs, args.Error(1)
+}
+
+// WatchUsers implements service.IUserService.
+func (m *MockIUserService) WatchUsers(ctx context.Context, resumeToken string, handle func(event *events.UserChangeEvent) error) error {
+	args := m.Called(ctx, resumeToken, handle)
+	for _, event := range m.Events {
+		if err := handle(event); err != nil {
+			return err
+		}
+	}
+	return args.Error(0)
+}
+
+// Update implements service.IUserService.
+func (m *MockIUserService) Update(ctx context.Context, id string, update *publicModel.UpdateUserModel) (*privateModel.PrivateUserModel, error) {
+	args := m.Called(ctx, id, update)
+
+	userModelArgs, ok := args.Get(0).(*privateModel.PrivateUserModel)
+	if !ok && args.Get(0) != nil {
+		return nil, args.Error(1)
+	}
+
+	return userModelArgs, args.Error(1)
+}
+
+// Delete implements service.IUserService.
+func (m *MockIUserService) Delete(ctx context.Context, id string, password string) error {
+	args := m.Called(ctx, id, password)
+	return args.Error(0)
+}
+
+// VerifyCredentials implements service.IUserService.
+func (m *MockIUserService) VerifyCredentials(ctx context.Context, credentials *publicModel.VerifyCredentialsModel) (*privateModel.PrivateUserModel, error) {
+	args := m.Called(ctx, credentials)
+
+	userModelArgs, ok := args.Get(0).(*privateModel.PrivateUserModel)
+	if !ok && args.Get(0) != nil {
+		return nil, args.Error(1)
+	}
+
+	return userModelArgs, args.Error(1)
+}
+
+// Import implements service.IUserService.
+func (m *MockIUserService) Import(ctx context.Context, user *publicModel.ImportUserModel) (*privateModel.PrivateUserModel, error) {
+	args := m.Called(ctx, user)
+
+	userModelArgs, ok := args.Get(0).(*privateModel.PrivateUserModel)
+	if !ok && args.Get(0) != nil {
+		return nil, args.Error(1)
+	}
+
+	return userModelArgs, args.Error(1)
+}
+
+// ResetPassword implements service.IUserService.
+func (m *MockIUserService) ResetPassword(ctx context.Context, id string, password string) error {
+	args := m.Called(ctx, id, password)
+	return args.Error(0)
+}
+
 // Ensure that the mock implements the interface
 var _ service.IUserService = &MockIUserService{}
 
+type MockIAuditService struct {
+	mock.Mock
+}
+
+// Record implements service.IAuditService.
+func (m *MockIAuditService) Record(ctx context.Context, action string, targetUserID string, before *privateModel.PrivateUserModel, after *privateModel.PrivateUserModel) error {
+	args := m.Called(ctx, action, targetUserID, before, after)
+	return args.Error(0)
+}
+
+// List implements service.IAuditService.
+func (m *MockIAuditService) List(ctx context.Context, filter repository.AuditFilter) ([]*privateModel.AuditEvent, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*privateModel.AuditEvent), args.Error(1)
+}
+
+// Ensure that the mock implements the interface
+var _ service.IAuditService = &MockIAuditService{}
+
 func TestCreateUser_Success(t *testing.T) {
 	userResponse := &privateModel.PrivateUserModel{
 		ID:        primitive.NewObjectID(),
@@ -96,7 +210,7 @@ func TestCreateUser_Success(t *testing.T) {
 	// Setup
 	mockUserService := new(MockIUserService)
 	mockUserService.On("Create", mock.Anything, mock.Anything).Return(userResponse, nil)
-	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, []grpc.UnaryServerInterceptor{})
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
 
 	// Test
 	resp, err := grpcserver.CreateUser(context.Background(), &pb.CreateUserRequest{
@@ -114,7 +228,7 @@ func TestCreateUser_Success(t *testing.T) {
 func TestCreateUser_Fail(t *testing.T) {
 	mockUserService := new(MockIUserService)
 	mockUserService.On("Create", mock.Anything, mock.Anything).Return(nil, assert.AnError)
-	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, []grpc.UnaryServerInterceptor{})
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
 
 	// Test
 	resp, err := grpcserver.CreateUser(context.Background(), &pb.CreateUserRequest{
@@ -141,8 +255,8 @@ func TestGetPrivateUserByIdentifier_Success(t *testing.T) {
 
 	// Setup
 	mockUserService := new(MockIUserService)
-	mockUserService.On("FindByIdentifier", mock.Anything, mock.Anything).Return(userResponse, nil)
-	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, []grpc.UnaryServerInterceptor{})
+	mockUserService.On("FindByIdentifierPrivate", mock.Anything, mock.Anything).Return(userResponse, nil)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
 
 	// Test
 	resp, err := grpcserver.GetPrivateUserByIdentifier(context.Background(), &pb.IdentifierRequest{
@@ -157,8 +271,8 @@ func TestGetPrivateUserByIdentifier_Success(t *testing.T) {
 
 func TestGetPrivateUserByIdentifier_Fail(t *testing.T) {
 	mockUserService := new(MockIUserService)
-	mockUserService.On("FindByIdentifier", mock.Anything, mock.Anything).Return(nil, assert.AnError)
-	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, []grpc.UnaryServerInterceptor{})
+	mockUserService.On("FindByIdentifierPrivate", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
 
 	// Test
 	resp, err := grpcserver.GetPrivateUserByIdentifier(context.Background(), &pb.IdentifierRequest{
@@ -184,7 +298,7 @@ func TestGetPublicUserByIdentifier_Success(t *testing.T) {
 	// Setup
 	mockUserService := new(MockIUserService)
 	mockUserService.On("FindByIdentifier", mock.Anything, mock.Anything).Return(userResponse, nil)
-	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, []grpc.UnaryServerInterceptor{})
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
 
 	// Test
 	resp, err := grpcserver.GetPublicUserByIdentifier(context.Background(), &pb.IdentifierRequest{
@@ -200,7 +314,7 @@ func TestGetPublicUserByIdentifier_Success(t *testing.T) {
 func TestGetPublicUserByIdentifier_Fail(t *testing.T) {
 	mockUserService := new(MockIUserService)
 	mockUserService.On("FindByIdentifier", mock.Anything, mock.Anything).Return(nil, assert.AnError)
-	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, []grpc.UnaryServerInterceptor{})
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
 
 	// Test
 	resp, err := grpcserver.GetPublicUserByIdentifier(context.Background(), &pb.IdentifierRequest{
@@ -212,3 +326,681 @@ func TestGetPublicUserByIdentifier_Fail(t *testing.T) {
 	assert.Nil(t, resp)
 	mockUserService.AssertExpectations(t)
 }
+
+func TestBatchGetPublicUsers_Success(t *testing.T) {
+	userResponse := &privateModel.PrivateUserModel{
+		ID:        primitive.NewObjectID(),
+		Email:     "test@mail.com",
+		Username:  "test",
+		Hash:      "test",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	// Setup
+	mockUserService := new(MockIUserService)
+	mockUserService.On("BatchFindByIdentifier", mock.Anything, []string{"test", "missing"}).
+		Return([]*privateModel.PrivateUserModel{userResponse, nil}, nil)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	resp, err := grpcserver.BatchGetPublicUsers(context.Background(), &pb.BatchIdentifierRequest{
+		UserIdentifiers: []string{"test", "missing"},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, resp.Results, 2)
+	assert.True(t, resp.Results[0].Found)
+	assert.Equal(t, userResponse.ID.Hex(), resp.Results[0].User.Id)
+	assert.Equal(t, "missing", resp.Results[1].UserIdentifier)
+	assert.False(t, resp.Results[1].Found)
+	assert.Nil(t, resp.Results[1].User)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestBatchGetPublicUsers_TooLarge(t *testing.T) {
+	mockUserService := new(MockIUserService)
+	mockUserService.On("BatchFindByIdentifier", mock.Anything, mock.Anything).Return(nil, service.ErrBatchTooLarge)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	resp, err := grpcserver.BatchGetPublicUsers(context.Background(), &pb.BatchIdentifierRequest{
+		UserIdentifiers: []string{"test"},
+	})
+
+	// Assert
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, resp)
+	mockUserService.AssertExpectations(t)
+}
+
+type MockWatchUsersServer struct {
+	grpc.ServerStream
+	Sent []*pb.UserEvent
+}
+
+func (m *MockWatchUsersServer) Send(event *pb.UserEvent) error {
+	m.Sent = append(m.Sent, event)
+	return nil
+}
+
+func (m *MockWatchUsersServer) Context() context.Context {
+	return context.Background()
+}
+
+func TestWatchUsers_Success(t *testing.T) {
+	user := &publicModel.PublicUserModel{
+		ID:        primitive.NewObjectID(),
+		Username:  "test",
+		CreatedAt: time.Now(),
+	}
+
+	// Setup
+	mockUserService := new(MockIUserService)
+	mockUserService.Events = []*events.UserChangeEvent{
+		{Type: events.UserCreated, UserID: user.ID.Hex(), User: user, ResumeToken: "1"},
+		{Type: events.UserDeleted, UserID: user.ID.Hex(), ResumeToken: "2"},
+	}
+	mockUserService.On("WatchUsers", mock.Anything, "token", mock.Anything).Return(context.Canceled)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+	stream := &MockWatchUsersServer{}
+
+	// Test
+	err := grpcserver.WatchUsers(&pb.WatchUsersRequest{ResumeToken: "token"}, stream)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, stream.Sent, 2)
+	assert.Equal(t, pb.UserEventType_USER_EVENT_TYPE_CREATED, stream.Sent[0].Type)
+	assert.Equal(t, user.Username, stream.Sent[0].User.Username)
+	assert.Equal(t, pb.UserEventType_USER_EVENT_TYPE_DELETED, stream.Sent[1].Type)
+	assert.Nil(t, stream.Sent[1].User)
+	assert.Equal(t, "2", stream.Sent[1].ResumeToken)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestWatchUsers_InvalidResumeToken(t *testing.T) {
+	mockUserService := new(MockIUserService)
+	mockUserService.On("WatchUsers", mock.Anything, "bad", mock.Anything).Return(events.ErrInvalidResumeToken)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	err := grpcserver.WatchUsers(&pb.WatchUsersRequest{ResumeToken: "bad"}, &MockWatchUsersServer{})
+
+	// Assert
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockUserService.AssertExpectations(t)
+}
+
+func TestListAuditEvents_Success(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	auditEvent := &privateModel.AuditEvent{
+		ID:           primitive.NewObjectID(),
+		Sequence:     7,
+		Actor:        "-1",
+		Action:       privateModel.AuditActionCreate,
+		TargetUserID: "target",
+		Changes:      []privateModel.FieldChange{{Field: "username", New: "test"}},
+		CreatedAt:    from.Add(time.Hour),
+	}
+
+	// Setup
+	mockAuditService := new(MockIAuditService)
+	mockAuditService.On("List", mock.Anything, repository.AuditFilter{TargetUserID: "target", From: from, Limit: 10}).
+		Return([]*privateModel.AuditEvent{auditEvent}, nil)
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), mockAuditService, []grpc.UnaryServerInterceptor{})
+	ctx := context.Background()
+
+	// Test
+	resp, err := grpcserver.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{
+		TargetUserId: "target",
+		From:         from.Format(time.RFC3339),
+		Limit:        10,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, resp.Events, 1)
+	assert.Equal(t, int64(7), resp.Events[0].Sequence)
+	assert.Equal(t, "test", resp.Events[0].Changes[0].NewValue)
+	mockAuditService.AssertExpectations(t)
+}
+
+func TestListAuditEvents_InvalidTimeRange(t *testing.T) {
+	mockAuditService := new(MockIAuditService)
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), mockAuditService, []grpc.UnaryServerInterceptor{})
+	ctx := context.Background()
+
+	// Test
+	resp, err := grpcserver.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{To: "yesterday"})
+
+	// Assert
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, resp)
+}
+
+func TestCreateUser_IdempotencyKeyReused(t *testing.T) {
+	mockUserService := new(MockIUserService)
+	mockUserService.On("Create", mock.MatchedBy(func(ctx context.Context) bool {
+		return service.IdempotencyKeyFromContext(ctx) == "key"
+	}), mock.Anything).Return(nil, service.ErrIdempotencyKeyReused)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcserver.IdempotencyKeyMetadataKey, "key"))
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	resp, err := grpcserver.CreateUser(ctx, &pb.CreateUserRequest{
+		Email:    "test@mail.com",
+		Username: "test",
+		Password: "test",
+	})
+
+	// Assert
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.Nil(t, resp)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestGetMe_Success(t *testing.T) {
+	userResponse := &privateModel.PrivateUserModel{
+		ID:        primitive.NewObjectID(),
+		Email:     "test@mail.com",
+		Username:  "test",
+		Hash:      "test",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	ctx := service.WithActor(context.Background(), service.Actor{UserID: userResponse.ID.Hex()})
+
+	// Setup
+	mockUserService := new(MockIUserService)
+	mockUserService.On("FindById", ctx, userResponse.ID.Hex()).Return(userResponse, nil)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	resp, err := grpcserver.GetMe(ctx, &pb.GetMeRequest{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, userResponse.ID.Hex(), resp.Id)
+	assert.Equal(t, userResponse.Email, resp.Email)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestUpdateMe_Success(t *testing.T) {
+	userResponse := &privateModel.PrivateUserModel{
+		ID:        primitive.NewObjectID(),
+		Email:     "test@mail.com",
+		Username:  "renamed",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	ctx := service.WithActor(context.Background(), service.Actor{UserID: userResponse.ID.Hex()})
+	username := "renamed"
+
+	// Setup
+	mockUserService := new(MockIUserService)
+	mockUserService.On("Update", ctx, userResponse.ID.Hex(), &publicModel.UpdateUserModel{Username: &username}).Return(userResponse, nil)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	resp, err := grpcserver.UpdateMe(ctx, &pb.UpdateMeRequest{Username: &username})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed", resp.Username)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestUpdateMe_PasswordConfirmationFailed(t *testing.T) {
+	mockUserService := new(MockIUserService)
+	mockUserService.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil, service.ErrPasswordConfirmation)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+	password := "new-password"
+
+	// Test
+	resp, err := grpcserver.UpdateMe(context.Background(), &pb.UpdateMeRequest{Password: &password, CurrentPassword: "wrong"})
+
+	// Assert
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Nil(t, resp)
+}
+
+func TestGetPublicUserByIdentifier_ProfileVisibility(t *testing.T) {
+	userResponse := &privateModel.PrivateUserModel{
+		ID:       primitive.NewObjectID(),
+		Username: "test",
+		Profile: &publicModel.ProfileModel{
+			DisplayName: "Test",
+			Timezone:    "Europe/Oslo",
+			Metadata:    map[string]string{"plan": "pro"},
+			Visibility:  map[string]publicModel.Visibility{publicModel.ProfileFieldDisplayName: publicModel.VisibilityAuthenticated},
+		},
+	}
+
+	// Setup
+	mockUserService := new(MockIUserService)
+	mockUserService.On("FindByIdentifier", mock.Anything, "test").Return(userResponse, nil)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	anonymous, err := grpcserver.GetPublicUserByIdentifier(context.Background(), &pb.IdentifierRequest{UserIdentifier: "test"})
+	assert.NoError(t, err)
+	authenticated, err := grpcserver.GetPublicUserByIdentifier(service.WithActor(context.Background(), service.Actor{UserID: "1"}), &pb.IdentifierRequest{UserIdentifier: "test"})
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "", anonymous.Profile.DisplayName)
+	assert.Equal(t, "", anonymous.Profile.Timezone)
+	assert.Equal(t, "Test", authenticated.Profile.DisplayName)
+	assert.Equal(t, "Europe/Oslo", authenticated.Profile.Timezone)
+	assert.Empty(t, authenticated.Profile.Metadata)
+	assert.Empty(t, authenticated.Profile.Visibility)
+}
+
+func TestCreateUser_WeakPassword(t *testing.T) {
+	weakPassword := &passwordpolicy.ValidationError{Reasons: []passwordpolicy.Reason{
+		{Code: passwordpolicy.ReasonTooShort, Message: "must be at least 10 characters long"},
+		{Code: passwordpolicy.ReasonCommon, Message: "is a commonly used password"},
+	}}
+
+	// Setup
+	mockUserService := new(MockIUserService)
+	mockUserService.On("Create", mock.Anything, mock.Anything).Return(nil, weakPassword)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	resp, err := grpcserver.CreateUser(context.Background(), &pb.CreateUserRequest{Username: "test", Password: "password"})
+
+	// Assert
+	assert.Nil(t, resp)
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		badRequest := st.Details()[0].(*errdetails.BadRequest)
+		assert.Len(t, badRequest.FieldViolations, 2)
+		assert.Equal(t, "password", badRequest.FieldViolations[0].Field)
+		assert.Equal(t, "too_short: must be at least 10 characters long", badRequest.FieldViolations[0].Description)
+	}
+}
+
+func TestResetPassword_Success(t *testing.T) {
+	// Setup
+	mockUserService := new(MockIUserService)
+	mockUserService.On("ResetPassword", mock.Anything, "1", "kX9#mQ2!vLp7").Return(nil)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	resp, err := grpcserver.ResetPassword(context.Background(), &pb.ResetPasswordRequest{UserId: "1", Password: "kX9#mQ2!vLp7"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	mockUserService.AssertExpectations(t)
+}
+
+type MockILockoutService struct {
+	mock.Mock
+}
+
+// Allow implements service.ILockoutService.
+func (m *MockILockoutService) Allow(ctx context.Context, user *privateModel.PrivateUserModel, sourceIP string) error {
+	args := m.Called(ctx, user, sourceIP)
+	return args.Error(0)
+}
+
+// RecordFailure implements service.ILockoutService.
+func (m *MockILockoutService) RecordFailure(ctx context.Context, user *privateModel.PrivateUserModel, sourceIP string) error {
+	args := m.Called(ctx, user, sourceIP)
+	return args.Error(0)
+}
+
+// RecordSuccess implements service.ILockoutService.
+func (m *MockILockoutService) RecordSuccess(ctx context.Context, user *privateModel.PrivateUserModel, sourceIP string) error {
+	args := m.Called(ctx, user, sourceIP)
+	return args.Error(0)
+}
+
+// Unlock implements service.ILockoutService.
+func (m *MockILockoutService) Unlock(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// History implements service.ILockoutService.
+func (m *MockILockoutService) History(ctx context.Context, id string, limit int64) ([]*privateModel.LoginAttempt, error) {
+	args := m.Called(ctx, id, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*privateModel.LoginAttempt), args.Error(1)
+}
+
+// Ensure that the mock implements the interface
+var _ service.ILockoutService = &MockILockoutService{}
+
+func TestVerifyCredentials_LockedUser(t *testing.T) {
+	until := time.Now().Add(time.Minute)
+	user := &privateModel.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test", LockedUntil: &until}
+
+	// Setup
+	mockUserService := new(MockIUserService)
+	mockUserService.On("VerifyCredentials", mock.Anything, mock.Anything).Return(user, nil)
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	resp, err := grpcserver.VerifyCredentials(context.Background(), &pb.VerifyCredentialsRequest{UserIdentifier: "test", Password: "password"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, resp.Locked)
+	assert.Equal(t, until.UTC().Format(time.RFC3339Nano), resp.LockedUntil)
+}
+
+func TestVerifyCredentials_Throttled(t *testing.T) {
+	// Setup
+	mockUserService := new(MockIUserService)
+	mockUserService.On("VerifyCredentials", mock.Anything, &publicModel.VerifyCredentialsModel{Identifier: "test", Password: "password", SourceIP: "10.0.0.1"}).
+		Return(nil, &service.LoginThrottledError{RetryAfter: 4 * time.Second})
+	grpcserver := grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	resp, err := grpcserver.VerifyCredentials(context.Background(), &pb.VerifyCredentialsRequest{UserIdentifier: "test", Password: "password", SourceIp: "10.0.0.1"})
+
+	// Assert
+	assert.Nil(t, resp)
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		retryInfo := st.Details()[0].(*errdetails.RetryInfo)
+		assert.Equal(t, 4*time.Second, retryInfo.RetryDelay.AsDuration())
+	}
+}
+
+func TestUnlockUser_Unimplemented(t *testing.T) {
+	// Setup
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	_, err := grpcserver.UnlockUser(context.Background(), &pb.UnlockUserRequest{UserId: "1"})
+
+	// Assert
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestUnlockUser_Success(t *testing.T) {
+	// Setup
+	mockLockoutService := new(MockILockoutService)
+	mockLockoutService.On("Unlock", mock.Anything, "1").Return(nil)
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+	grpcserver.LockoutService = mockLockoutService
+
+	// Test
+	resp, err := grpcserver.UnlockUser(context.Background(), &pb.UnlockUserRequest{UserId: "1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	mockLockoutService.AssertExpectations(t)
+}
+
+func TestGetLoginHistory_Success(t *testing.T) {
+	createdAt := time.Date(2023, 11, 1, 12, 0, 0, 0, time.UTC)
+	attempt := &privateModel.LoginAttempt{ID: primitive.NewObjectID(), UserID: "1", SourceIP: "10.0.0.1", Result: privateModel.LoginFailed, CreatedAt: createdAt}
+
+	// Setup
+	mockLockoutService := new(MockILockoutService)
+	mockLockoutService.On("History", mock.Anything, "1", int64(20)).Return([]*privateModel.LoginAttempt{attempt}, nil)
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+	grpcserver.LockoutService = mockLockoutService
+
+	// Test
+	resp, err := grpcserver.GetLoginHistory(context.Background(), &pb.GetLoginHistoryRequest{UserId: "1", Limit: 20})
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Attempts, 1) {
+		assert.Equal(t, attempt.ID.Hex(), resp.Attempts[0].Id)
+		assert.Equal(t, "failure", resp.Attempts[0].Result)
+		assert.Equal(t, "10.0.0.1", resp.Attempts[0].SourceIp)
+		assert.Equal(t, "2023-11-01T12:00:00Z", resp.Attempts[0].CreatedAt)
+	}
+}
+
+type MockIMFAService struct {
+	mock.Mock
+}
+
+// Enroll implements service.IMFAService.
+func (m *MockIMFAService) Enroll(ctx context.Context, id string) (*publicModel.MFAEnrollmentModel, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*publicModel.MFAEnrollmentModel), args.Error(1)
+}
+
+// Confirm implements service.IMFAService.
+func (m *MockIMFAService) Confirm(ctx context.Context, id string, code string) (*publicModel.MFARecoveryCodesModel, error) {
+	args := m.Called(ctx, id, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*publicModel.MFARecoveryCodesModel), args.Error(1)
+}
+
+// Verify implements service.IMFAService.
+func (m *MockIMFAService) Verify(ctx context.Context, id string, verification *publicModel.VerifyMFAModel) (*publicModel.MFAVerificationModel, error) {
+	args := m.Called(ctx, id, verification)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*publicModel.MFAVerificationModel), args.Error(1)
+}
+
+// Disable implements service.IMFAService.
+func (m *MockIMFAService) Disable(ctx context.Context, id string, code string) error {
+	args := m.Called(ctx, id, code)
+	return args.Error(0)
+}
+
+// Ensure that the mock implements the interface
+var _ service.IMFAService = &MockIMFAService{}
+
+func TestEnrollMFA_UsesCaller(t *testing.T) {
+	ctx := service.WithActor(context.Background(), service.Actor{UserID: "1"})
+
+	// Setup
+	mockMFAService := new(MockIMFAService)
+	mockMFAService.On("Enroll", ctx, "1").Return(&publicModel.MFAEnrollmentModel{Secret: "SECRET", URI: "otpauth://totp/BitBridge:test"}, nil)
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+	grpcserver.MFAService = mockMFAService
+
+	// Test
+	resp, err := grpcserver.EnrollMFA(ctx, &pb.EnrollMFARequest{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "SECRET", resp.Secret)
+	assert.Equal(t, "otpauth://totp/BitBridge:test", resp.Uri)
+}
+
+func TestVerifyMFA_Success(t *testing.T) {
+	// Setup
+	mockMFAService := new(MockIMFAService)
+	mockMFAService.On("Verify", mock.Anything, "1", &publicModel.VerifyMFAModel{Code: "123456", SourceIP: "10.0.0.1"}).
+		Return(&publicModel.MFAVerificationModel{Method: service.MFAMethodTOTP, RemainingRecoveryCodes: 9}, nil)
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+	grpcserver.MFAService = mockMFAService
+
+	// Test
+	resp, err := grpcserver.VerifyMFA(context.Background(), &pb.VerifyMFARequest{UserId: "1", Code: "123456", SourceIp: "10.0.0.1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "totp", resp.Method)
+	assert.Equal(t, int32(9), resp.RemainingRecoveryCodes)
+}
+
+func TestVerifyMFA_ErrorCodes(t *testing.T) {
+	tests := map[error]codes.Code{
+		service.ErrInvalidMFACode:                             codes.Unauthenticated,
+		service.ErrMFANotEnabled:                              codes.FailedPrecondition,
+		&service.AccountLockedError{Until: time.Now()}:        codes.PermissionDenied,
+		&service.LoginThrottledError{RetryAfter: time.Second}: codes.ResourceExhausted,
+	}
+
+	for serviceErr, expected := range tests {
+		// Setup
+		mockMFAService := new(MockIMFAService)
+		mockMFAService.On("Verify", mock.Anything, "1", mock.Anything).Return(nil, serviceErr)
+		grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+		grpcserver.MFAService = mockMFAService
+
+		// Test
+		_, err := grpcserver.VerifyMFA(context.Background(), &pb.VerifyMFARequest{UserId: "1", Code: "123456"})
+
+		// Assert
+		assert.Equal(t, expected, status.Code(err), serviceErr.Error())
+	}
+}
+
+func TestDisableMFA_DefaultsToCaller(t *testing.T) {
+	ctx := service.WithActor(context.Background(), service.Actor{UserID: "1"})
+
+	// Setup
+	mockMFAService := new(MockIMFAService)
+	mockMFAService.On("Disable", ctx, "1", "123456").Return(service.ErrInvalidMFACode)
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+	grpcserver.MFAService = mockMFAService
+
+	// Test
+	_, err := grpcserver.DisableMFA(ctx, &pb.DisableMFARequest{Code: "123456"})
+
+	// Assert
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockMFAService.AssertExpectations(t)
+}
+
+func TestConfirmMFA_Unimplemented(t *testing.T) {
+	// Setup
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	_, err := grpcserver.ConfirmMFA(context.Background(), &pb.ConfirmMFARequest{Code: "123456"})
+
+	// Assert
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+type MockIPrivacyService struct {
+	mock.Mock
+}
+
+func (m *MockIPrivacyService) Export(ctx context.Context, id string) (*privateModel.UserDataExport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*privateModel.UserDataExport), args.Error(1)
+}
+
+func (m *MockIPrivacyService) Erase(ctx context.Context, id string) (*publicModel.ErasureReceiptModel, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*publicModel.ErasureReceiptModel), args.Error(1)
+}
+
+// Ensure that the mock implements the interface
+var _ service.IPrivacyService = &MockIPrivacyService{}
+
+func TestExportUserData_Success(t *testing.T) {
+	id := primitive.NewObjectID()
+	export := &privateModel.UserDataExport{
+		ExportedAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		User:       &privateModel.PrivateUserModel{ID: id, Email: "test@mail.com", Username: "test"},
+	}
+
+	// Setup
+	mockPrivacyService := new(MockIPrivacyService)
+	mockPrivacyService.On("Export", mock.Anything, id.Hex()).Return(export, nil)
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+	grpcserver.PrivacyService = mockPrivacyService
+
+	// Test
+	resp, err := grpcserver.ExportUserData(context.Background(), &pb.ExportUserDataRequest{UserId: id.Hex()})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "user-"+id.Hex()+"-export.json", resp.Filename)
+	assert.Contains(t, string(resp.Archive), `"test@mail.com"`)
+	assert.Contains(t, string(resp.Archive), `"2024-03-01T12:00:00Z"`)
+}
+
+func TestExportUserData_Unimplemented(t *testing.T) {
+	// Setup
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	_, err := grpcserver.ExportUserData(context.Background(), &pb.ExportUserDataRequest{UserId: "1"})
+
+	// Assert
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestEraseUser_Success(t *testing.T) {
+	erasedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Setup
+	mockPrivacyService := new(MockIPrivacyService)
+	mockPrivacyService.On("Erase", mock.Anything, "1").Return(&publicModel.ErasureReceiptModel{
+		UserID:            "1",
+		ErasedAt:          erasedAt,
+		UsernameReleaseAt: erasedAt.Add(service.DefaultUsernameHold),
+		ErasureRef:        "ref",
+	}, nil)
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+	grpcserver.PrivacyService = mockPrivacyService
+
+	// Test
+	resp, err := grpcserver.EraseUser(context.Background(), &pb.EraseUserRequest{UserId: "1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-01T12:00:00Z", resp.ErasedAt)
+	assert.Equal(t, "2024-03-31T12:00:00Z", resp.UsernameReleaseAt)
+	assert.Equal(t, "ref", resp.ErasureRef)
+}
+
+func TestEraseUser_AlreadyErased(t *testing.T) {
+	// Setup
+	mockPrivacyService := new(MockIPrivacyService)
+	mockPrivacyService.On("Erase", mock.Anything, "1").Return(nil, service.ErrUserErased)
+	grpcserver := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+	grpcserver.PrivacyService = mockPrivacyService
+
+	// Test
+	_, err := grpcserver.EraseUser(context.Background(), &pb.EraseUserRequest{UserId: "1"})
+
+	// Assert
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestNewServer_Reflection(t *testing.T) {
+	// Setup
+	server := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+
+	// Test
+	withoutReflection := server.NewServer().GetServiceInfo()
+	server.Reflection = true
+	withReflection := server.NewServer().GetServiceInfo()
+
+	// Assert
+	assert.Contains(t, withoutReflection, pb.UserService_ServiceDesc.ServiceName)
+	assert.Contains(t, withoutReflection, pbv2.UserService_ServiceDesc.ServiceName)
+	assert.NotContains(t, withoutReflection, "grpc.reflection.v1.ServerReflection")
+	assert.Contains(t, withReflection, "grpc.reflection.v1.ServerReflection")
+	assert.Contains(t, withReflection, "grpc.reflection.v1alpha.ServerReflection")
+}
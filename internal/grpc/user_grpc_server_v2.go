@@ -0,0 +1,386 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/events"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/mapping"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb"
+	pbv2 "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb/v2"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UserGrpcServerV2 serves the bitbridge.user.v2 API next to the v1 API of UserGrpcServer.
+// It reads the services from Server on every call, so services set on Server after construction
+// are picked up by both versions.
+type UserGrpcServerV2 struct {
+	Server *UserGrpcServer
+	pbv2.UnimplementedUserServiceServer
+}
+
+// NewUserGrpcServerV2 creates a new instance of UserGrpcServerV2 backed by the services of server.
+func NewUserGrpcServerV2(server *UserGrpcServer) *UserGrpcServerV2 {
+	return &UserGrpcServerV2{
+		Server: server,
+	}
+}
+
+// WithV2Methods returns a copy of methods keyed by full v1 method names, with every v1 entry also
+// added under the name of the v2 method with the same name. It lets the interceptors treat both
+// versions of a method alike.
+func WithV2Methods[T any](methods map[string]T) map[string]T {
+	v1Prefix := "/" + pb.UserService_ServiceDesc.ServiceName + "/"
+	v2Prefix := "/" + pbv2.UserService_ServiceDesc.ServiceName + "/"
+
+	withV2 := make(map[string]T, 2*len(methods))
+	for method, value := range methods {
+		withV2[method] = value
+		if name, ok := strings.CutPrefix(method, v1Prefix); ok {
+			withV2[v2Prefix+name] = value
+		}
+	}
+	return withV2
+}
+
+func (s *UserGrpcServerV2) CreateUser(ctx context.Context, createUserModel *pbv2.CreateUserRequest) (*pbv2.PublicUser, error) {
+	user, err := s.Server.UserService.Create(withIdempotencyKey(ctx), &publicModel.CreateUserModel{
+		Email:    createUserModel.Email,
+		Username: createUserModel.Username,
+		Password: createUserModel.Password,
+		Profile:  mapping.NewProfileModelV2(createUserModel.Profile),
+	})
+	if err != nil {
+		return nil, createUserError(err)
+	}
+
+	return mapping.ToPublicUserV2(user.ToPublicUserModel()), nil
+}
+
+func (s *UserGrpcServerV2) GetPrivateUserByIdentifier(ctx context.Context, getUserByIdentifierModel *pbv2.GetPrivateUserByIdentifierRequest) (*pbv2.User, error) {
+	user, err := s.Server.UserService.FindByIdentifierPrivate(ctx, getUserByIdentifierModel.UserIdentifier)
+	if err != nil {
+		return nil, forbiddenError(err)
+	}
+
+	return mapping.ToUserV2(user.ToAccountUserModel(time.Now())), nil
+}
+
+func (s *UserGrpcServerV2) GetPublicUserByIdentifier(ctx context.Context, getPublicUserByIdentifierModel *pbv2.GetPublicUserByIdentifierRequest) (*pbv2.PublicUser, error) {
+	user, err := s.Server.UserService.FindByIdentifier(ctx, getPublicUserByIdentifierModel.UserIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.ToPublicUserV2(user.ToPublicUserModelFor(service.ActorFromContext(ctx).Audience())), nil
+}
+
+func (s *UserGrpcServerV2) BatchGetPublicUsers(ctx context.Context, batchIdentifierModel *pbv2.BatchGetPublicUsersRequest) (*pbv2.BatchGetPublicUsersResponse, error) {
+	users, err := s.Server.UserService.BatchFindByIdentifier(ctx, batchIdentifierModel.UserIdentifiers)
+	if err != nil {
+		if errors.Is(err, service.ErrBatchTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	audience := service.ActorFromContext(ctx).Audience()
+	results := make([]*pbv2.BatchGetPublicUsersResult, len(users))
+	for i, user := range users {
+		results[i] = &pbv2.BatchGetPublicUsersResult{UserIdentifier: batchIdentifierModel.UserIdentifiers[i]}
+		if user != nil {
+			results[i].Found = true
+			results[i].User = mapping.ToPublicUserV2(user.ToPublicUserModelFor(audience))
+		}
+	}
+
+	return &pbv2.BatchGetPublicUsersResponse{Results: results}, nil
+}
+
+var userEventTypesV2 = map[events.UserEventType]pbv2.UserEventType{
+	events.UserCreated: pbv2.UserEventType_USER_EVENT_TYPE_CREATED,
+	events.UserUpdated: pbv2.UserEventType_USER_EVENT_TYPE_UPDATED,
+	events.UserDeleted: pbv2.UserEventType_USER_EVENT_TYPE_DELETED,
+}
+
+func (s *UserGrpcServerV2) WatchUsers(watchUsersModel *pbv2.WatchUsersRequest, stream pbv2.UserService_WatchUsersServer) error {
+	err := s.Server.UserService.WatchUsers(stream.Context(), watchUsersModel.ResumeToken, func(event *events.UserChangeEvent) error {
+		userEvent := &pbv2.UserEvent{
+			Type:        userEventTypesV2[event.Type],
+			UserId:      event.UserID,
+			ResumeToken: event.ResumeToken,
+		}
+		if event.User != nil {
+			userEvent.User = mapping.ToPublicUserV2(event.User)
+		}
+		return stream.Send(userEvent)
+	})
+
+	return watchUsersError(err)
+}
+
+func (s *UserGrpcServerV2) ListAuditEvents(ctx context.Context, listAuditEventsModel *pbv2.ListAuditEventsRequest) (*pbv2.ListAuditEventsResponse, error) {
+	filter := repository.AuditFilter{
+		TargetUserID: listAuditEventsModel.TargetUserId,
+		Limit:        listAuditEventsModel.Limit,
+	}
+	if listAuditEventsModel.From != nil {
+		filter.From = listAuditEventsModel.From.AsTime()
+	}
+	if listAuditEventsModel.To != nil {
+		filter.To = listAuditEventsModel.To.AsTime()
+	}
+
+	auditEvents, err := s.Server.AuditService.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pbv2.ListAuditEventsResponse{Events: make([]*pbv2.AuditEvent, len(auditEvents))}
+	for i, auditEvent := range auditEvents {
+		changes := make([]*pbv2.AuditFieldChange, len(auditEvent.Changes))
+		for j, change := range auditEvent.Changes {
+			changes[j] = &pbv2.AuditFieldChange{Field: change.Field, OldValue: change.Old, NewValue: change.New}
+		}
+		response.Events[i] = &pbv2.AuditEvent{
+			Id:           auditEvent.ID.Hex(),
+			Sequence:     auditEvent.Sequence,
+			Actor:        auditEvent.Actor,
+			Action:       auditEvent.Action,
+			TargetUserId: auditEvent.TargetUserID,
+			SourceIp:     auditEvent.SourceIP,
+			RequestId:    auditEvent.RequestID,
+			Changes:      changes,
+			CreatedAt:    timestamppb.New(auditEvent.CreatedAt),
+			PrevHash:     auditEvent.PrevHash,
+			Hash:         auditEvent.Hash,
+		}
+	}
+
+	return response, nil
+}
+
+func (s *UserGrpcServerV2) GetMe(ctx context.Context, getMeModel *pbv2.GetMeRequest) (*pbv2.Me, error) {
+	user, err := s.Server.UserService.FindById(ctx, service.ActorFromContext(ctx).UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.ToMeV2(user.ToSelfUserModel()), nil
+}
+
+func (s *UserGrpcServerV2) UpdateMe(ctx context.Context, updateMeModel *pbv2.UpdateMeRequest) (*pbv2.Me, error) {
+	user, err := s.Server.UserService.Update(ctx, service.ActorFromContext(ctx).UserID, &publicModel.UpdateUserModel{
+		Email:           updateMeModel.Email,
+		Username:        updateMeModel.Username,
+		Password:        updateMeModel.Password,
+		Profile:         mapping.NewProfileModelV2(updateMeModel.Profile),
+		CurrentPassword: updateMeModel.CurrentPassword,
+	})
+	if err != nil {
+		return nil, updateMeError(err)
+	}
+
+	return mapping.ToMeV2(user.ToSelfUserModel()), nil
+}
+
+func (s *UserGrpcServerV2) ImportUser(ctx context.Context, importUserModel *pbv2.ImportUserRequest) (*pbv2.PublicUser, error) {
+	user, err := s.Server.UserService.Import(ctx, &publicModel.ImportUserModel{
+		Email:        importUserModel.Email,
+		Username:     importUserModel.Username,
+		PasswordHash: importUserModel.PasswordHash,
+		Profile:      mapping.NewProfileModelV2(importUserModel.Profile),
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedPasswordHash) || errors.Is(err, service.ErrInvalidProfile) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	return mapping.ToPublicUserV2(user.ToPublicUserModel()), nil
+}
+
+func (s *UserGrpcServerV2) VerifyCredentials(ctx context.Context, verifyCredentialsModel *pbv2.VerifyCredentialsRequest) (*pbv2.User, error) {
+	user, err := s.Server.UserService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{
+		Identifier: verifyCredentialsModel.UserIdentifier,
+		Password:   verifyCredentialsModel.Password,
+		SourceIP:   verifyCredentialsModel.SourceIp,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, lockoutError(err)
+	}
+
+	return mapping.ToUserV2(user.ToAccountUserModel(time.Now())), nil
+}
+
+func (s *UserGrpcServerV2) ResetPassword(ctx context.Context, resetPasswordModel *pbv2.ResetPasswordRequest) (*pbv2.ResetPasswordResponse, error) {
+	if err := s.Server.UserService.ResetPassword(ctx, resetPasswordModel.UserId, resetPasswordModel.Password); err != nil {
+		return nil, resetPasswordError(err)
+	}
+
+	return &pbv2.ResetPasswordResponse{}, nil
+}
+
+func (s *UserGrpcServerV2) UnlockUser(ctx context.Context, unlockUserModel *pbv2.UnlockUserRequest) (*pbv2.UnlockUserResponse, error) {
+	if s.Server.LockoutService == nil {
+		return nil, status.Error(codes.Unimplemented, "login tracking is not enabled")
+	}
+
+	if err := s.Server.LockoutService.Unlock(ctx, unlockUserModel.UserId); err != nil {
+		return nil, forbiddenError(err)
+	}
+
+	return &pbv2.UnlockUserResponse{}, nil
+}
+
+var loginResultsV2 = map[model.LoginResult]pbv2.LoginResult{
+	model.LoginSucceeded: pbv2.LoginResult_LOGIN_RESULT_SUCCESS,
+	model.LoginFailed:    pbv2.LoginResult_LOGIN_RESULT_FAILURE,
+	model.LoginRejected:  pbv2.LoginResult_LOGIN_RESULT_REJECTED,
+	model.LoginReset:     pbv2.LoginResult_LOGIN_RESULT_RESET,
+}
+
+func (s *UserGrpcServerV2) GetLoginHistory(ctx context.Context, getLoginHistoryModel *pbv2.GetLoginHistoryRequest) (*pbv2.GetLoginHistoryResponse, error) {
+	if s.Server.LockoutService == nil {
+		return nil, status.Error(codes.Unimplemented, "login tracking is not enabled")
+	}
+
+	attempts, err := s.Server.LockoutService.History(ctx, getLoginHistoryModel.UserId, getLoginHistoryModel.Limit)
+	if err != nil {
+		return nil, forbiddenError(err)
+	}
+
+	response := &pbv2.GetLoginHistoryResponse{Attempts: make([]*pbv2.LoginAttempt, len(attempts))}
+	for i, attempt := range attempts {
+		response.Attempts[i] = &pbv2.LoginAttempt{
+			Id:        attempt.ID.Hex(),
+			UserId:    attempt.UserID,
+			SourceIp:  attempt.SourceIP,
+			Result:    loginResultsV2[attempt.Result],
+			CreatedAt: timestamppb.New(attempt.CreatedAt),
+		}
+	}
+
+	return response, nil
+}
+
+func (s *UserGrpcServerV2) EnrollMFA(ctx context.Context, enrollMFAModel *pbv2.EnrollMFARequest) (*pbv2.EnrollMFAResponse, error) {
+	if s.Server.MFAService == nil {
+		return nil, status.Error(codes.Unimplemented, "multi-factor authentication is not enabled")
+	}
+
+	enrollment, err := s.Server.MFAService.Enroll(ctx, service.ActorFromContext(ctx).UserID)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &pbv2.EnrollMFAResponse{Secret: enrollment.Secret, Uri: enrollment.URI}, nil
+}
+
+func (s *UserGrpcServerV2) ConfirmMFA(ctx context.Context, confirmMFAModel *pbv2.ConfirmMFARequest) (*pbv2.ConfirmMFAResponse, error) {
+	if s.Server.MFAService == nil {
+		return nil, status.Error(codes.Unimplemented, "multi-factor authentication is not enabled")
+	}
+
+	recoveryCodes, err := s.Server.MFAService.Confirm(ctx, service.ActorFromContext(ctx).UserID, confirmMFAModel.Code)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &pbv2.ConfirmMFAResponse{RecoveryCodes: recoveryCodes.RecoveryCodes}, nil
+}
+
+var mfaMethodsV2 = map[string]pbv2.MFAMethod{
+	service.MFAMethodTOTP:         pbv2.MFAMethod_MFA_METHOD_TOTP,
+	service.MFAMethodRecoveryCode: pbv2.MFAMethod_MFA_METHOD_RECOVERY_CODE,
+}
+
+func (s *UserGrpcServerV2) VerifyMFA(ctx context.Context, verifyMFAModel *pbv2.VerifyMFARequest) (*pbv2.VerifyMFAResponse, error) {
+	if s.Server.MFAService == nil {
+		return nil, status.Error(codes.Unimplemented, "multi-factor authentication is not enabled")
+	}
+
+	verification, err := s.Server.MFAService.Verify(ctx, verifyMFAModel.UserId, &publicModel.VerifyMFAModel{
+		Code:     verifyMFAModel.Code,
+		SourceIP: verifyMFAModel.SourceIp,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidMFACode) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, mfaError(err)
+	}
+
+	return &pbv2.VerifyMFAResponse{
+		Method:                 mfaMethodsV2[verification.Method],
+		RemainingRecoveryCodes: int32(verification.RemainingRecoveryCodes),
+	}, nil
+}
+
+// DisableMFA disables MFA for the given user, or for the caller when no user is given.
+func (s *UserGrpcServerV2) DisableMFA(ctx context.Context, disableMFAModel *pbv2.DisableMFARequest) (*pbv2.DisableMFAResponse, error) {
+	if s.Server.MFAService == nil {
+		return nil, status.Error(codes.Unimplemented, "multi-factor authentication is not enabled")
+	}
+
+	userId := disableMFAModel.UserId
+	if userId == "" {
+		userId = service.ActorFromContext(ctx).UserID
+	}
+	if err := s.Server.MFAService.Disable(ctx, userId, disableMFAModel.Code); err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &pbv2.DisableMFAResponse{}, nil
+}
+
+func (s *UserGrpcServerV2) ExportUserData(ctx context.Context, exportUserDataModel *pbv2.ExportUserDataRequest) (*pbv2.ExportUserDataResponse, error) {
+	if s.Server.PrivacyService == nil {
+		return nil, status.Error(codes.Unimplemented, "data subject requests are not enabled")
+	}
+
+	export, err := s.Server.PrivacyService.Export(ctx, exportUserDataModel.UserId)
+	if err != nil {
+		return nil, privacyError(err)
+	}
+
+	archive, err := json.Marshal(export)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbv2.ExportUserDataResponse{Archive: archive, Filename: export.Filename()}, nil
+}
+
+func (s *UserGrpcServerV2) EraseUser(ctx context.Context, eraseUserModel *pbv2.EraseUserRequest) (*pbv2.EraseUserResponse, error) {
+	if s.Server.PrivacyService == nil {
+		return nil, status.Error(codes.Unimplemented, "data subject requests are not enabled")
+	}
+
+	receipt, err := s.Server.PrivacyService.Erase(ctx, eraseUserModel.UserId)
+	if err != nil {
+		return nil, privacyError(err)
+	}
+
+	return &pbv2.EraseUserResponse{
+		ErasedAt:          timestamppb.New(receipt.ErasedAt),
+		UsernameReleaseAt: timestamppb.New(receipt.UsernameReleaseAt),
+		ErasureRef:        receipt.ErasureRef,
+	}, nil
+}
+
+// Ensure UserGrpcServerV2 implements the v2 service
+var _ pbv2.UserServiceServer = (*UserGrpcServerV2)(nil)
@@ -0,0 +1,160 @@
+package grpcserver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	grpcserver "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/grpc"
+	privateModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb"
+	pbv2 "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb/v2"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestWithV2Methods(t *testing.T) {
+	// Setup
+	methods := map[string]authz.Requirement{
+		pb.UserService_CreateUser_FullMethodName: {Any: authz.PermissionCreate},
+		"/other.Service/Method":                  {Any: authz.PermissionReadAudit},
+	}
+
+	// Test
+	withV2 := grpcserver.WithV2Methods(methods)
+
+	// Assert
+	assert.Len(t, withV2, 3)
+	assert.Equal(t, authz.Requirement{Any: authz.PermissionCreate}, withV2[pbv2.UserService_CreateUser_FullMethodName])
+	assert.Equal(t, authz.Requirement{Any: authz.PermissionCreate}, withV2[pb.UserService_CreateUser_FullMethodName])
+	assert.Len(t, methods, 2)
+}
+
+func TestGetPrivateUserByIdentifierV2_Timestamps(t *testing.T) {
+	createdAt := time.Date(2023, 11, 1, 12, 0, 0, 0, time.UTC)
+	lockedUntil := createdAt.Add(time.Hour)
+	user := &privateModel.PrivateUserModel{
+		ID:          primitive.NewObjectID(),
+		Email:       "test@mail.com",
+		Username:    "test",
+		LockedUntil: &lockedUntil,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt.Add(time.Minute),
+	}
+
+	// Setup
+	mockUserService := new(MockIUserService)
+	mockUserService.On("FindByIdentifierPrivate", mock.Anything, "test").Return(user, nil)
+	grpcserver := grpcserver.NewUserGrpcServerV2(grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{}))
+
+	// Test
+	resp, err := grpcserver.GetPrivateUserByIdentifier(context.Background(), &pbv2.GetPrivateUserByIdentifierRequest{UserIdentifier: "test"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID.Hex(), resp.Id)
+	assert.Equal(t, createdAt, resp.CreatedAt.AsTime())
+	assert.Equal(t, createdAt.Add(time.Minute), resp.UpdatedAt.AsTime())
+	assert.Equal(t, lockedUntil, resp.LockedUntil.AsTime())
+}
+
+func TestGetPublicUserByIdentifierV2_Success(t *testing.T) {
+	user := &privateModel.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test", CreatedAt: time.Now()}
+
+	// Setup
+	mockUserService := new(MockIUserService)
+	mockUserService.On("FindByIdentifier", mock.Anything, "test").Return(user, nil)
+	grpcserver := grpcserver.NewUserGrpcServerV2(grpcserver.NewUserGrpcServer(mockUserService, nil, []grpc.UnaryServerInterceptor{}))
+
+	// Test
+	resp, err := grpcserver.GetPublicUserByIdentifier(context.Background(), &pbv2.GetPublicUserByIdentifierRequest{UserIdentifier: "test"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "test", resp.Username)
+	assert.True(t, resp.CreatedAt.IsValid())
+}
+
+func TestListAuditEventsV2_TimeRange(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	auditEvent := &privateModel.AuditEvent{ID: primitive.NewObjectID(), Sequence: 7, CreatedAt: from.Add(time.Hour)}
+
+	// Setup
+	mockAuditService := new(MockIAuditService)
+	mockAuditService.On("List", mock.Anything, repository.AuditFilter{TargetUserID: "target", From: from, Limit: 10}).
+		Return([]*privateModel.AuditEvent{auditEvent}, nil)
+	grpcserver := grpcserver.NewUserGrpcServerV2(grpcserver.NewUserGrpcServer(new(MockIUserService), mockAuditService, []grpc.UnaryServerInterceptor{}))
+
+	// Test
+	resp, err := grpcserver.ListAuditEvents(context.Background(), &pbv2.ListAuditEventsRequest{
+		TargetUserId: "target",
+		From:         timestamppb.New(from),
+		Limit:        10,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Events, 1) {
+		assert.Equal(t, from.Add(time.Hour), resp.Events[0].CreatedAt.AsTime())
+	}
+	mockAuditService.AssertExpectations(t)
+}
+
+func TestGetLoginHistoryV2_ResultEnum(t *testing.T) {
+	attempt := &privateModel.LoginAttempt{ID: primitive.NewObjectID(), UserID: "1", Result: privateModel.LoginRejected, CreatedAt: time.Now()}
+
+	// Setup
+	mockLockoutService := new(MockILockoutService)
+	mockLockoutService.On("History", mock.Anything, "1", int64(20)).Return([]*privateModel.LoginAttempt{attempt}, nil)
+	server := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+	grpcserver := grpcserver.NewUserGrpcServerV2(server)
+	// Services set after construction are shared with v2
+	server.LockoutService = mockLockoutService
+
+	// Test
+	resp, err := grpcserver.GetLoginHistory(context.Background(), &pbv2.GetLoginHistoryRequest{UserId: "1", Limit: 20})
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Attempts, 1) {
+		assert.Equal(t, pbv2.LoginResult_LOGIN_RESULT_REJECTED, resp.Attempts[0].Result)
+	}
+}
+
+func TestVerifyMFAV2_MethodEnum(t *testing.T) {
+	// Setup
+	mockMFAService := new(MockIMFAService)
+	mockMFAService.On("Verify", mock.Anything, "1", mock.Anything).
+		Return(&publicModel.MFAVerificationModel{Method: service.MFAMethodRecoveryCode, RemainingRecoveryCodes: 3}, nil)
+	server := grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{})
+	server.MFAService = mockMFAService
+	grpcserver := grpcserver.NewUserGrpcServerV2(server)
+
+	// Test
+	resp, err := grpcserver.VerifyMFA(context.Background(), &pbv2.VerifyMFARequest{UserId: "1", Code: "abcd-efgh"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, pbv2.MFAMethod_MFA_METHOD_RECOVERY_CODE, resp.Method)
+	assert.Equal(t, int32(3), resp.RemainingRecoveryCodes)
+}
+
+func TestEraseUserV2_Unimplemented(t *testing.T) {
+	// Setup
+	grpcserver := grpcserver.NewUserGrpcServerV2(grpcserver.NewUserGrpcServer(new(MockIUserService), nil, []grpc.UnaryServerInterceptor{}))
+
+	// Test
+	_, err := grpcserver.EraseUser(context.Background(), &pbv2.EraseUserRequest{UserId: "1"})
+
+	// Assert
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
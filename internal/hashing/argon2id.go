@@ -0,0 +1,131 @@
+package hashing
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"strconv"
+
+	"golang.org/x/crypto/argon2"
+)
+
+type Argon2idParams struct {
+	// Memory is in KiB.
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// Bounds of the parameters accepted in argon2id hashes. Hashes may be imported from other systems, and
+// larger parameters would let a single verification exhaust the memory or CPU of the service.
+const (
+	// MaxArgon2idMemory is in KiB.
+	MaxArgon2idMemory      = 256 * 1024
+	MaxArgon2idIterations  = 16
+	MaxArgon2idParallelism = 16
+)
+
+// DefaultArgon2idParams follow the OWASP recommendation for argon2id.
+var DefaultArgon2idParams = Argon2idParams{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Argon2idHasher is an implementation of IHasher producing $argon2id$ PHC strings.
+type Argon2idHasher struct {
+	Params Argon2idParams
+}
+
+// NewArgon2idHasher creates a new instance of Argon2idHasher.
+func NewArgon2idHasher(params Argon2idParams) *Argon2idHasher {
+	return &Argon2idHasher{
+		Params: params,
+	}
+}
+
+// Identifies implements IHasher.
+func (h *Argon2idHasher) Identifies(encoded string) bool {
+	return phcID(encoded) == "argon2id"
+}
+
+// Hash implements IHasher.
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.Params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	phc := &phcHash{
+		ID:      "argon2id",
+		Version: argon2.Version,
+		Params: []phcParam{
+			{Name: "m", Value: strconv.FormatUint(uint64(h.Params.Memory), 10)},
+			{Name: "t", Value: strconv.FormatUint(uint64(h.Params.Iterations), 10)},
+			{Name: "p", Value: strconv.FormatUint(uint64(h.Params.Parallelism), 10)},
+		},
+		Salt: salt,
+		Hash: argon2.IDKey([]byte(password), salt, h.Params.Iterations, h.Params.Memory, h.Params.Parallelism, h.Params.KeyLength),
+	}
+	return phc.String(), nil
+}
+
+// Verify implements IHasher.
+func (h *Argon2idHasher) Verify(encoded string, password string) error {
+	phc, params, err := h.decode(encoded)
+	if err != nil {
+		return err
+	}
+
+	key := argon2.IDKey([]byte(password), phc.Salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(phc.Hash)))
+	if subtle.ConstantTimeCompare(key, phc.Hash) != 1 {
+		return ErrMismatch
+	}
+	return nil
+}
+
+// NeedsRehash implements IHasher.
+func (h *Argon2idHasher) NeedsRehash(encoded string) bool {
+	phc, params, err := h.decode(encoded)
+	if err != nil {
+		return true
+	}
+	return phc.Version != argon2.Version ||
+		params.Memory != h.Params.Memory ||
+		params.Iterations != h.Params.Iterations ||
+		params.Parallelism != h.Params.Parallelism ||
+		uint32(len(phc.Hash)) != h.Params.KeyLength
+}
+
+// Validate implements IHasher.
+func (h *Argon2idHasher) Validate(encoded string) error {
+	_, _, err := h.decode(encoded)
+	return err
+}
+
+func (h *Argon2idHasher) decode(encoded string) (*phcHash, Argon2idParams, error) {
+	phc, err := parsePHC(encoded)
+	if err != nil || phc.ID != "argon2id" || len(phc.Salt) == 0 || len(phc.Hash) == 0 {
+		return nil, Argon2idParams{}, ErrMalformedHash
+	}
+
+	memory, err := phc.uintParam("m", 32)
+	if err != nil || memory > MaxArgon2idMemory {
+		return nil, Argon2idParams{}, ErrMalformedHash
+	}
+	iterations, err := phc.uintParam("t", 32)
+	if err != nil || iterations < 1 || iterations > MaxArgon2idIterations {
+		return nil, Argon2idParams{}, ErrMalformedHash
+	}
+	parallelism, err := phc.uintParam("p", 8)
+	if err != nil || parallelism < 1 || parallelism > MaxArgon2idParallelism {
+		return nil, Argon2idParams{}, ErrMalformedHash
+	}
+
+	return phc, Argon2idParams{Memory: uint32(memory), Iterations: uint32(iterations), Parallelism: uint8(parallelism)}, nil
+}
+
+var _ IHasher = (*Argon2idHasher)(nil)
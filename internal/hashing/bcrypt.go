@@ -0,0 +1,78 @@
+package hashing
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultBcryptCost is used by BcryptHasher when no cost is configured.
+const DefaultBcryptCost = 12
+
+// MaxBcryptCost bounds the cost accepted in bcrypt hashes, so that a single verification of an imported
+// hash cannot tie up the CPU. bcrypt itself accepts up to 31.
+const MaxBcryptCost = 16
+
+// bcryptHashLength is the length of every bcrypt hash in modular crypt format.
+const bcryptHashLength = 60
+
+// BcryptHasher is an implementation of IHasher for bcrypt. bcrypt predates PHC and keeps its own
+// modular crypt format ($2a$, $2b$, $2y$), which is what hashes made by earlier releases use.
+type BcryptHasher struct {
+	Cost int
+}
+
+// NewBcryptHasher creates a new instance of BcryptHasher.
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{
+		Cost: cost,
+	}
+}
+
+// Identifies implements IHasher.
+func (h *BcryptHasher) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+// Hash implements IHasher.
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify implements IHasher.
+func (h *BcryptHasher) Verify(encoded string, password string) error {
+	if err := h.Validate(encoded); err != nil {
+		return err
+	}
+
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrMismatch
+	}
+	if err != nil {
+		return ErrMalformedHash
+	}
+	return nil
+}
+
+// NeedsRehash implements IHasher.
+func (h *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != h.Cost
+}
+
+// Validate implements IHasher.
+func (h *BcryptHasher) Validate(encoded string) error {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil || cost > MaxBcryptCost || len(encoded) != bcryptHashLength {
+		return ErrMalformedHash
+	}
+	return nil
+}
+
+var _ IHasher = (*BcryptHasher)(nil)
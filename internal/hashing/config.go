@@ -0,0 +1,100 @@
+package hashing
+
+import (
+	"fmt"
+	"strconv"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Environment variables read by ConfigFromEnv. Unset variables keep the value of DefaultConfig.
+const (
+	EnvAlgorithm           = "PASSWORD_HASH_ALGORITHM"
+	EnvArgon2idMemory      = "PASSWORD_HASH_ARGON2ID_MEMORY"
+	EnvArgon2idIterations  = "PASSWORD_HASH_ARGON2ID_ITERATIONS"
+	EnvArgon2idParallelism = "PASSWORD_HASH_ARGON2ID_PARALLELISM"
+	EnvScryptLogN          = "PASSWORD_HASH_SCRYPT_LOGN"
+	EnvScryptR             = "PASSWORD_HASH_SCRYPT_R"
+	EnvScryptP             = "PASSWORD_HASH_SCRYPT_P"
+	EnvBcryptCost          = "PASSWORD_HASH_BCRYPT_COST"
+)
+
+// ConfigFromEnv reads the algorithm and work factors for new hashes through lookup, usually os.LookupEnv,
+// starting from DefaultConfig. The result is validated, so that the service never produces hashes it
+// would itself refuse to verify.
+func ConfigFromEnv(lookup func(string) (string, bool)) (Config, error) {
+	config := DefaultConfig()
+	if value, ok := lookup(EnvAlgorithm); ok && value != "" {
+		config.Algorithm = value
+	}
+
+	fields := []struct {
+		name    string
+		bitSize int
+		set     func(uint64)
+	}{
+		{EnvArgon2idMemory, 32, func(v uint64) { config.Argon2id.Memory = uint32(v) }},
+		{EnvArgon2idIterations, 32, func(v uint64) { config.Argon2id.Iterations = uint32(v) }},
+		{EnvArgon2idParallelism, 8, func(v uint64) { config.Argon2id.Parallelism = uint8(v) }},
+		{EnvScryptLogN, 8, func(v uint64) { config.Scrypt.LogN = uint8(v) }},
+		{EnvScryptR, 16, func(v uint64) { config.Scrypt.R = int(v) }},
+		{EnvScryptP, 16, func(v uint64) { config.Scrypt.P = int(v) }},
+		{EnvBcryptCost, 8, func(v uint64) { config.BcryptCost = int(v) }},
+	}
+	for _, field := range fields {
+		value, ok := lookup(field.name)
+		if !ok || value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 10, field.bitSize)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s %q", field.name, value)
+		}
+		field.set(parsed)
+	}
+
+	if err := config.Validate(); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// Validate checks the configured work factors against the bounds accepted when verifying hashes.
+func (config Config) Validate() error {
+	switch config.Algorithm {
+	case AlgorithmArgon2id, AlgorithmBcrypt, AlgorithmScrypt:
+	default:
+		return fmt.Errorf("unsupported password hash algorithm %q", config.Algorithm)
+	}
+
+	argon2id := config.Argon2id
+	if argon2id.Parallelism < 1 || argon2id.Parallelism > MaxArgon2idParallelism {
+		return fmt.Errorf("argon2id parallelism %d out of range [1, %d]", argon2id.Parallelism, MaxArgon2idParallelism)
+	}
+	if argon2id.Iterations < 1 || argon2id.Iterations > MaxArgon2idIterations {
+		return fmt.Errorf("argon2id iterations %d out of range [1, %d]", argon2id.Iterations, MaxArgon2idIterations)
+	}
+	// argon2 needs at least 8 KiB per lane
+	if minMemory := 8 * uint32(argon2id.Parallelism); argon2id.Memory < minMemory || argon2id.Memory > MaxArgon2idMemory {
+		return fmt.Errorf("argon2id memory %d KiB out of range [%d, %d]", argon2id.Memory, minMemory, MaxArgon2idMemory)
+	}
+
+	scrypt := config.Scrypt
+	if scrypt.LogN < 1 || scrypt.LogN > MaxScryptLogN {
+		return fmt.Errorf("scrypt log2 cost %d out of range [1, %d]", scrypt.LogN, MaxScryptLogN)
+	}
+	if scrypt.R < 1 || scrypt.R > MaxScryptR {
+		return fmt.Errorf("scrypt block size %d out of range [1, %d]", scrypt.R, MaxScryptR)
+	}
+	if scrypt.P < 1 || scrypt.P > MaxScryptP {
+		return fmt.Errorf("scrypt parallelism %d out of range [1, %d]", scrypt.P, MaxScryptP)
+	}
+	if memory := 128 * scrypt.R << scrypt.LogN; memory > MaxScryptMemory {
+		return fmt.Errorf("scrypt memory %d bytes exceeds %d", memory, MaxScryptMemory)
+	}
+
+	if config.BcryptCost < bcrypt.MinCost || config.BcryptCost > MaxBcryptCost {
+		return fmt.Errorf("bcrypt cost %d out of range [%d, %d]", config.BcryptCost, bcrypt.MinCost, MaxBcryptCost)
+	}
+	return nil
+}
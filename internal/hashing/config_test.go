@@ -0,0 +1,91 @@
+package hashing_test
+
+import (
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/hashing"
+	"github.com/stretchr/testify/assert"
+)
+
+func lookupFrom(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+func TestConfigFromEnv_Defaults(t *testing.T) {
+	// Act
+	config, err := hashing.ConfigFromEnv(lookupFrom(nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, hashing.DefaultConfig(), config)
+}
+
+func TestConfigFromEnv_Overrides(t *testing.T) {
+	// Arrange
+	env := map[string]string{
+		hashing.EnvAlgorithm:           hashing.AlgorithmScrypt,
+		hashing.EnvArgon2idMemory:      "19456",
+		hashing.EnvArgon2idIterations:  "2",
+		hashing.EnvArgon2idParallelism: "1",
+		hashing.EnvScryptLogN:          "17",
+		hashing.EnvScryptR:             "8",
+		hashing.EnvScryptP:             "2",
+		hashing.EnvBcryptCost:          "13",
+	}
+
+	// Act
+	config, err := hashing.ConfigFromEnv(lookupFrom(env))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, hashing.AlgorithmScrypt, config.Algorithm)
+	assert.Equal(t, uint32(19456), config.Argon2id.Memory)
+	assert.Equal(t, uint32(2), config.Argon2id.Iterations)
+	assert.Equal(t, uint8(1), config.Argon2id.Parallelism)
+	assert.Equal(t, hashing.DefaultArgon2idParams.KeyLength, config.Argon2id.KeyLength)
+	assert.Equal(t, uint8(17), config.Scrypt.LogN)
+	assert.Equal(t, 8, config.Scrypt.R)
+	assert.Equal(t, 2, config.Scrypt.P)
+	assert.Equal(t, 13, config.BcryptCost)
+}
+
+func TestConfigFromEnv_Invalid(t *testing.T) {
+	cases := map[string]map[string]string{
+		"unsupported algorithm":    {hashing.EnvAlgorithm: "md5"},
+		"not a number":             {hashing.EnvBcryptCost: "twelve"},
+		"negative":                 {hashing.EnvScryptR: "-8"},
+		"argon2id huge memory":     {hashing.EnvArgon2idMemory: "1048576"},
+		"argon2id tiny memory":     {hashing.EnvArgon2idMemory: "8"},
+		"argon2id zero iterations": {hashing.EnvArgon2idIterations: "0"},
+		"argon2id many lanes":      {hashing.EnvArgon2idParallelism: "64"},
+		"argon2id lanes overflow":  {hashing.EnvArgon2idParallelism: "257"},
+		"scrypt huge cost":         {hashing.EnvScryptLogN: "24"},
+		"scrypt huge memory":       {hashing.EnvScryptLogN: "20", hashing.EnvScryptR: "16"},
+		"scrypt zero parallelism":  {hashing.EnvScryptP: "0"},
+		"bcrypt low cost":          {hashing.EnvBcryptCost: "3"},
+		"bcrypt huge cost":         {hashing.EnvBcryptCost: "20"},
+	}
+
+	for name, env := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := hashing.ConfigFromEnv(lookupFrom(env))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewRegistryFromConfig_ValidatesParameters(t *testing.T) {
+	// Arrange
+	config := hashing.DefaultConfig()
+	config.Argon2id.Iterations = hashing.MaxArgon2idIterations + 1
+
+	// Act
+	registry, err := hashing.NewRegistryFromConfig(config)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, registry)
+}
@@ -0,0 +1,94 @@
+package hashing
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// MaxDjangoIterations bounds the work factor accepted in imported Django hashes, so that a single
+// verification cannot tie up the CPU. It leaves headroom above the 1,000,000 iterations of Django 5.2.
+const MaxDjangoIterations = 2000000
+
+// DjangoPBKDF2Hasher is an implementation of IHasher for hashes imported from Django applications,
+// stored as pbkdf2_sha256$<iterations>$<salt>$<base64 hash>. It is registered so that imported
+// accounts can sign in, and is not meant to be the preferred hasher.
+type DjangoPBKDF2Hasher struct {
+	Iterations int
+}
+
+// NewDjangoPBKDF2Hasher creates a new instance of DjangoPBKDF2Hasher.
+func NewDjangoPBKDF2Hasher(iterations int) *DjangoPBKDF2Hasher {
+	return &DjangoPBKDF2Hasher{
+		Iterations: iterations,
+	}
+}
+
+// Identifies implements IHasher.
+func (h *DjangoPBKDF2Hasher) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, "pbkdf2_sha256$")
+}
+
+// Hash implements IHasher.
+func (h *DjangoPBKDF2Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, 12)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	saltString := base64.RawURLEncoding.EncodeToString(salt)
+
+	key := pbkdf2.Key([]byte(password), []byte(saltString), h.Iterations, sha256.Size, sha256.New)
+	return fmt.Sprintf("pbkdf2_sha256$%d$%s$%s", h.Iterations, saltString, base64.StdEncoding.EncodeToString(key)), nil
+}
+
+// Verify implements IHasher.
+func (h *DjangoPBKDF2Hasher) Verify(encoded string, password string) error {
+	iterations, salt, hash, err := h.decode(encoded)
+	if err != nil {
+		return err
+	}
+
+	key := pbkdf2.Key([]byte(password), []byte(salt), iterations, len(hash), sha256.New)
+	if subtle.ConstantTimeCompare(key, hash) != 1 {
+		return ErrMismatch
+	}
+	return nil
+}
+
+// NeedsRehash implements IHasher.
+func (h *DjangoPBKDF2Hasher) NeedsRehash(encoded string) bool {
+	iterations, _, _, err := h.decode(encoded)
+	return err != nil || iterations != h.Iterations
+}
+
+// Validate implements IHasher.
+func (h *DjangoPBKDF2Hasher) Validate(encoded string) error {
+	_, _, _, err := h.decode(encoded)
+	return err
+}
+
+func (h *DjangoPBKDF2Hasher) decode(encoded string) (int, string, []byte, error) {
+	fields := strings.Split(encoded, "$")
+	if len(fields) != 4 || fields[0] != "pbkdf2_sha256" || fields[2] == "" {
+		return 0, "", nil, ErrMalformedHash
+	}
+
+	iterations, err := strconv.Atoi(fields[1])
+	if err != nil || iterations < 1 || iterations > MaxDjangoIterations {
+		return 0, "", nil, ErrMalformedHash
+	}
+	hash, err := base64.StdEncoding.DecodeString(fields[3])
+	if err != nil || len(hash) == 0 {
+		return 0, "", nil, ErrMalformedHash
+	}
+
+	return iterations, fields[2], hash, nil
+}
+
+var _ IHasher = (*DjangoPBKDF2Hasher)(nil)
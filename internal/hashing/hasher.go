@@ -0,0 +1,25 @@
+package hashing
+
+import "errors"
+
+var (
+	// ErrMismatch is returned when a password does not match a hash.
+	ErrMismatch = errors.New("password does not match")
+	// ErrUnknownFormat is returned for hashes no registered hasher recognises.
+	ErrUnknownFormat = errors.New("unrecognised password hash format")
+	// ErrMalformedHash is returned for hashes that look like a known format but cannot be decoded, or
+	// whose parameters are outside the bounds the hasher accepts.
+	ErrMalformedHash = errors.New("malformed password hash")
+)
+
+// IHasher hashes passwords with one algorithm and verifies hashes in that algorithm's format.
+type IHasher interface {
+	// Identifies reports whether the encoded hash is in this hasher's format.
+	Identifies(encoded string) bool
+	Hash(password string) (string, error)
+	Verify(encoded string, password string) error
+	// NeedsRehash reports whether the encoded hash was made with parameters other than the hasher's own.
+	NeedsRehash(encoded string) bool
+	// Validate fully decodes the encoded hash, failing with ErrMalformedHash when it could not be verified.
+	Validate(encoded string) error
+}
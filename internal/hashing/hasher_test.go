@@ -0,0 +1,145 @@
+package hashing_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/hashing"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Cheap parameters keep the tests fast; they are not meant for production use.
+var testArgon2idParams = hashing.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+var testScryptParams = hashing.ScryptParams{LogN: 4, R: 8, P: 1, SaltLength: 16, KeyLength: 32}
+
+func TestHashers_RoundTrip(t *testing.T) {
+	hashers := map[string]hashing.IHasher{
+		"argon2id": hashing.NewArgon2idHasher(testArgon2idParams),
+		"bcrypt":   hashing.NewBcryptHasher(bcrypt.MinCost),
+		"scrypt":   hashing.NewScryptHasher(testScryptParams),
+		"django":   hashing.NewDjangoPBKDF2Hasher(1000),
+	}
+
+	for name, hasher := range hashers {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			encoded, err := hasher.Hash("correct horse")
+
+			// Assert
+			assert.NoError(t, err)
+			assert.True(t, hasher.Identifies(encoded))
+			assert.NoError(t, hasher.Verify(encoded, "correct horse"))
+			assert.ErrorIs(t, hasher.Verify(encoded, "wrong horse"), hashing.ErrMismatch)
+			assert.False(t, hasher.NeedsRehash(encoded))
+		})
+	}
+}
+
+func TestArgon2idHasher_Format(t *testing.T) {
+	// Arrange
+	hasher := hashing.NewArgon2idHasher(testArgon2idParams)
+
+	// Act
+	encoded, err := hasher.Hash("correct horse")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=1,p=1$"))
+	assert.Len(t, strings.Split(encoded, "$"), 6)
+}
+
+func TestArgon2idHasher_NeedsRehashWhenParametersChange(t *testing.T) {
+	// Arrange
+	encoded, _ := hashing.NewArgon2idHasher(testArgon2idParams).Hash("correct horse")
+	stronger := testArgon2idParams
+	stronger.Iterations = 2
+	hasher := hashing.NewArgon2idHasher(stronger)
+
+	// Act & Assert
+	assert.NoError(t, hasher.Verify(encoded, "correct horse"))
+	assert.True(t, hasher.NeedsRehash(encoded))
+}
+
+func TestScryptHasher_VerifiesExternalHash(t *testing.T) {
+	// Produced by Python's hashlib.scrypt with n=1024, r=8, p=1
+	encoded := "$scrypt$ln=10,r=8,p=1$MDEyMzQ1Njc4OWFiY2RlZg$6g3umF+uVrJsObaTZhIbbTlgrvOEFcCItdwSjtPF67M"
+	hasher := hashing.NewScryptHasher(testScryptParams)
+
+	// Act & Assert
+	assert.NoError(t, hasher.Verify(encoded, "correct horse"))
+	assert.True(t, hasher.NeedsRehash(encoded))
+}
+
+func TestBcryptHasher_NeedsRehashWhenCostChanges(t *testing.T) {
+	// Arrange
+	encoded, _ := hashing.NewBcryptHasher(bcrypt.MinCost).Hash("correct horse")
+	hasher := hashing.NewBcryptHasher(bcrypt.MinCost + 1)
+
+	// Act & Assert
+	assert.NoError(t, hasher.Verify(encoded, "correct horse"))
+	assert.True(t, hasher.NeedsRehash(encoded))
+}
+
+func TestDjangoPBKDF2Hasher_VerifiesLegacyHash(t *testing.T) {
+	// Produced by Python's hashlib.pbkdf2_hmac, as Django stores it
+	encoded := "pbkdf2_sha256$1000$seasalt$mQnueSakb748zqBAC1tmWVZsZbi2zPGZarEzTGdfmso="
+	hasher := hashing.NewDjangoPBKDF2Hasher(hashing.DefaultDjangoIterations)
+
+	// Act & Assert
+	assert.True(t, hasher.Identifies(encoded))
+	assert.NoError(t, hasher.Verify(encoded, "correct horse"))
+	assert.ErrorIs(t, hasher.Verify(encoded, "wrong horse"), hashing.ErrMismatch)
+	assert.True(t, hasher.NeedsRehash(encoded))
+}
+
+func TestHashers_MalformedHashes(t *testing.T) {
+	cases := map[string]struct {
+		hasher  hashing.IHasher
+		encoded string
+	}{
+		"argon2id missing params": {hashing.NewArgon2idHasher(testArgon2idParams), "$argon2id$v=19$c2FsdA$aGFzaA"},
+		"argon2id bad base64":     {hashing.NewArgon2idHasher(testArgon2idParams), "$argon2id$v=19$m=64,t=1,p=1$!!!$aGFzaA"},
+		"argon2id zero lanes":     {hashing.NewArgon2idHasher(testArgon2idParams), "$argon2id$v=19$m=64,t=1,p=0$c2FsdA$aGFzaA"},
+		"scrypt missing hash":     {hashing.NewScryptHasher(testScryptParams), "$scrypt$ln=4,r=8,p=1$c2FsdA"},
+		"bcrypt truncated":        {hashing.NewBcryptHasher(bcrypt.MinCost), "$2a$04$short"},
+		"django bad iterations":   {hashing.NewDjangoPBKDF2Hasher(1000), "pbkdf2_sha256$many$salt$aGFzaA=="},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, tc.hasher.Verify(tc.encoded, "correct horse"), hashing.ErrMalformedHash)
+			assert.ErrorIs(t, tc.hasher.Validate(tc.encoded), hashing.ErrMalformedHash)
+			assert.True(t, tc.hasher.NeedsRehash(tc.encoded))
+		})
+	}
+}
+
+// Each of these would panic or exhaust the service if it reached the key derivation.
+func TestHashers_ParametersOutOfBounds(t *testing.T) {
+	cases := map[string]struct {
+		hasher  hashing.IHasher
+		encoded string
+	}{
+		"argon2id zero iterations":   {hashing.NewArgon2idHasher(testArgon2idParams), "$argon2id$v=19$m=64,t=0,p=1$c2FsdA$aGFzaA"},
+		"argon2id many iterations":   {hashing.NewArgon2idHasher(testArgon2idParams), "$argon2id$v=19$m=64,t=4294967295,p=1$c2FsdA$aGFzaA"},
+		"argon2id huge memory":       {hashing.NewArgon2idHasher(testArgon2idParams), "$argon2id$v=19$m=4294967295,t=1,p=1$c2FsdA$aGFzaA"},
+		"argon2id many lanes":        {hashing.NewArgon2idHasher(testArgon2idParams), "$argon2id$v=19$m=64,t=1,p=255$c2FsdA$aGFzaA"},
+		"scrypt zero block size":     {hashing.NewScryptHasher(testScryptParams), "$scrypt$ln=4,r=0,p=1$c2FsdA$aGFzaA"},
+		"scrypt zero parallelism":    {hashing.NewScryptHasher(testScryptParams), "$scrypt$ln=4,r=8,p=0$c2FsdA$aGFzaA"},
+		"scrypt huge cost":           {hashing.NewScryptHasher(testScryptParams), "$scrypt$ln=40,r=8,p=1$c2FsdA$aGFzaA"},
+		"scrypt huge memory":         {hashing.NewScryptHasher(testScryptParams), "$scrypt$ln=20,r=8,p=1$c2FsdA$aGFzaA"},
+		"scrypt huge block size":     {hashing.NewScryptHasher(testScryptParams), "$scrypt$ln=4,r=2147483647,p=1$c2FsdA$aGFzaA"},
+		"scrypt huge parallelism":    {hashing.NewScryptHasher(testScryptParams), "$scrypt$ln=4,r=8,p=2147483647$c2FsdA$aGFzaA"},
+		"bcrypt huge cost":           {hashing.NewBcryptHasher(bcrypt.MinCost), "$2a$31$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"},
+		"django too many iterations": {hashing.NewDjangoPBKDF2Hasher(1000), "pbkdf2_sha256$2000000000$salt$aGFzaA=="},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, tc.hasher.Validate(tc.encoded), hashing.ErrMalformedHash)
+			assert.ErrorIs(t, tc.hasher.Verify(tc.encoded, "correct horse"), hashing.ErrMalformedHash)
+			assert.True(t, tc.hasher.NeedsRehash(tc.encoded))
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package hashing
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// phcHash is a hash in PHC string format: $id[$v=version][$param=value(,param=value)*][$salt[$hash]].
+// Salt and hash are encoded as unpadded standard base64.
+type phcHash struct {
+	ID      string
+	Version int
+	Params  []phcParam
+	Salt    []byte
+	Hash    []byte
+}
+
+type phcParam struct {
+	Name  string
+	Value string
+}
+
+func parsePHC(encoded string) (*phcHash, error) {
+	fields := strings.Split(encoded, "$")
+	if len(fields) < 2 || fields[0] != "" || fields[1] == "" {
+		return nil, ErrMalformedHash
+	}
+
+	phc := &phcHash{ID: fields[1]}
+	fields = fields[2:]
+
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "v=") {
+		version, err := strconv.Atoi(strings.TrimPrefix(fields[0], "v="))
+		if err != nil {
+			return nil, ErrMalformedHash
+		}
+		phc.Version = version
+		fields = fields[1:]
+	}
+
+	if len(fields) > 0 && strings.Contains(fields[0], "=") {
+		for _, pair := range strings.Split(fields[0], ",") {
+			name, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, ErrMalformedHash
+			}
+			phc.Params = append(phc.Params, phcParam{Name: name, Value: value})
+		}
+		fields = fields[1:]
+	}
+
+	var err error
+	if len(fields) > 0 {
+		if phc.Salt, err = base64.RawStdEncoding.DecodeString(fields[0]); err != nil {
+			return nil, ErrMalformedHash
+		}
+	}
+	if len(fields) > 1 {
+		if phc.Hash, err = base64.RawStdEncoding.DecodeString(fields[1]); err != nil {
+			return nil, ErrMalformedHash
+		}
+	}
+	if len(fields) > 2 {
+		return nil, ErrMalformedHash
+	}
+
+	return phc, nil
+}
+
+func (phc *phcHash) String() string {
+	var b strings.Builder
+	b.WriteString("$" + phc.ID)
+	if phc.Version != 0 {
+		fmt.Fprintf(&b, "$v=%d", phc.Version)
+	}
+	for i, param := range phc.Params {
+		if i == 0 {
+			b.WriteString("$")
+		} else {
+			b.WriteString(",")
+		}
+		b.WriteString(param.Name + "=" + param.Value)
+	}
+	b.WriteString("$" + base64.RawStdEncoding.EncodeToString(phc.Salt))
+	b.WriteString("$" + base64.RawStdEncoding.EncodeToString(phc.Hash))
+	return b.String()
+}
+
+// uintParam returns a numeric parameter, failing when it is missing or not a number.
+func (phc *phcHash) uintParam(name string, bits int) (uint64, error) {
+	for _, param := range phc.Params {
+		if param.Name == name {
+			value, err := strconv.ParseUint(param.Value, 10, bits)
+			if err != nil {
+				return 0, ErrMalformedHash
+			}
+			return value, nil
+		}
+	}
+	return 0, ErrMalformedHash
+}
+
+// phcID returns the algorithm id of a PHC string, or "" when encoded is not one.
+func phcID(encoded string) string {
+	if !strings.HasPrefix(encoded, "$") {
+		return ""
+	}
+	id, _, _ := strings.Cut(encoded[1:], "$")
+	return id
+}
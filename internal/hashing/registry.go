@@ -0,0 +1,120 @@
+package hashing
+
+import (
+	common_crypto "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/crypto"
+)
+
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmScrypt   = "scrypt"
+)
+
+// DefaultDjangoIterations matches the PBKDF2 work factor of recent Django releases.
+const DefaultDjangoIterations = 600000
+
+// Config selects the algorithm for new hashes and the parameters of each supported algorithm.
+type Config struct {
+	Algorithm  string
+	Argon2id   Argon2idParams
+	BcryptCost int
+	Scrypt     ScryptParams
+}
+
+// DefaultConfig hashes new passwords with argon2id.
+func DefaultConfig() Config {
+	return Config{
+		Algorithm:  AlgorithmArgon2id,
+		Argon2id:   DefaultArgon2idParams,
+		BcryptCost: DefaultBcryptCost,
+		Scrypt:     DefaultScryptParams,
+	}
+}
+
+// Registry hashes new passwords with its preferred hasher and verifies hashes from any registered one.
+// It implements common_crypto.ICrypto, so it can stand in for the shared crypto service.
+type Registry struct {
+	Preferred IHasher
+	Hashers   []IHasher
+}
+
+// NewRegistry creates a new instance of Registry. The preferred hasher is registered as well.
+func NewRegistry(preferred IHasher, others ...IHasher) *Registry {
+	return &Registry{
+		Preferred: preferred,
+		Hashers:   append([]IHasher{preferred}, others...),
+	}
+}
+
+// NewRegistryFromConfig registers every supported algorithm, including the legacy import formats,
+// and prefers the configured one. The configuration is validated first.
+func NewRegistryFromConfig(config Config) (*Registry, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	hashers := map[string]IHasher{
+		AlgorithmArgon2id: NewArgon2idHasher(config.Argon2id),
+		AlgorithmBcrypt:   NewBcryptHasher(config.BcryptCost),
+		AlgorithmScrypt:   NewScryptHasher(config.Scrypt),
+	}
+
+	preferred := hashers[config.Algorithm]
+
+	others := []IHasher{NewDjangoPBKDF2Hasher(DefaultDjangoIterations)}
+	for _, algorithm := range []string{AlgorithmArgon2id, AlgorithmBcrypt, AlgorithmScrypt} {
+		if algorithm != config.Algorithm {
+			others = append(others, hashers[algorithm])
+		}
+	}
+
+	return NewRegistry(preferred, others...), nil
+}
+
+// GenerateFromPassword implements common_crypto.ICrypto.
+func (r *Registry) GenerateFromPassword(password string) (string, error) {
+	return r.Preferred.Hash(password)
+}
+
+// CompareHashAndPassword implements common_crypto.ICrypto.
+func (r *Registry) CompareHashAndPassword(hashedPassword string, password string) error {
+	hasher := r.identify(hashedPassword)
+	if hasher == nil {
+		return ErrUnknownFormat
+	}
+	return hasher.Verify(hashedPassword, password)
+}
+
+// NeedsRehash reports whether a hash should be replaced after a successful verification, either because
+// it was made by a hasher other than the preferred one or with outdated parameters.
+func (r *Registry) NeedsRehash(hashedPassword string) bool {
+	hasher := r.identify(hashedPassword)
+	return hasher != r.Preferred || hasher.NeedsRehash(hashedPassword)
+}
+
+// Recognizes reports whether a registered hasher can verify the hash.
+func (r *Registry) Recognizes(hashedPassword string) bool {
+	return r.identify(hashedPassword) != nil
+}
+
+// Validate fully decodes a hash, failing with ErrUnknownFormat when no registered hasher recognises it and
+// with ErrMalformedHash when its hasher could not verify it.
+func (r *Registry) Validate(hashedPassword string) error {
+	hasher := r.identify(hashedPassword)
+	if hasher == nil {
+		return ErrUnknownFormat
+	}
+	return hasher.Validate(hashedPassword)
+}
+
+func (r *Registry) identify(hashedPassword string) IHasher {
+	for _, hasher := range r.Hashers {
+		if hasher.Identifies(hashedPassword) {
+			return hasher
+		}
+	}
+	return nil
+}
+
+// Ensure Registry implements ICrypto
+var _ common_crypto.ICrypto = (*Registry)(nil)
@@ -0,0 +1,85 @@
+package hashing_test
+
+import (
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/hashing"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestRegistry() *hashing.Registry {
+	return hashing.NewRegistry(
+		hashing.NewArgon2idHasher(testArgon2idParams),
+		hashing.NewBcryptHasher(bcrypt.MinCost),
+		hashing.NewScryptHasher(testScryptParams),
+		hashing.NewDjangoPBKDF2Hasher(1000),
+	)
+}
+
+func TestRegistry_HashesWithPreferred(t *testing.T) {
+	// Arrange
+	registry := newTestRegistry()
+
+	// Act
+	encoded, err := registry.GenerateFromPassword("correct horse")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, registry.Preferred.Identifies(encoded))
+	assert.NoError(t, registry.CompareHashAndPassword(encoded, "correct horse"))
+	assert.False(t, registry.NeedsRehash(encoded))
+}
+
+func TestRegistry_VerifiesOtherAlgorithmsAndRequestsRehash(t *testing.T) {
+	// Arrange
+	registry := newTestRegistry()
+	encoded, _ := hashing.NewBcryptHasher(bcrypt.MinCost).Hash("correct horse")
+
+	// Act & Assert
+	assert.True(t, registry.Recognizes(encoded))
+	assert.NoError(t, registry.Validate(encoded))
+	assert.NoError(t, registry.CompareHashAndPassword(encoded, "correct horse"))
+	assert.ErrorIs(t, registry.CompareHashAndPassword(encoded, "wrong horse"), hashing.ErrMismatch)
+	assert.True(t, registry.NeedsRehash(encoded))
+}
+
+func TestRegistry_UnknownFormat(t *testing.T) {
+	// Arrange
+	registry := newTestRegistry()
+
+	// Act & Assert
+	assert.False(t, registry.Recognizes("5f4dcc3b5aa765d61d8327deb882cf99"))
+	assert.ErrorIs(t, registry.Validate("5f4dcc3b5aa765d61d8327deb882cf99"), hashing.ErrUnknownFormat)
+	assert.ErrorIs(t, registry.CompareHashAndPassword("5f4dcc3b5aa765d61d8327deb882cf99", "password"), hashing.ErrUnknownFormat)
+	assert.True(t, registry.NeedsRehash("5f4dcc3b5aa765d61d8327deb882cf99"))
+}
+
+func TestNewRegistryFromConfig(t *testing.T) {
+	// Arrange
+	config := hashing.DefaultConfig()
+	config.Algorithm = hashing.AlgorithmScrypt
+
+	// Act
+	registry, err := hashing.NewRegistryFromConfig(config)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.IsType(t, &hashing.ScryptHasher{}, registry.Preferred)
+	assert.Len(t, registry.Hashers, 4)
+	assert.True(t, registry.Recognizes("pbkdf2_sha256$1000$seasalt$mQnueSakb748zqBAC1tmWVZsZbi2zPGZarEzTGdfmso="))
+	assert.True(t, registry.Recognizes("$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"))
+}
+
+func TestNewRegistryFromConfig_UnsupportedAlgorithm(t *testing.T) {
+	// Arrange
+	config := hashing.DefaultConfig()
+	config.Algorithm = "md5"
+
+	// Act
+	registry, err := hashing.NewRegistryFromConfig(config)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, registry)
+}
@@ -0,0 +1,134 @@
+package hashing
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"strconv"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+type ScryptParams struct {
+	// LogN is the base-2 logarithm of the CPU/memory cost N.
+	LogN       uint8
+	R          int
+	P          int
+	SaltLength int
+	KeyLength  int
+}
+
+// Bounds of the parameters accepted in scrypt hashes. Hashes may be imported from other systems, and
+// larger parameters would let a single verification exhaust the memory or CPU of the service.
+const (
+	MaxScryptLogN = 20
+	MaxScryptR    = 32
+	MaxScryptP    = 16
+	// MaxScryptMemory is in bytes, and bounds the 128·r·N bytes a verification allocates.
+	MaxScryptMemory = 256 * 1024 * 1024
+)
+
+var DefaultScryptParams = ScryptParams{
+	LogN:       15,
+	R:          8,
+	P:          1,
+	SaltLength: 16,
+	KeyLength:  32,
+}
+
+// ScryptHasher is an implementation of IHasher producing $scrypt$ PHC strings.
+type ScryptHasher struct {
+	Params ScryptParams
+}
+
+// NewScryptHasher creates a new instance of ScryptHasher.
+func NewScryptHasher(params ScryptParams) *ScryptHasher {
+	return &ScryptHasher{
+		Params: params,
+	}
+}
+
+// Identifies implements IHasher.
+func (h *ScryptHasher) Identifies(encoded string) bool {
+	return phcID(encoded) == "scrypt"
+}
+
+// Hash implements IHasher.
+func (h *ScryptHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.Params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, 1<<h.Params.LogN, h.Params.R, h.Params.P, h.Params.KeyLength)
+	if err != nil {
+		return "", err
+	}
+
+	phc := &phcHash{
+		ID: "scrypt",
+		Params: []phcParam{
+			{Name: "ln", Value: strconv.Itoa(int(h.Params.LogN))},
+			{Name: "r", Value: strconv.Itoa(h.Params.R)},
+			{Name: "p", Value: strconv.Itoa(h.Params.P)},
+		},
+		Salt: salt,
+		Hash: key,
+	}
+	return phc.String(), nil
+}
+
+// Verify implements IHasher.
+func (h *ScryptHasher) Verify(encoded string, password string) error {
+	phc, params, err := h.decode(encoded)
+	if err != nil {
+		return err
+	}
+
+	key, err := scrypt.Key([]byte(password), phc.Salt, 1<<params.LogN, params.R, params.P, len(phc.Hash))
+	if err != nil {
+		return ErrMalformedHash
+	}
+	if subtle.ConstantTimeCompare(key, phc.Hash) != 1 {
+		return ErrMismatch
+	}
+	return nil
+}
+
+// NeedsRehash implements IHasher.
+func (h *ScryptHasher) NeedsRehash(encoded string) bool {
+	phc, params, err := h.decode(encoded)
+	if err != nil {
+		return true
+	}
+	return params.LogN != h.Params.LogN || params.R != h.Params.R || params.P != h.Params.P || len(phc.Hash) != h.Params.KeyLength
+}
+
+// Validate implements IHasher.
+func (h *ScryptHasher) Validate(encoded string) error {
+	_, _, err := h.decode(encoded)
+	return err
+}
+
+func (h *ScryptHasher) decode(encoded string) (*phcHash, ScryptParams, error) {
+	phc, err := parsePHC(encoded)
+	if err != nil || phc.ID != "scrypt" || len(phc.Salt) == 0 || len(phc.Hash) == 0 {
+		return nil, ScryptParams{}, ErrMalformedHash
+	}
+
+	logN, err := phc.uintParam("ln", 6)
+	if err != nil || logN < 1 || logN > MaxScryptLogN {
+		return nil, ScryptParams{}, ErrMalformedHash
+	}
+	r, err := phc.uintParam("r", 31)
+	if err != nil || r < 1 || r > MaxScryptR || 128*r<<logN > MaxScryptMemory {
+		return nil, ScryptParams{}, ErrMalformedHash
+	}
+	p, err := phc.uintParam("p", 31)
+	if err != nil || p < 1 || p > MaxScryptP {
+		return nil, ScryptParams{}, ErrMalformedHash
+	}
+
+	return phc, ScryptParams{LogN: uint8(logN), R: int(r), P: int(p)}, nil
+}
+
+var _ IHasher = (*ScryptHasher)(nil)
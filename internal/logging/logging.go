@@ -0,0 +1,114 @@
+// Package logging builds the structured loggers of the service. Records carry the request and trace
+// id of their context and never contain passwords, password hashes or email addresses.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys set on every record logged with a correlated context.
+const (
+	RequestIDKey = "request_id"
+	TraceIDKey   = "trace_id"
+)
+
+// TraceparentHeader is the W3C Trace Context header, also used as the gRPC metadata key.
+const TraceparentHeader = "traceparent"
+
+// NewLogger returns a logger writing JSON records at level or above to w.
+func NewLogger(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(NewRedactingHandler(NewContextHandler(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))))
+}
+
+// Correlation identifies the request a record was logged for.
+type Correlation struct {
+	RequestID string
+	TraceID   string
+}
+
+type correlationKey struct{}
+
+// WithCorrelation returns a context whose records carry correlation.
+func WithCorrelation(ctx context.Context, correlation Correlation) context.Context {
+	return context.WithValue(ctx, correlationKey{}, correlation)
+}
+
+// CorrelationFromContext returns the correlation of a context, which is empty outside of a request.
+func CorrelationFromContext(ctx context.Context) Correlation {
+	correlation, _ := ctx.Value(correlationKey{}).(Correlation)
+	return correlation
+}
+
+// NewRequestID returns a random request id for requests that arrive without one.
+func NewRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(id)
+}
+
+// TraceID returns the trace id of the span of a context, or else the one of a W3C traceparent value.
+func TraceID(ctx context.Context, traceparent string) string {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		return spanContext.TraceID().String()
+	}
+	return TraceIDFromTraceparent(traceparent)
+}
+
+// TraceIDFromTraceparent returns the trace id of a W3C traceparent value, or "" when it is malformed.
+func TraceIDFromTraceparent(traceparent string) string {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return ""
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil || parts[1] == strings.Repeat("0", 32) {
+		return ""
+	}
+	return strings.ToLower(parts[1])
+}
+
+// ContextHandler adds the correlation of the context to each record.
+type ContextHandler struct {
+	handler slog.Handler
+}
+
+var _ slog.Handler = (*ContextHandler)(nil)
+
+func NewContextHandler(handler slog.Handler) *ContextHandler {
+	return &ContextHandler{
+		handler: handler,
+	}
+}
+
+func (h *ContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		correlation := CorrelationFromContext(ctx)
+		if correlation.RequestID != "" {
+			record.AddAttrs(slog.String(RequestIDKey, correlation.RequestID))
+		}
+		if correlation.TraceID != "" {
+			record.AddAttrs(slog.String(TraceIDKey, correlation.TraceID))
+		}
+	}
+	return h.handler.Handle(ctx, record)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewContextHandler(h.handler.WithAttrs(attrs))
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return NewContextHandler(h.handler.WithGroup(name))
+}
@@ -0,0 +1,124 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decode returns the single record written to logs.
+func decode(t *testing.T, logs *bytes.Buffer) map[string]interface{} {
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &record))
+	return record
+}
+
+func TestNewLogger_Correlation(t *testing.T) {
+	// Setup
+	var logs bytes.Buffer
+	logger := logging.NewLogger(&logs, slog.LevelInfo)
+	ctx := logging.WithCorrelation(context.Background(), logging.Correlation{RequestID: "request-1", TraceID: "trace-1"})
+
+	// Test
+	logger.InfoContext(ctx, "Created user", "user_id", "1")
+
+	// Assert
+	record := decode(t, &logs)
+	assert.Equal(t, "Created user", record["msg"])
+	assert.Equal(t, "1", record["user_id"])
+	assert.Equal(t, "request-1", record[logging.RequestIDKey])
+	assert.Equal(t, "trace-1", record[logging.TraceIDKey])
+}
+
+func TestNewLogger_WithoutCorrelation(t *testing.T) {
+	// Setup
+	var logs bytes.Buffer
+	logger := logging.NewLogger(&logs, slog.LevelInfo)
+
+	// Test
+	logger.Info("Starting")
+	logger.Debug("Below the level")
+
+	// Assert
+	record := decode(t, &logs)
+	assert.NotContains(t, record, logging.RequestIDKey)
+	assert.NotContains(t, record, logging.TraceIDKey)
+}
+
+func TestNewLogger_Redacts(t *testing.T) {
+	// Setup
+	var logs bytes.Buffer
+	logger := logging.NewLogger(&logs, slog.LevelInfo).With("email", "test@mail.com")
+
+	// Test
+	logger.Info("Failed to create user test@mail.com",
+		"password", "secret",
+		"Hash", "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$aGFzaA",
+		"new_password", "secret",
+		"error", errors.New("duplicate key { email: \"test@mail.com\" }"),
+		"reason", "hash $2a$10$abcdefghijklmnopqrstuuabcdefghijklmnopqrstuvwxyz01234 rejected",
+		slog.Group("user", "id", "1", "email", "test@mail.com"),
+	)
+
+	// Assert
+	assert.NotContains(t, logs.String(), "test@mail.com")
+	assert.NotContains(t, logs.String(), "secret")
+	assert.NotContains(t, logs.String(), "argon2id")
+	assert.NotContains(t, logs.String(), "$2a$")
+	record := decode(t, &logs)
+	assert.Equal(t, "Failed to create user [REDACTED]", record["msg"])
+	assert.Equal(t, logging.Redacted, record["email"])
+	assert.Equal(t, logging.Redacted, record["Hash"])
+	assert.Equal(t, `duplicate key { email: "[REDACTED]" }`, record["error"])
+	assert.Equal(t, "hash [REDACTED] rejected", record["reason"])
+	assert.Equal(t, map[string]interface{}{"id": "1", "email": logging.Redacted}, record["user"])
+}
+
+func TestRedactString_PasswordHashes(t *testing.T) {
+	cases := map[string]string{
+		"argon2id":      "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$aGFzaA",
+		"scrypt":        "$scrypt$ln=15,r=8,p=1$c2FsdA$aGFzaA",
+		"pbkdf2_sha256": "pbkdf2_sha256$600000$salt$aGFzaA==",
+		"bcrypt":        "$2b$12$abcdefghijklmnopqrstuuabcdefghijklmnopqrstuvwxyz01234",
+	}
+
+	for format, hash := range cases {
+		// Test
+		redacted := logging.RedactString("cannot verify " + hash + " against the password")
+
+		// Assert
+		assert.Equal(t, "cannot verify [REDACTED] against the password", redacted, format)
+	}
+}
+
+func TestTraceIDFromTraceparent(t *testing.T) {
+	cases := map[string]string{
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01": "",
+		"00-4bf92f3577b34da6a3ce929d0e0e47zz-00f067aa0ba902b7-01": "",
+		"00-4bf92f3577b34da6-00f067aa0ba902b7-01":                 "",
+		"": "",
+	}
+
+	for traceparent, expected := range cases {
+		// Test & Assert
+		assert.Equal(t, expected, logging.TraceIDFromTraceparent(traceparent), traceparent)
+	}
+}
+
+func TestNewRequestID(t *testing.T) {
+	// Test
+	first := logging.NewRequestID()
+	second := logging.NewRequestID()
+
+	// Assert
+	assert.Len(t, first, 32)
+	assert.NotEqual(t, first, second)
+}
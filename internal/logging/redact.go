@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces sensitive values in records.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are the attribute key fragments whose values are always redacted.
+var sensitiveKeys = []string{"password", "hash", "email"}
+
+// sensitiveValues match values that are sensitive wherever they appear, such as in error messages:
+// email addresses and password hashes in every format of the hashing package: argon2id, scrypt,
+// Django PBKDF2 and bcrypt.
+var sensitiveValues = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}|\$argon2id\$\S+|\$scrypt\$\S+|pbkdf2_sha256\$\S+|\$2[abxy]?\$\d{2}\$[./A-Za-z0-9]{53}`)
+
+// RedactingHandler keeps passwords, password hashes and email addresses out of records. Values are
+// redacted by the key of their attribute, and wherever they appear in messages, strings and errors.
+// Other values are passed as is, so log fields of a user rather than the user itself.
+type RedactingHandler struct {
+	handler slog.Handler
+}
+
+var _ slog.Handler = (*RedactingHandler)(nil)
+
+func NewRedactingHandler(handler slog.Handler) *RedactingHandler {
+	return &RedactingHandler{
+		handler: handler,
+	}
+}
+
+func (h *RedactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *RedactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, RedactString(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(attr))
+		return true
+	})
+	return h.handler.Handle(ctx, redacted)
+}
+
+func (h *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = redactAttr(attr)
+	}
+	return NewRedactingHandler(h.handler.WithAttrs(redacted))
+}
+
+func (h *RedactingHandler) WithGroup(name string) slog.Handler {
+	return NewRedactingHandler(h.handler.WithGroup(name))
+}
+
+// RedactString replaces the email addresses and password hashes in s.
+func RedactString(s string) string {
+	return sensitiveValues.ReplaceAllString(s, Redacted)
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range sensitiveKeys {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactAttr(attr slog.Attr) slog.Attr {
+	attr.Value = attr.Value.Resolve()
+	if isSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, Redacted)
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, RedactString(attr.Value.String()))
+	case slog.KindGroup:
+		group := attr.Value.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, member := range group {
+			redacted[i] = redactAttr(member)
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(redacted...)}
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok {
+			return slog.String(attr.Key, RedactString(err.Error()))
+		}
+	}
+	return attr
+}
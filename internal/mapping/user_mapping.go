@@ -0,0 +1,143 @@
+// Package mapping converts the views of a user in public/model to the messages of both gRPC API
+// versions. The HTTP API serves the same views as JSON, so every transport derives its response from
+// one projection of model.PrivateUserModel and returns the same fields.
+package mapping
+
+import (
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb"
+	pbv2 "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb/v2"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FormatTime formats a timestamp of the v1 API as RFC 3339 in UTC, the format of the HTTP API.
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// formatOptionalTime formats an optional timestamp of the v1 API, which is empty when there is none.
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return FormatTime(*t)
+}
+
+// optionalTimestamp converts an optional timestamp of the v2 API, which is unset when there is none.
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func ToPublicUserResponse(user *publicModel.PublicUserModel) *pb.PublicUserResponse {
+	return &pb.PublicUserResponse{
+		Id:        user.ID.Hex(),
+		Username:  user.Username,
+		Profile:   user.Profile.ToUserProfile(),
+		CreatedAt: FormatTime(user.CreatedAt),
+		UpdatedAt: FormatTime(user.UpdatedAt),
+	}
+}
+
+func ToPublicUserV2(user *publicModel.PublicUserModel) *pbv2.PublicUser {
+	return &pbv2.PublicUser{
+		Id:        user.ID.Hex(),
+		Username:  user.Username,
+		Profile:   ToUserProfileV2(user.Profile),
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
+
+func ToMeResponse(user *publicModel.SelfUserModel) *pb.MeResponse {
+	return &pb.MeResponse{
+		Id:         user.ID.Hex(),
+		Username:   user.Username,
+		Email:      user.Email,
+		Profile:    user.Profile.ToUserProfile(),
+		MfaEnabled: user.MFAEnabled,
+		CreatedAt:  FormatTime(user.CreatedAt),
+		UpdatedAt:  FormatTime(user.UpdatedAt),
+	}
+}
+
+func ToMeV2(user *publicModel.SelfUserModel) *pbv2.Me {
+	return &pbv2.Me{
+		Id:         user.ID.Hex(),
+		Username:   user.Username,
+		Email:      user.Email,
+		Profile:    ToUserProfileV2(user.Profile),
+		MfaEnabled: user.MFAEnabled,
+		CreatedAt:  timestamppb.New(user.CreatedAt),
+		UpdatedAt:  timestamppb.New(user.UpdatedAt),
+	}
+}
+
+func ToUserResponse(user *publicModel.AccountUserModel) *pb.UserResponse {
+	return &pb.UserResponse{
+		Id:          user.ID.Hex(),
+		Email:       user.Email,
+		Username:    user.Username,
+		Hash:        user.Hash,
+		Profile:     user.Profile.ToUserProfile(),
+		Locked:      user.Locked,
+		LockedUntil: formatOptionalTime(user.LockedUntil),
+		MfaEnabled:  user.MFAEnabled,
+		CreatedAt:   FormatTime(user.CreatedAt),
+		UpdatedAt:   FormatTime(user.UpdatedAt),
+	}
+}
+
+func ToUserV2(user *publicModel.AccountUserModel) *pbv2.User {
+	return &pbv2.User{
+		Id:          user.ID.Hex(),
+		Email:       user.Email,
+		Username:    user.Username,
+		Hash:        user.Hash,
+		Profile:     ToUserProfileV2(user.Profile),
+		Locked:      user.Locked,
+		LockedUntil: optionalTimestamp(user.LockedUntil),
+		MfaEnabled:  user.MFAEnabled,
+		CreatedAt:   timestamppb.New(user.CreatedAt),
+		UpdatedAt:   timestamppb.New(user.UpdatedAt),
+	}
+}
+
+// ToUserProfileV2 converts a profile through its v1 message, which has the same fields.
+func ToUserProfileV2(profile *publicModel.ProfileModel) *pbv2.UserProfile {
+	userProfile := profile.ToUserProfile()
+	if userProfile == nil {
+		return nil
+	}
+
+	return &pbv2.UserProfile{
+		DisplayName: userProfile.DisplayName,
+		AvatarUrl:   userProfile.AvatarUrl,
+		Bio:         userProfile.Bio,
+		Locale:      userProfile.Locale,
+		Timezone:    userProfile.Timezone,
+		Metadata:    userProfile.Metadata,
+		Visibility:  userProfile.Visibility,
+	}
+}
+
+// NewProfileModelV2 converts a v2 profile through its v1 message, which has the same fields.
+func NewProfileModelV2(userProfile *pbv2.UserProfile) *publicModel.ProfileModel {
+	if userProfile == nil {
+		return nil
+	}
+
+	return publicModel.NewProfileModel(&pb.UserProfile{
+		DisplayName: userProfile.DisplayName,
+		AvatarUrl:   userProfile.AvatarUrl,
+		Bio:         userProfile.Bio,
+		Locale:      userProfile.Locale,
+		Timezone:    userProfile.Timezone,
+		Metadata:    userProfile.Metadata,
+		Visibility:  userProfile.Visibility,
+	})
+}
@@ -0,0 +1,189 @@
+package mapping_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/mapping"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// newMappedUser returns a user with every field set, so that no transport leaves out a zero value.
+func newMappedUser() *model.PrivateUserModel {
+	createdAt := time.Date(2023, 11, 1, 12, 0, 0, 500000000, time.UTC)
+	lockedUntil := createdAt.Add(time.Hour)
+	return &model.PrivateUserModel{
+		ID:       primitive.NewObjectID(),
+		TenantID: "acme",
+		Email:    "test@mail.com",
+		Username: "test",
+		Hash:     "$argon2id$hash",
+		Roles:    []string{"admin"},
+		Profile: &publicModel.ProfileModel{
+			DisplayName: "Test User",
+			AvatarURL:   "https://example.com/avatar.png",
+			Bio:         "Bio",
+			Locale:      "en-GB",
+			Timezone:    "Europe/London",
+			Metadata:    map[string]string{"team": "core"},
+			Visibility: map[string]publicModel.Visibility{
+				publicModel.ProfileFieldLocale:   publicModel.VisibilityPublic,
+				publicModel.ProfileFieldTimezone: publicModel.VisibilityPublic,
+				publicModel.ProfileFieldMetadata: publicModel.VisibilityPublic,
+			},
+		},
+		LockedUntil: &lockedUntil,
+		MFAEnabled:  true,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt.Add(time.Minute),
+	}
+}
+
+// decodeHTTP returns the JSON body the HTTP API serves for a view.
+func decodeHTTP(t *testing.T, view interface{}) map[string]interface{} {
+	body, err := json.Marshal(view)
+	require.NoError(t, err)
+	return decode(t, body)
+}
+
+// decodeV1 returns a v1 message as JSON, with its camel case field names in snake case.
+func decodeV1(t *testing.T, message proto.Message) map[string]interface{} {
+	body, err := protojson.Marshal(message)
+	require.NoError(t, err)
+	return snakeKeys(decode(t, body))
+}
+
+// decodeV2 returns a v2 message as JSON with its proto field names.
+func decodeV2(t *testing.T, message proto.Message) map[string]interface{} {
+	body, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	require.NoError(t, err)
+	return decode(t, body)
+}
+
+// decode unmarshals a JSON object, replacing timestamps with their instant so that their formats compare equal.
+func decode(t *testing.T, body []byte) map[string]interface{} {
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &fields))
+	return normalizeTimes(fields).(map[string]interface{})
+}
+
+func normalizeTimes(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			value[key] = normalizeTimes(field)
+		}
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t.UTC()
+		}
+	}
+	return value
+}
+
+// snakeKeys converts the field names of messages. Keys of the profile maps are user data and are kept.
+func snakeKeys(fields map[string]interface{}) map[string]interface{} {
+	converted := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if nested, ok := value.(map[string]interface{}); ok && key != "metadata" && key != "visibility" {
+			value = snakeKeys(nested)
+		}
+		converted[snakeCase(key)] = value
+	}
+	return converted
+}
+
+func snakeCase(name string) string {
+	var builder strings.Builder
+	for _, r := range name {
+		if unicode.IsUpper(r) {
+			builder.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+func TestPublicUser_SameFieldsOnEveryTransport(t *testing.T) {
+	// Setup
+	user := newMappedUser()
+	view := user.ToPublicUserModelFor(publicModel.VisibilityPublic)
+
+	// Test
+	httpFields := decodeHTTP(t, view)
+	v1Fields := decodeV1(t, mapping.ToPublicUserResponse(view))
+	v2Fields := decodeV2(t, mapping.ToPublicUserV2(view))
+
+	// Assert
+	assert.Equal(t, httpFields, v1Fields)
+	assert.Equal(t, httpFields, v2Fields)
+	assert.Contains(t, httpFields, "updated_at")
+	assert.NotContains(t, httpFields, "email")
+}
+
+func TestSelfUser_SameFieldsOnEveryTransport(t *testing.T) {
+	// Setup
+	view := newMappedUser().ToSelfUserModel()
+
+	// Test
+	httpFields := decodeHTTP(t, view)
+	v1Fields := decodeV1(t, mapping.ToMeResponse(view))
+	v2Fields := decodeV2(t, mapping.ToMeV2(view))
+
+	// Assert
+	assert.Equal(t, httpFields, v1Fields)
+	assert.Equal(t, httpFields, v2Fields)
+	assert.NotContains(t, httpFields, "hash")
+}
+
+func TestAccountUser_SameFieldsOnEveryTransport(t *testing.T) {
+	// Setup
+	user := newMappedUser()
+	view := user.ToAccountUserModel(user.CreatedAt)
+
+	// Test
+	httpFields := decodeHTTP(t, view)
+	v1Fields := decodeV1(t, mapping.ToUserResponse(view))
+	v2Fields := decodeV2(t, mapping.ToUserV2(view))
+
+	// Assert
+	assert.Equal(t, httpFields, v1Fields)
+	assert.Equal(t, httpFields, v2Fields)
+	assert.Equal(t, true, httpFields["locked"])
+	assert.NotContains(t, httpFields, "tenant_id")
+	assert.NotContains(t, httpFields, "roles")
+}
+
+func TestFormatTime(t *testing.T) {
+	// Setup
+	local := time.Date(2023, 11, 1, 13, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	// Test
+	formatted := mapping.FormatTime(local)
+
+	// Assert
+	assert.Equal(t, "2023-11-01T12:00:00Z", formatted)
+}
+
+func TestProfileV2_RoundTrip(t *testing.T) {
+	// Setup
+	profile := newMappedUser().Profile
+
+	// Test
+	roundTripped := mapping.NewProfileModelV2(mapping.ToUserProfileV2(profile))
+
+	// Assert
+	assert.Equal(t, profile, roundTripped)
+	assert.Nil(t, mapping.ToUserProfileV2(nil))
+	assert.Nil(t, mapping.NewProfileModelV2(nil))
+}
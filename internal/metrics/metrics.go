@@ -0,0 +1,40 @@
+// Package metrics defines the Prometheus metrics of the service, registered with the default registry.
+// Labels only take values from fixed sets, such as route templates and method names, to keep cardinality bounded.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes the name of every metric of the service.
+const Namespace = "user_service"
+
+// Transport label values.
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+// Panics counts panics recovered in request handlers, by transport.
+var Panics = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Name:      "panics_total",
+	Help:      "Panics recovered in request handlers.",
+}, []string{"transport"})
+
+// Metrics of the default registry, which Handler exposes.
+var (
+	HTTP  = NewRED(prometheus.DefaultRegisterer, TransportHTTP, "route", "status")
+	GRPC  = NewRED(prometheus.DefaultRegisterer, TransportGRPC, "method", "code")
+	Mongo = NewMongoMetrics(prometheus.DefaultRegisterer)
+	Users = NewUserCounters(prometheus.DefaultRegisterer)
+)
+
+// Handler serves the metrics of the default registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
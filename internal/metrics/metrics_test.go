@@ -0,0 +1,87 @@
+package metrics_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// sampleCount returns the number of observations of the histogram series with labels.
+func sampleCount(t *testing.T, registry *prometheus.Registry, name string, labels map[string]string) uint64 {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			matched := map[string]string{}
+			for _, pair := range metric.GetLabel() {
+				matched[pair.GetName()] = pair.GetValue()
+			}
+			if assert.ObjectsAreEqual(labels, matched) {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func TestRED_Observe(t *testing.T) {
+	// Setup
+	registry := prometheus.NewRegistry()
+	red := metrics.NewRED(registry, metrics.TransportGRPC, "method", "code")
+
+	// Test
+	red.Observe("/UserService/CreateUser", "OK", time.Now())
+	red.Observe("/UserService/CreateUser", "AlreadyExists", time.Now())
+
+	// Assert
+	assert.Equal(t, float64(1), testutil.ToFloat64(red.Requests.WithLabelValues("/UserService/CreateUser", "AlreadyExists")))
+	assert.Equal(t, 1, testutil.CollectAndCount(red.Duration, "user_service_grpc_request_duration_seconds"))
+	assert.Equal(t, 2, testutil.CollectAndCount(red.Requests, "user_service_grpc_requests_total"))
+}
+
+func TestMongoMetrics_CommandMonitor(t *testing.T) {
+	// Setup
+	registry := prometheus.NewRegistry()
+	mongoMetrics := metrics.NewMongoMetrics(registry)
+	monitor := mongoMetrics.CommandMonitor()
+
+	// Test
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "find", Duration: time.Millisecond}})
+	monitor.Failed(context.Background(), &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "insert", Duration: time.Millisecond}})
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "hello", Duration: time.Millisecond}})
+
+	// Assert
+	assert.Equal(t, 3, testutil.CollectAndCount(mongoMetrics.CommandDuration))
+	name := "user_service_mongo_command_duration_seconds"
+	assert.Equal(t, uint64(1), sampleCount(t, registry, name, map[string]string{"command": "find", "outcome": "succeeded"}))
+	assert.Equal(t, uint64(1), sampleCount(t, registry, name, map[string]string{"command": "insert", "outcome": "failed"}))
+	assert.Equal(t, uint64(1), sampleCount(t, registry, name, map[string]string{"command": metrics.OtherCommand, "outcome": "succeeded"}))
+}
+
+func TestMongoMetrics_PoolMonitor(t *testing.T) {
+	// Setup
+	mongoMetrics := metrics.NewMongoMetrics(prometheus.NewRegistry())
+	monitor := mongoMetrics.PoolMonitor(50)
+
+	// Test
+	for _, eventType := range []string{event.ConnectionCreated, event.ConnectionCreated, event.GetSucceeded, event.GetSucceeded, event.ConnectionReturned, event.ConnectionClosed} {
+		monitor.Event(&event.PoolEvent{Type: eventType})
+	}
+	monitor.Event(&event.PoolEvent{Type: event.GetFailed, Reason: event.ReasonTimedOut})
+
+	// Assert
+	assert.Equal(t, float64(50), testutil.ToFloat64(mongoMetrics.MaxConnections))
+	assert.Equal(t, float64(1), testutil.ToFloat64(mongoMetrics.Connections.WithLabelValues("open")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(mongoMetrics.Connections.WithLabelValues("in_use")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(mongoMetrics.CheckoutFailures.WithLabelValues(event.ReasonTimedOut)))
+}
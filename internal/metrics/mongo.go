@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// OtherCommand labels the commands missing from mongoCommands.
+const OtherCommand = "other"
+
+// mongoCommands are the commands labelled by name; the driver also runs handshakes and
+// server-specific commands that are all labelled OtherCommand.
+var mongoCommands = map[string]struct{}{
+	"find": {}, "insert": {}, "update": {}, "delete": {}, "findAndModify": {}, "aggregate": {},
+	"count": {}, "distinct": {}, "getMore": {}, "killCursors": {}, "createIndexes": {}, "dropIndexes": {},
+	"commitTransaction": {}, "abortTransaction": {}, "ping": {},
+}
+
+// MongoMetrics holds the metrics of the MongoDB client, filled by its monitors.
+type MongoMetrics struct {
+	// CommandDuration is by command and outcome, succeeded or failed
+	CommandDuration *prometheus.HistogramVec
+	// Connections is by state: open connections, and those of them checked out
+	Connections *prometheus.GaugeVec
+	// MaxConnections is the configured size of the pool
+	MaxConnections prometheus.Gauge
+	// CheckoutFailures is by the reason the driver reports
+	CheckoutFailures *prometheus.CounterVec
+}
+
+func NewMongoMetrics(registerer prometheus.Registerer) *MongoMetrics {
+	factory := promauto.With(registerer)
+	return &MongoMetrics{
+		CommandDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "mongo",
+			Name:      "command_duration_seconds",
+			Help:      "Time to run MongoDB commands, by command and outcome.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"command", "outcome"}),
+		Connections: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "mongo",
+			Name:      "pool_connections",
+			Help:      "Connections of the MongoDB pool, by state.",
+		}, []string{"state"}),
+		MaxConnections: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "mongo",
+			Name:      "pool_max_connections",
+			Help:      "Maximum size of the MongoDB pool.",
+		}),
+		CheckoutFailures: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "mongo",
+			Name:      "pool_checkout_failures_total",
+			Help:      "Failures to check a connection out of the MongoDB pool, by reason.",
+		}, []string{"reason"}),
+	}
+}
+
+// CommandMonitor returns a monitor that records the duration of every command.
+func (m *MongoMetrics) CommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, finished *event.CommandSucceededEvent) {
+			m.CommandDuration.WithLabelValues(commandLabel(finished.CommandName), "succeeded").Observe(finished.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, finished *event.CommandFailedEvent) {
+			m.CommandDuration.WithLabelValues(commandLabel(finished.CommandName), "failed").Observe(finished.Duration.Seconds())
+		},
+	}
+}
+
+// PoolMonitor returns a monitor that tracks the connections of a pool of maxPoolSize connections.
+func (m *MongoMetrics) PoolMonitor(maxPoolSize uint64) *event.PoolMonitor {
+	m.MaxConnections.Set(float64(maxPoolSize))
+	open := m.Connections.WithLabelValues("open")
+	inUse := m.Connections.WithLabelValues("in_use")
+	return &event.PoolMonitor{
+		Event: func(poolEvent *event.PoolEvent) {
+			switch poolEvent.Type {
+			case event.ConnectionCreated:
+				open.Inc()
+			case event.ConnectionClosed:
+				open.Dec()
+			case event.GetSucceeded:
+				inUse.Inc()
+			case event.ConnectionReturned:
+				inUse.Dec()
+			case event.GetFailed:
+				m.CheckoutFailures.WithLabelValues(poolEvent.Reason).Inc()
+			}
+		},
+	}
+}
+
+func commandLabel(name string) string {
+	if _, ok := mongoCommands[name]; ok {
+		return name
+	}
+	return OtherCommand
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// RED holds the rate, errors and duration of the requests of a transport. Requests are counted by
+// operation and outcome, so that errors are the requests with a failed outcome; durations are by operation.
+type RED struct {
+	Requests *prometheus.CounterVec
+	Duration *prometheus.HistogramVec
+}
+
+// NewRED registers the RED metrics of a transport, whose operations and outcomes are labelled with
+// operationLabel and outcomeLabel.
+func NewRED(registerer prometheus.Registerer, transport string, operationLabel string, outcomeLabel string) *RED {
+	factory := promauto.With(registerer)
+	return &RED{
+		Requests: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: transport,
+			Name:      "requests_total",
+			Help:      "Requests handled, by operation and outcome.",
+		}, []string{operationLabel, outcomeLabel}),
+		Duration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: transport,
+			Name:      "request_duration_seconds",
+			Help:      "Time to handle requests, by operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{operationLabel}),
+	}
+}
+
+// Observe records a request handled since start.
+func (m *RED) Observe(operation string, outcome string, start time.Time) {
+	m.Requests.WithLabelValues(operation, outcome).Inc()
+	m.Duration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Sources of created users.
+const (
+	SourceCreate = "create"
+	SourceImport = "import"
+)
+
+// Operations that can conflict with an existing user.
+const (
+	OperationCreate = "create"
+	OperationImport = "import"
+	OperationUpdate = "update"
+)
+
+// Identifier types of lookups.
+const (
+	IdentifierID       = "id"
+	IdentifierEmail    = "email"
+	IdentifierUsername = "username"
+)
+
+// UserCounters count the business events of the user service.
+type UserCounters struct {
+	// Created is by source
+	Created *prometheus.CounterVec
+	// Conflicts counts writes refused for a duplicate email or username, by operation
+	Conflicts *prometheus.CounterVec
+	// Lookups is by identifier type, counting each identifier of a batch
+	Lookups *prometheus.CounterVec
+}
+
+func NewUserCounters(registerer prometheus.Registerer) *UserCounters {
+	factory := promauto.With(registerer)
+	return &UserCounters{
+		Created: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "users_created_total",
+			Help:      "Users created, by source.",
+		}, []string{"source"}),
+		Conflicts: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "user_conflicts_total",
+			Help:      "Writes refused because the email or username is taken, by operation.",
+		}, []string{"operation"}),
+		Lookups: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "user_lookups_total",
+			Help:      "Users looked up, by identifier type.",
+		}, []string{"identifier_type"}),
+	}
+}
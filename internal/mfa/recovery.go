@@ -0,0 +1,75 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// RecoveryCodeCount is the number of recovery codes issued at once.
+	RecoveryCodeCount = 10
+	// recoveryCodeGroup is the length of each of the two dash-separated halves of a code.
+	recoveryCodeGroup = 5
+)
+
+// recoveryAlphabet leaves out characters that are easily confused when codes are copied from paper.
+const recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// GenerateRecoveryCodes returns n random codes of the form xxxxx-xxxxx.
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	for i := range codes {
+		raw := make([]byte, 2*recoveryCodeGroup)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+		var code strings.Builder
+		for j, b := range raw {
+			if j == recoveryCodeGroup {
+				code.WriteByte('-')
+			}
+			// The modulo bias over 256 values is small enough not to matter for one-time codes
+			code.WriteByte(recoveryAlphabet[int(b)%len(recoveryAlphabet)])
+		}
+		codes[i] = code.String()
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode returns the form a recovery code is stored in. The codes carry close to
+// 50 bits of randomness and are single use, so a fast hash suffices where passwords need a slow one.
+func HashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// MatchRecoveryCode returns the index of the stored hash the code matches, or -1.
+func MatchRecoveryCode(hashes []string, code string) int {
+	hashed := []byte(HashRecoveryCode(code))
+	match := -1
+	for i, hash := range hashes {
+		if subtle.ConstantTimeCompare([]byte(hash), hashed) == 1 {
+			match = i
+		}
+	}
+	return match
+}
+
+// IsRecoveryCode reports whether a submitted code has the shape of a recovery code rather than a TOTP code.
+func IsRecoveryCode(code string) bool {
+	return len(normalizeRecoveryCode(code)) == 2*recoveryCodeGroup
+}
+
+// normalizeRecoveryCode makes codes typed with other case, spacing or without the dash match.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+}
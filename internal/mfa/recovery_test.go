@@ -0,0 +1,27 @@
+package mfa_test
+
+import (
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/mfa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := mfa.GenerateRecoveryCodes(mfa.RecoveryCodeCount)
+	assert.NoError(t, err)
+	assert.Len(t, codes, mfa.RecoveryCodeCount)
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, code)
+		assert.True(t, mfa.IsRecoveryCode(code))
+		hashes[i] = mfa.HashRecoveryCode(code)
+		assert.NotContains(t, hashes[i], code)
+	}
+
+	assert.Equal(t, 3, mfa.MatchRecoveryCode(hashes, codes[3]))
+	assert.Equal(t, 3, mfa.MatchRecoveryCode(hashes, " "+codes[3][:5]+codes[3][6:]))
+	assert.Equal(t, -1, mfa.MatchRecoveryCode(hashes, "aaaaa-aaaaa"))
+	assert.False(t, mfa.IsRecoveryCode("123456"))
+}
@@ -0,0 +1,124 @@
+package mfa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SecretSize is the number of random bytes in a generated TOTP secret, the size RFC 4226 recommends.
+const SecretSize = 20
+
+// ErrInvalidSecret is returned for a secret that is not valid base32.
+var ErrInvalidSecret = errors.New("mfa: invalid TOTP secret")
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTP generates and validates RFC 6238 time-based one-time passwords with HMAC-SHA1,
+// the only algorithm every common authenticator app supports.
+type TOTP struct {
+	Digits int
+	Period time.Duration
+	// Skew is the number of periods before and after the current one in which a code is still accepted.
+	Skew int
+}
+
+// DefaultTOTP returns the parameters authenticator apps assume when the otpauth URI names none.
+func DefaultTOTP() TOTP {
+	return TOTP{
+		Digits: 6,
+		Period: 30 * time.Second,
+		Skew:   1,
+	}
+}
+
+// GenerateSecret returns a new random secret in the unpadded base32 form authenticator apps expect.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, SecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return secretEncoding.EncodeToString(secret), nil
+}
+
+// Step returns the time step a moment falls in.
+func (t TOTP) Step(at time.Time) int64 {
+	return at.Unix() / int64(t.Period/time.Second)
+}
+
+// Code returns the code for a secret at the given time step.
+func (t TOTP) Code(secret string, step int64) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation, RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulus := uint32(1)
+	for i := 0; i < t.Digits; i++ {
+		modulus *= 10
+	}
+	return fmt.Sprintf("%0*d", t.Digits, value%modulus), nil
+}
+
+// Validate checks a code against the steps around the given time and returns the step it matched.
+// Steps up to and including lastStep are refused, so each code can be used only once.
+func (t TOTP) Validate(secret string, code string, at time.Time, lastStep int64) (int64, bool, error) {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != t.Digits {
+		return 0, false, nil
+	}
+
+	current := t.Step(at)
+	for step := current - int64(t.Skew); step <= current+int64(t.Skew); step++ {
+		if step <= lastStep {
+			continue
+		}
+		expected, err := t.Code(secret, step)
+		if err != nil {
+			return 0, false, err
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// URI returns the otpauth URI that authenticator apps scan as a QR code.
+func (t TOTP) URI(issuer string, account string, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(t.Digits))
+	query.Set("period", fmt.Sprint(int64(t.Period/time.Second)))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
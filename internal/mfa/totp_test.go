@@ -0,0 +1,80 @@
+package mfa_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/mfa"
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is the SHA-1 test key of RFC 6238 appendix B, "12345678901234567890", in base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	totp := mfa.TOTP{Digits: 8, Period: 30 * time.Second}
+	vectors := map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := totp.Code(rfc6238Secret, totp.Step(time.Unix(unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+}
+
+func TestValidate_AcceptsSkewAndRefusesReplay(t *testing.T) {
+	totp := mfa.DefaultTOTP()
+	now := time.Unix(1700000000, 0)
+	previous, _ := totp.Code(rfc6238Secret, totp.Step(now)-1)
+	tooOld, _ := totp.Code(rfc6238Secret, totp.Step(now)-2)
+
+	step, ok, err := totp.Validate(rfc6238Secret, previous, now, 0)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, totp.Step(now)-1, step)
+
+	_, ok, _ = totp.Validate(rfc6238Secret, previous, now, step)
+	assert.False(t, ok)
+
+	_, ok, _ = totp.Validate(rfc6238Secret, tooOld, now, 0)
+	assert.False(t, ok)
+
+	_, ok, _ = totp.Validate(rfc6238Secret, "12345", now, 0)
+	assert.False(t, ok)
+}
+
+func TestValidate_InvalidSecret(t *testing.T) {
+	_, _, err := mfa.DefaultTOTP().Validate("not base32!", "123456", time.Now(), 0)
+	assert.ErrorIs(t, err, mfa.ErrInvalidSecret)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := mfa.GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	_, err = mfa.DefaultTOTP().Code(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	uri := mfa.DefaultTOTP().URI("BitBridge", "jane@mail.com", "JBSWY3DPEHPK3PXP")
+
+	parsed, err := url.Parse(uri)
+	assert.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/BitBridge:jane@mail.com", parsed.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", parsed.Query().Get("secret"))
+	assert.Equal(t, "BitBridge", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+	assert.Equal(t, "30", parsed.Query().Get("period"))
+}
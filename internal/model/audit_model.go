@@ -0,0 +1,229 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	AuditActionCreate        = "user.create"
+	AuditActionImport        = "user.import"
+	AuditActionLookupPrivate = "user.lookup_private"
+	AuditActionUpdate        = "user.update"
+	AuditActionDelete        = "user.delete"
+	AuditActionPasswordReset = "user.password_reset"
+	AuditActionLockout       = "user.lockout"
+	AuditActionUnlock        = "user.unlock"
+	AuditActionMFAEnable     = "user.mfa_enable"
+	AuditActionMFADisable    = "user.mfa_disable"
+	AuditActionExport        = "user.export"
+	AuditActionErase         = "user.erase"
+)
+
+// RedactedValue replaces secret and personal values in audit diffs.
+const RedactedValue = "[REDACTED]"
+
+// AuditHashVersion is the hash version of newly recorded events. Version 1 hashes a salted digest of
+// the source IP instead of the IP itself, so that the IP can be scrubbed without breaking the chain.
+// Version 0 events hash every value as recorded.
+const AuditHashVersion = 1
+
+// redactedFields are the fields whose values are never copied into audit diffs: the password hash,
+// and the personal data that is encrypted at rest and scrubbed on erasure.
+var redactedFields = map[string]bool{
+	"email":    true,
+	"username": true,
+	"password": true,
+	"profile":  true,
+}
+
+type FieldChange struct {
+	Field string `json:"field" bson:"field"`
+	Old   string `json:"old" bson:"old"`
+	New   string `json:"new" bson:"new"`
+}
+
+// AuditEvent is an append-only record of an action on a user. Each event carries the hash of
+// its predecessor, so editing or removing a stored event breaks the chain.
+type AuditEvent struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TenantID     string             `json:"tenant_id,omitempty" bson:"tenant_id,omitempty"`
+	Sequence     int64              `json:"sequence" bson:"sequence"`
+	Actor        string             `json:"actor" bson:"actor"`
+	Action       string             `json:"action" bson:"action"`
+	TargetUserID string             `json:"target_user_id" bson:"target_user_id"`
+	SourceIP     string             `json:"source_ip" bson:"source_ip"`
+	// SourceIPDigest stands in for SourceIP in the hash of version 1 events
+	SourceIPDigest string `json:"source_ip_digest,omitempty" bson:"source_ip_digest,omitempty"`
+	// Salt salts SourceIPDigest. It is scrubbed with the IP, so the digest cannot be reversed by trying every address
+	Salt        string        `json:"-" bson:"salt,omitempty"`
+	RequestID   string        `json:"request_id" bson:"request_id"`
+	Changes     []FieldChange `json:"changes" bson:"changes"`
+	CreatedAt   time.Time     `json:"created_at" bson:"created_at"`
+	PrevHash    string        `json:"prev_hash" bson:"prev_hash"`
+	Hash        string        `json:"hash" bson:"hash"`
+	HashVersion int           `json:"hash_version,omitempty" bson:"hash_version,omitempty"`
+	// ScrubbedAt is set once the personal data of an erased user was removed from the event
+	ScrubbedAt *time.Time `json:"scrubbed_at,omitempty" bson:"scrubbed_at,omitempty"`
+}
+
+// ComputeHash returns the chain hash of the event, covering every field except ID, Hash, the salt and
+// the scrubbing time. Version 1 events hash SourceIPDigest in place of SourceIP. TenantID and
+// HashVersion are omitted while empty so that events recorded before they existed keep their hash.
+func (event *AuditEvent) ComputeHash() string {
+	sourceIP := event.SourceIP
+	if event.HashVersion >= 1 {
+		sourceIP = event.SourceIPDigest
+	}
+	content, _ := json.Marshal(struct {
+		Sequence     int64
+		Actor        string
+		Action       string
+		TargetUserID string
+		SourceIP     string
+		RequestID    string
+		Changes      []FieldChange
+		CreatedAt    int64
+		PrevHash     string
+		TenantID     string `json:",omitempty"`
+		HashVersion  int    `json:",omitempty"`
+	}{
+		event.Sequence,
+		event.Actor,
+		event.Action,
+		event.TargetUserID,
+		sourceIP,
+		event.RequestID,
+		event.Changes,
+		event.CreatedAt.UnixMilli(),
+		event.PrevHash,
+		event.TenantID,
+		event.HashVersion,
+	})
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// DigestSourceIP salts and digests the source IP of an event about to be hashed with AuditHashVersion.
+func (event *AuditEvent) DigestSourceIP() error {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	event.HashVersion = AuditHashVersion
+	event.Salt = hex.EncodeToString(salt)
+	event.SourceIPDigest = event.sourceIPDigest()
+	return nil
+}
+
+func (event *AuditEvent) sourceIPDigest() string {
+	sum := sha256.Sum256([]byte(event.Salt + "\x00" + event.SourceIP))
+	return hex.EncodeToString(sum[:])
+}
+
+// MatchesHash reports whether the event still matches its hash. A version 1 event that keeps its
+// source IP must match its digest as well. A scrubbed version 0 event no longer holds the values its
+// hash covered, so only the link from its successor vouches for it.
+func (event *AuditEvent) MatchesHash() bool {
+	if event.HashVersion == 0 && event.ScrubbedAt != nil {
+		return true
+	}
+	if event.HashVersion >= 1 && event.SourceIP != "" && event.SourceIPDigest != event.sourceIPDigest() {
+		return false
+	}
+	return event.Hash == event.ComputeHash()
+}
+
+// Scrub removes the personal data of an erased user from the event: the source IP of their own
+// actions, and personal values that releases before redacting diffs recorded about them. It reports
+// whether anything was removed, and leaves the hash as it is.
+func (event *AuditEvent) Scrub(userID string, scrubbedAt time.Time) bool {
+	scrubbed := false
+	if event.Actor == userID && (event.SourceIP != "" || event.Salt != "") {
+		event.SourceIP, event.Salt = "", ""
+		scrubbed = true
+	}
+	if event.TargetUserID == userID {
+		for i, change := range event.Changes {
+			if !redactedFields[change.Field] {
+				continue
+			}
+			old, new := redact(change.Old), redact(change.New)
+			if old != change.Old || new != change.New {
+				event.Changes[i].Old, event.Changes[i].New = old, new
+				scrubbed = true
+			}
+		}
+	}
+	if scrubbed {
+		event.ScrubbedAt = &scrubbedAt
+	}
+	return scrubbed
+}
+
+// DiffUsers lists the fields that differ between two versions of a user. A nil version stands for
+// a user that does not exist yet or any more. Password hashes and personal data are never copied into
+// the diff: their changes only record whether the field was set before and after.
+func DiffUsers(before *PrivateUserModel, after *PrivateUserModel) []FieldChange {
+	fields := func(user *PrivateUserModel) map[string]string {
+		if user == nil {
+			return map[string]string{}
+		}
+		values := map[string]string{
+			"email":    user.Email,
+			"username": user.Username,
+			"password": user.Hash,
+		}
+		if user.Profile != nil {
+			values["profile"] = profileDigest(user.Profile)
+		}
+		if user.LockedUntil != nil {
+			values["locked_until"] = user.LockedUntil.UTC().Format(time.RFC3339)
+		}
+		if user.MFAEnabled {
+			values["mfa_enabled"] = "true"
+		}
+		return values
+	}
+
+	old, new := fields(before), fields(after)
+	changes := []FieldChange{}
+	for _, field := range []string{"email", "username", "password", "profile", "locked_until", "mfa_enabled"} {
+		if old[field] == new[field] {
+			continue
+		}
+
+		change := FieldChange{Field: field, Old: old[field], New: new[field]}
+		if redactedFields[field] {
+			change.Old, change.New = redact(change.Old), redact(change.New)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// redact replaces a value set in a redacted field with RedactedValue.
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return RedactedValue
+}
+
+// profileDigest renders a profile as JSON for the audit diff. Map keys are sorted by encoding/json,
+// so equal profiles always produce the same string.
+func profileDigest(profile *model.ProfileModel) string {
+	encoded, err := json.Marshal(profile)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
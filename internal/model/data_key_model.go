@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type DataKeyPurpose string
+
+const (
+	// DataKeyEncryption keys encrypt PII fields. The newest one is active and older ones remain for decryption.
+	DataKeyEncryption DataKeyPurpose = "encryption"
+	// DataKeyIndex keys compute blind indexes. There is only ever one, as replacing it invalidates every index.
+	DataKeyIndex DataKeyPurpose = "index"
+)
+
+// DataKey is a key used for PII at rest, stored wrapped by a master key that never leaves Vault or the key file.
+type DataKey struct {
+	ID          string         `json:"id" bson:"_id"`
+	Purpose     DataKeyPurpose `json:"purpose" bson:"purpose"`
+	WrappedKey  string         `json:"-" bson:"wrapped_key"`
+	MasterKeyID string         `json:"master_key_id" bson:"master_key_id"`
+	CreatedAt   time.Time      `json:"created_at" bson:"created_at"`
+}
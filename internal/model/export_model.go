@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// UserDataExport is everything stored about a user, as returned for a data subject access request.
+// The password hash is redacted and the TOTP secret left out, since neither is the user's data to take away.
+type UserDataExport struct {
+	ExportedAt    time.Time         `json:"exported_at"`
+	User          *PrivateUserModel `json:"user"`
+	AuditEvents   []*AuditEvent     `json:"audit_events"`
+	LoginAttempts []*LoginAttempt   `json:"login_attempts"`
+}
+
+// Filename returns the name the export is offered for download under.
+func (export *UserDataExport) Filename() string {
+	return "user-" + export.User.ID.Hex() + "-export.json"
+}
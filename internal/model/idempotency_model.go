@@ -0,0 +1,30 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type IdempotencyStatus string
+
+const (
+	IdempotencyPending   IdempotencyStatus = "pending"
+	IdempotencyCompleted IdempotencyStatus = "completed"
+)
+
+// IdempotencyRecord remembers the outcome of a request made with an idempotency key so that
+// retries of the same request can be answered without repeating it.
+type IdempotencyRecord struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Caller      string             `json:"caller" bson:"caller"`
+	Key         string             `json:"key" bson:"key"`
+	RequestHash string             `json:"request_hash" bson:"request_hash"`
+	Status      IdempotencyStatus  `json:"status" bson:"status"`
+	Response    json.RawMessage    `json:"response,omitempty" bson:"response,omitempty"`
+	// PendingUntil ends the lease of a pending request, after which a retry may take the key over
+	PendingUntil time.Time `json:"pending_until,omitempty" bson:"pending_until,omitempty"`
+	CreatedAt    time.Time `json:"created_at" bson:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at" bson:"expires_at"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LoginResult string
+
+const (
+	LoginSucceeded LoginResult = "success"
+	LoginFailed    LoginResult = "failure"
+	// LoginRejected marks an attempt refused because of a lockout or throttle, before the password was checked.
+	LoginRejected LoginResult = "rejected"
+	// LoginReset marks an administrator unlocking the account, which ends the run of failures.
+	LoginReset LoginResult = "reset"
+)
+
+// LoginAttempt records one credential verification. Attempts expire through a TTL index, so the
+// history only reaches back as far as brute-force tracking needs.
+type LoginAttempt struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TenantID  string             `json:"tenant_id" bson:"tenant_id"`
+	UserID    string             `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	SourceIP  string             `json:"source_ip,omitempty" bson:"source_ip,omitempty"`
+	Result    LoginResult        `json:"result" bson:"result"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OutboxEventType string
+
+const (
+	UserCreatedEvent     OutboxEventType = "UserCreated"
+	UserUpdatedEvent     OutboxEventType = "UserUpdated"
+	PasswordChangedEvent OutboxEventType = "PasswordChanged"
+	UserDeletedEvent     OutboxEventType = "UserDeleted"
+	UserErasedEvent      OutboxEventType = "UserErased"
+)
+
+// OutboxEvent is a domain event waiting in the outbox collection to be relayed to other services.
+type OutboxEvent struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	IdempotencyKey string             `json:"idempotency_key" bson:"idempotency_key"`
+	Type           OutboxEventType    `json:"type" bson:"type"`
+	AggregateID    string             `json:"aggregate_id" bson:"aggregate_id"`
+	Payload        json.RawMessage    `json:"payload" bson:"payload"`
+	Attempts       int                `json:"attempts" bson:"attempts"`
+	LastError      string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	NextAttemptAt  time.Time          `json:"next_attempt_at" bson:"next_attempt_at"`
+	PublishedAt    *time.Time         `json:"published_at,omitempty" bson:"published_at"`
+}
+
+// NewOutboxEvent creates an event for a user mutation. The payload carries only the public projection,
+// and the idempotency key is derived from the user version so consumers can drop redelivered events.
+func NewOutboxEvent(eventType OutboxEventType, user *PrivateUserModel) (*OutboxEvent, error) {
+	payload, err := json.Marshal(user.ToPublicUserModel())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &OutboxEvent{
+		ID:             primitive.NewObjectID(),
+		IdempotencyKey: fmt.Sprintf("%s:%s:%d", eventType, user.ID.Hex(), user.UpdatedAt.UnixNano()),
+		Type:           eventType,
+		AggregateID:    user.ID.Hex(),
+		Payload:        payload,
+		CreatedAt:      now,
+		NextAttemptAt:  now,
+	}, nil
+}
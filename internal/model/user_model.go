@@ -1,6 +1,10 @@
 package model
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
 	"time"
 
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
@@ -8,18 +12,117 @@ import (
 )
 
 type PrivateUserModel struct {
-	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Email     string             `json:"email" bson:"email"`
-	Username  string             `json:"username" bson:"username"`
-	Hash      string             `json:"password" bson:"password"`
-	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+	ID                primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	TenantID          string              `json:"tenant_id" bson:"tenant_id"`
+	Email             string              `json:"email" bson:"email"`
+	EmailIndex        string              `json:"-" bson:"email_index,omitempty"`
+	Username          string              `json:"username" bson:"username"`
+	Hash              string              `json:"password" bson:"password"`
+	Roles             []string            `json:"roles,omitempty" bson:"roles,omitempty"`
+	Profile           *model.ProfileModel `json:"profile,omitempty" bson:"profile,omitempty"`
+	LockedUntil       *time.Time          `json:"locked_until,omitempty" bson:"locked_until,omitempty"`
+	MFAEnabled        bool                `json:"mfa_enabled" bson:"mfa_enabled"`
+	MFA               *MFAState           `json:"-" bson:"mfa,omitempty"`
+	PIIKeyID          string              `json:"-" bson:"pii_key_id,omitempty"`
+	ErasedAt          *time.Time          `json:"erased_at,omitempty" bson:"erased_at,omitempty"`
+	ErasureRef        string              `json:"erasure_ref,omitempty" bson:"erasure_ref,omitempty"`
+	UsernameHeldUntil *time.Time          `json:"username_held_until,omitempty" bson:"username_held_until,omitempty"`
+	CreatedAt         time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at" bson:"updated_at"`
 }
 
+// MFAState is a user's TOTP enrolment. It exists with MFAEnabled unset between enrolment and
+// confirmation. The secret is encrypted at rest and only hashes of recovery codes are stored.
+type MFAState struct {
+	Secret        string     `bson:"secret"`
+	EnrolledAt    time.Time  `bson:"enrolled_at"`
+	ConfirmedAt   *time.Time `bson:"confirmed_at,omitempty"`
+	LastStep      int64      `bson:"last_step"`
+	RecoveryCodes []string   `bson:"recovery_codes,omitempty"`
+}
+
+// IsLocked reports whether sign-in is blocked at the given time after repeated failed attempts.
+func (privateUserModel *PrivateUserModel) IsLocked(now time.Time) bool {
+	return privateUserModel.LockedUntil != nil && now.Before(*privateUserModel.LockedUntil)
+}
+
+// IsErased reports whether the user was erased on request and only a tombstone remains.
+func (privateUserModel *PrivateUserModel) IsErased() bool {
+	return privateUserModel.ErasedAt != nil
+}
+
+// Tombstone returns what remains of the user after erasure: the id, tenant and timestamps that audit
+// events and other services refer to, and the username until usernameHeldUntil. Everything else is
+// dropped, and the email is replaced with a unique placeholder that cannot receive mail.
+func (privateUserModel *PrivateUserModel) Tombstone(erasedAt time.Time, usernameHeldUntil time.Time, erasureKey []byte) *PrivateUserModel {
+	return &PrivateUserModel{
+		ID:                privateUserModel.ID,
+		TenantID:          privateUserModel.TenantID,
+		Email:             "erased-" + privateUserModel.ID.Hex() + "@erased.invalid",
+		Username:          privateUserModel.Username,
+		ErasedAt:          &erasedAt,
+		ErasureRef:        ErasureRef(erasureKey, privateUserModel),
+		UsernameHeldUntil: &usernameHeldUntil,
+		CreatedAt:         privateUserModel.CreatedAt,
+		UpdatedAt:         erasedAt,
+	}
+}
+
+// ErasureRef returns a one-way reference to the identity of a user. It lets an auditor holding the key
+// confirm that an erased account belonged to a claimed identity without the service keeping that
+// identity. The reference is keyed, so that it cannot be matched against guessed emails without the key.
+func ErasureRef(key []byte, user *PrivateUserModel) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.Join([]string{user.TenantID, user.ID.Hex(), user.Email, user.Username}, "\x00")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ErasedUsername is the username of an erased user once its hold period is over.
+func ErasedUsername(id primitive.ObjectID) string {
+	return "erased-" + id.Hex()
+}
+
+// ToPublicUserModel returns the projection shown to unauthenticated callers.
 func (privateUserModel *PrivateUserModel) ToPublicUserModel() *model.PublicUserModel {
+	return privateUserModel.ToPublicUserModelFor(model.VisibilityPublic)
+}
+
+// ToPublicUserModelFor returns the public projection with the profile fields the audience may see.
+func (privateUserModel *PrivateUserModel) ToPublicUserModelFor(audience model.Visibility) *model.PublicUserModel {
 	return &model.PublicUserModel{
 		ID:        privateUserModel.ID,
 		Username:  privateUserModel.Username,
+		Profile:   privateUserModel.Profile.VisibleTo(audience),
 		CreatedAt: privateUserModel.CreatedAt,
+		UpdatedAt: privateUserModel.UpdatedAt,
+	}
+}
+
+// ToSelfUserModel returns the projection shown to the owner of the account.
+func (privateUserModel *PrivateUserModel) ToSelfUserModel() *model.SelfUserModel {
+	return &model.SelfUserModel{
+		ID:         privateUserModel.ID,
+		Email:      privateUserModel.Email,
+		Username:   privateUserModel.Username,
+		Profile:    privateUserModel.Profile,
+		MFAEnabled: privateUserModel.MFAEnabled,
+		CreatedAt:  privateUserModel.CreatedAt,
+		UpdatedAt:  privateUserModel.UpdatedAt,
+	}
+}
+
+// ToAccountUserModel returns the projection shown to privileged callers, locked as of now.
+func (privateUserModel *PrivateUserModel) ToAccountUserModel(now time.Time) *model.AccountUserModel {
+	return &model.AccountUserModel{
+		ID:          privateUserModel.ID,
+		Email:       privateUserModel.Email,
+		Username:    privateUserModel.Username,
+		Hash:        privateUserModel.Hash,
+		Profile:     privateUserModel.Profile,
+		Locked:      privateUserModel.IsLocked(now),
+		LockedUntil: privateUserModel.LockedUntil,
+		MFAEnabled:  privateUserModel.MFAEnabled,
+		CreatedAt:   privateUserModel.CreatedAt,
+		UpdatedAt:   privateUserModel.UpdatedAt,
 	}
 }
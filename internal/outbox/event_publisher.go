@@ -0,0 +1,77 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+)
+
+// IEventPublisher delivers outbox events to other services. Delivery is at least once,
+// so implementations may see the same event again and consumers should dedupe on its idempotency key.
+type IEventPublisher interface {
+	Publish(ctx context.Context, event *model.OutboxEvent) error
+}
+
+// LogEventPublisher is an implementation of IEventPublisher that writes events to a logger.
+type LogEventPublisher struct {
+	Logger *slog.Logger
+}
+
+// NewLogEventPublisher creates a new instance of LogEventPublisher.
+func NewLogEventPublisher(logger *slog.Logger) *LogEventPublisher {
+	return &LogEventPublisher{
+		Logger: logger,
+	}
+}
+
+// Publish implements IEventPublisher.
+func (p *LogEventPublisher) Publish(ctx context.Context, event *model.OutboxEvent) error {
+	p.Logger.InfoContext(ctx, "event",
+		"type", event.Type,
+		"aggregate_id", event.AggregateID,
+		"idempotency_key", event.IdempotencyKey,
+		"payload", string(event.Payload),
+	)
+	return nil
+}
+
+// FileEventPublisher is an implementation of IEventPublisher that appends events as JSON lines to a file.
+type FileEventPublisher struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// NewFileEventPublisher creates a new instance of FileEventPublisher.
+func NewFileEventPublisher(path string) *FileEventPublisher {
+	return &FileEventPublisher{
+		Path: path,
+	}
+}
+
+// Publish implements IEventPublisher.
+func (p *FileEventPublisher) Publish(ctx context.Context, event *model.OutboxEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	file, err := os.OpenFile(p.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+var _ IEventPublisher = (*LogEventPublisher)(nil)
+var _ IEventPublisher = (*FileEventPublisher)(nil)
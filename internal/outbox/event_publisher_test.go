@@ -0,0 +1,47 @@
+package outbox_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/outbox"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFileEventPublisher_AppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	publisher := outbox.NewFileEventPublisher(path)
+
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test", Email: "test@mail.com", Hash: "secret"}
+	created, err := model.NewOutboxEvent(model.UserCreatedEvent, user)
+	assert.NoError(t, err)
+	deleted, err := model.NewOutboxEvent(model.UserDeletedEvent, user)
+	assert.NoError(t, err)
+
+	assert.NoError(t, publisher.Publish(context.Background(), created))
+	assert.NoError(t, publisher.Publish(context.Background(), deleted))
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	var lines []model.OutboxEvent
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event model.OutboxEvent
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		lines = append(lines, event)
+	}
+
+	assert.Len(t, lines, 2)
+	assert.Equal(t, created.IdempotencyKey, lines[0].IdempotencyKey)
+	assert.Equal(t, model.UserDeletedEvent, lines[1].Type)
+	assert.NotContains(t, string(lines[0].Payload), "secret")
+	assert.NotContains(t, string(lines[0].Payload), "test@mail.com")
+}
@@ -0,0 +1,99 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+)
+
+const (
+	DefaultBatchSize    = 100
+	DefaultPollInterval = time.Second
+	DefaultRetryBackoff = time.Second
+	DefaultMaxBackoff   = 5 * time.Minute
+)
+
+// Relay moves events from the outbox collection to an IEventPublisher.
+// An event is marked published only after the publisher accepts it, so a crash in between
+// causes a redelivery rather than a lost event.
+type Relay struct {
+	Repository   repository.IOutboxRepository
+	Publisher    IEventPublisher
+	BatchSize    int64
+	PollInterval time.Duration
+	RetryBackoff time.Duration
+	MaxBackoff   time.Duration
+	Logger       *slog.Logger
+}
+
+// NewRelay creates a new instance of Relay with default batching and retry settings.
+func NewRelay(repository repository.IOutboxRepository, publisher IEventPublisher, logger *slog.Logger) *Relay {
+	return &Relay{
+		Repository:   repository,
+		Publisher:    publisher,
+		BatchSize:    DefaultBatchSize,
+		PollInterval: DefaultPollInterval,
+		RetryBackoff: DefaultRetryBackoff,
+		MaxBackoff:   DefaultMaxBackoff,
+		Logger:       logger,
+	}
+}
+
+// Run relays events until the context is done.
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RelayOnce(ctx); err != nil {
+			r.Logger.ErrorContext(ctx, "Outbox relay failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes one batch of due events and returns how many were published.
+// Failed events are rescheduled with exponential backoff.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	now := time.Now()
+	events, err := r.Repository.FindPending(ctx, now, r.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, event := range events {
+		if err := r.Publisher.Publish(ctx, event); err != nil {
+			nextAttemptAt := now.Add(r.backoff(event.Attempts))
+			if err := r.Repository.MarkFailed(ctx, event.ID, err.Error(), nextAttemptAt); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if err := r.Repository.MarkPublished(ctx, event.ID, time.Now()); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	backoff := r.RetryBackoff
+	for i := 0; i < attempts && backoff < r.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > r.MaxBackoff {
+		return r.MaxBackoff
+	}
+	return backoff
+}
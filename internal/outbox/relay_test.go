@@ -0,0 +1,122 @@
+package outbox_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/outbox"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MockIOutboxRepository struct {
+	mock.Mock
+}
+
+// Add implements repository.IOutboxRepository.
+func (m *MockIOutboxRepository) Add(ctx context.Context, event *model.OutboxEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// FindPending implements repository.IOutboxRepository.
+func (m *MockIOutboxRepository) FindPending(ctx context.Context, now time.Time, limit int64) ([]*model.OutboxEvent, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.OutboxEvent), args.Error(1)
+}
+
+// MarkPublished implements repository.IOutboxRepository.
+func (m *MockIOutboxRepository) MarkPublished(ctx context.Context, id primitive.ObjectID, publishedAt time.Time) error {
+	args := m.Called(ctx, id, publishedAt)
+	return args.Error(0)
+}
+
+// MarkFailed implements repository.IOutboxRepository.
+func (m *MockIOutboxRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, reason string, nextAttemptAt time.Time) error {
+	args := m.Called(ctx, id, reason, nextAttemptAt)
+	return args.Error(0)
+}
+
+// Ensure that MockIOutboxRepository implements IOutboxRepository.
+var _ repository.IOutboxRepository = &MockIOutboxRepository{}
+
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+// Publish implements outbox.IEventPublisher.
+func (m *MockEventPublisher) Publish(ctx context.Context, event *model.OutboxEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// Ensure that MockEventPublisher implements IEventPublisher.
+var _ outbox.IEventPublisher = &MockEventPublisher{}
+
+func TestRelayOnce_PublishesAndMarks(t *testing.T) {
+	ctx := context.Background()
+	delivered := &model.OutboxEvent{ID: primitive.NewObjectID(), Type: model.UserCreatedEvent}
+	failing := &model.OutboxEvent{ID: primitive.NewObjectID(), Type: model.UserCreatedEvent, Attempts: 2}
+
+	mockRepository := new(MockIOutboxRepository)
+	mockPublisher := new(MockEventPublisher)
+	mockRepository.On("FindPending", ctx, mock.Anything, int64(outbox.DefaultBatchSize)).Return([]*model.OutboxEvent{delivered, failing}, nil)
+	mockPublisher.On("Publish", ctx, delivered).Return(nil)
+	mockPublisher.On("Publish", ctx, failing).Return(assert.AnError)
+	mockRepository.On("MarkPublished", ctx, delivered.ID, mock.Anything).Return(nil)
+	mockRepository.On("MarkFailed", ctx, failing.ID, assert.AnError.Error(), mock.MatchedBy(func(next time.Time) bool {
+		// two previous attempts double the one second backoff twice
+		return time.Until(next) > 3*time.Second && time.Until(next) <= 4*time.Second
+	})).Return(nil)
+
+	relay := outbox.NewRelay(mockRepository, mockPublisher, logging.NewLogger(io.Discard, slog.LevelInfo))
+	published, err := relay.RelayOnce(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, published)
+	mockRepository.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestRelayOnce_FindPendingError(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepository := new(MockIOutboxRepository)
+	mockPublisher := new(MockEventPublisher)
+	mockRepository.On("FindPending", ctx, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	relay := outbox.NewRelay(mockRepository, mockPublisher, logging.NewLogger(io.Discard, slog.LevelInfo))
+	published, err := relay.RelayOnce(ctx)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, published)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestRelayOnce_MarkPublishedError(t *testing.T) {
+	ctx := context.Background()
+	event := &model.OutboxEvent{ID: primitive.NewObjectID(), Type: model.UserDeletedEvent}
+
+	mockRepository := new(MockIOutboxRepository)
+	mockPublisher := new(MockEventPublisher)
+	mockRepository.On("FindPending", ctx, mock.Anything, mock.Anything).Return([]*model.OutboxEvent{event}, nil)
+	mockPublisher.On("Publish", ctx, event).Return(nil)
+	mockRepository.On("MarkPublished", ctx, event.ID, mock.Anything).Return(assert.AnError)
+
+	relay := outbox.NewRelay(mockRepository, mockPublisher, logging.NewLogger(io.Discard, slog.LevelInfo))
+	published, err := relay.RelayOnce(ctx)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, published)
+	mockRepository.AssertExpectations(t)
+}
@@ -0,0 +1,148 @@
+package passwordpolicy
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// IBreachChecker reports whether a password is known from data breaches.
+type IBreachChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+// IRangeSource returns the SHA-1 hash suffixes of breached passwords whose hash starts with a five
+// character prefix. Only the prefix leaves the service, so the source never learns the password.
+type IRangeSource interface {
+	Range(ctx context.Context, prefix string) ([]string, error)
+}
+
+// KAnonymityBreachChecker is an implementation of IBreachChecker using k-anonymity range queries.
+type KAnonymityBreachChecker struct {
+	Source IRangeSource
+}
+
+// NewKAnonymityBreachChecker creates a new instance of KAnonymityBreachChecker.
+func NewKAnonymityBreachChecker(source IRangeSource) *KAnonymityBreachChecker {
+	return &KAnonymityBreachChecker{
+		Source: source,
+	}
+}
+
+// IsBreached implements IBreachChecker.
+func (c *KAnonymityBreachChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := c.Source.Range(ctx, digest[:5])
+	if err != nil {
+		return false, err
+	}
+
+	for _, suffix := range suffixes {
+		if suffix == digest[5:] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DefaultPwnedPasswordsURL is the range API of Have I Been Pwned's Pwned Passwords.
+const DefaultPwnedPasswordsURL = "https://api.pwnedpasswords.com/range/"
+
+// HTTPRangeSource is an implementation of IRangeSource for the Pwned Passwords range API.
+type HTTPRangeSource struct {
+	Client  *http.Client
+	BaseURL string
+}
+
+// NewHTTPRangeSource creates a new instance of HTTPRangeSource with a request timeout.
+func NewHTTPRangeSource(baseURL string, timeout time.Duration) *HTTPRangeSource {
+	return &HTTPRangeSource{
+		Client:  &http.Client{Timeout: timeout},
+		BaseURL: baseURL,
+	}
+}
+
+// Range implements IRangeSource.
+// Responses are padded with zero-count entries so their size does not reveal the prefix; those are dropped.
+func (s *HTTPRangeSource) Range(ctx context.Context, prefix string) ([]string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.BaseURL+prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Add-Padding", "true")
+
+	response, err := s.Client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("range request for %s failed with status %d", prefix, response.StatusCode)
+	}
+
+	return parseRange(response.Body)
+}
+
+//go:embed breached_fixture.txt
+var breachedFixture string
+
+// FixtureRangeSource is an implementation of IRangeSource serving a fixed set of hashes, for tests
+// and environments without network access.
+type FixtureRangeSource struct {
+	Hashes map[string][]string
+}
+
+// NewFixtureRangeSource creates a FixtureRangeSource from the bundled fixture.
+func NewFixtureRangeSource() *FixtureRangeSource {
+	source, _ := LoadFixtureRangeSource(strings.NewReader(breachedFixture))
+	return source
+}
+
+// LoadFixtureRangeSource reads full SHA-1 hashes in the Pwned Passwords download format, HASH:COUNT per line.
+func LoadFixtureRangeSource(r io.Reader) (*FixtureRangeSource, error) {
+	hashes, err := parseRange(r)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &FixtureRangeSource{Hashes: map[string][]string{}}
+	for _, hash := range hashes {
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("fixture hash %q is not a SHA-1 hash", hash)
+		}
+		source.Hashes[hash[:5]] = append(source.Hashes[hash[:5]], hash[5:])
+	}
+	return source, nil
+}
+
+// Range implements IRangeSource.
+func (s *FixtureRangeSource) Range(ctx context.Context, prefix string) ([]string, error) {
+	return s.Hashes[strings.ToUpper(prefix)], nil
+}
+
+// parseRange reads HASH:COUNT lines, skipping entries with a count of zero.
+func parseRange(r io.Reader) ([]string, error) {
+	var hashes []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		hash, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if hash == "" || count == "0" {
+			continue
+		}
+		hashes = append(hashes, strings.ToUpper(hash))
+	}
+	return hashes, scanner.Err()
+}
+
+// Ensure KAnonymityBreachChecker implements IBreachChecker
+var _ IBreachChecker = (*KAnonymityBreachChecker)(nil)
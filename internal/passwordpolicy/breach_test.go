@@ -0,0 +1,65 @@
+package passwordpolicy_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/passwordpolicy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKAnonymityBreachChecker_SendsOnlyThePrefix(t *testing.T) {
+	// Arrange
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+		// SHA-1 of "Tr0ub4dor&3" is 87452E7A5AE6A49466A6AC578B98ADBA78C6AA6
+		fmt.Fprint(w, "0000000000000000000000000000000000A:0\r\n2E7A5AE6A49466A6AC578B98ADBA78C6AA6:92\r\n")
+	}))
+	defer server.Close()
+	checker := passwordpolicy.NewKAnonymityBreachChecker(passwordpolicy.NewHTTPRangeSource(server.URL+"/range/", time.Second))
+
+	// Act
+	breached, err := checker.IsBreached(context.Background(), "Tr0ub4dor&3")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, breached)
+	assert.Equal(t, "/range/87457", requested)
+}
+
+func TestKAnonymityBreachChecker_IgnoresPadding(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "2E7A5AE6A49466A6AC578B98ADBA78C6AA6:0\r\n")
+	}))
+	defer server.Close()
+	checker := passwordpolicy.NewKAnonymityBreachChecker(passwordpolicy.NewHTTPRangeSource(server.URL+"/", time.Second))
+
+	// Act
+	breached, err := checker.IsBreached(context.Background(), "Tr0ub4dor&3")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, breached)
+}
+
+func TestHTTPRangeSource_ErrorStatus(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	// Act
+	suffixes, err := passwordpolicy.NewHTTPRangeSource(server.URL+"/", time.Second).Range(context.Background(), "87457")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, suffixes)
+}
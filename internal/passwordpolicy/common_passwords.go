@@ -0,0 +1,91 @@
+package passwordpolicy
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"strings"
+	"sync"
+)
+
+//go:embed common_passwords.txt
+var bundledCommonPasswords string
+
+// CommonPasswords is a rejection list ranked by how often each password is used, most common first.
+type CommonPasswords struct {
+	ranks map[string]int
+}
+
+var (
+	bundledOnce sync.Once
+	bundled     *CommonPasswords
+)
+
+// BundledCommonPasswords returns the list shipped with the service. It is parsed once and shared.
+func BundledCommonPasswords() *CommonPasswords {
+	bundledOnce.Do(func() {
+		bundled, _ = LoadCommonPasswords(strings.NewReader(bundledCommonPasswords))
+	})
+	return bundled
+}
+
+// LoadCommonPasswords reads one password per line in order of frequency. Blank lines and lines
+// starting with # are skipped.
+func LoadCommonPasswords(r io.Reader) (*CommonPasswords, error) {
+	list := &CommonPasswords{ranks: map[string]int{}}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, ok := list.ranks[line]; !ok {
+			list.ranks[line] = len(list.ranks) + 1
+		}
+	}
+
+	return list, scanner.Err()
+}
+
+// Contains reports whether the password, ignoring case, common character substitutions and a
+// trailing run of digits or symbols, is on the list.
+func (c *CommonPasswords) Contains(password string) bool {
+	lowered := strings.ToLower(password)
+	for _, candidate := range []string{lowered, strings.TrimRightFunc(lowered, isDigitOrSymbol)} {
+		for _, variant := range unleet(candidate) {
+			if _, ok := c.ranks[variant]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rank returns the 1-based rank of an already lowercased word, or 0 when it is not on the list.
+func (c *CommonPasswords) rank(word string) int {
+	if c == nil {
+		return 0
+	}
+	return c.ranks[word]
+}
+
+var leetSubstitutions = []*strings.Replacer{
+	strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s", "!", "i"),
+	strings.NewReplacer("0", "o", "1", "l", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s", "!", "l"),
+}
+
+// unleet returns word along with its readings under the common substitutions.
+func unleet(word string) []string {
+	variants := []string{word}
+	for _, replacer := range leetSubstitutions {
+		if variant := replacer.Replace(word); variant != word {
+			variants = append(variants, variant)
+		}
+	}
+	return variants
+}
+
+func isDigitOrSymbol(r rune) bool {
+	return !(r >= 'a' && r <= 'z') && r < 0x80
+}
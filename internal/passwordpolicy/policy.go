@@ -0,0 +1,153 @@
+package passwordpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrWeakPassword is wrapped by every ValidationError.
+var ErrWeakPassword = errors.New("password does not meet the password policy")
+
+type ReasonCode string
+
+const (
+	ReasonTooShort         ReasonCode = "too_short"
+	ReasonTooLong          ReasonCode = "too_long"
+	ReasonCommon           ReasonCode = "common"
+	ReasonContainsUserInfo ReasonCode = "contains_user_info"
+	ReasonTooWeak          ReasonCode = "too_weak"
+	ReasonBreached         ReasonCode = "breached"
+)
+
+// Reason explains one way in which a password fails the policy.
+type Reason struct {
+	Code    ReasonCode `json:"code"`
+	Message string     `json:"message"`
+}
+
+// ValidationError lists every reason a password was rejected.
+type ValidationError struct {
+	Reasons []Reason
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Reasons))
+	for i, reason := range e.Reasons {
+		messages[i] = reason.Message
+	}
+	return ErrWeakPassword.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrWeakPassword
+}
+
+// IPolicy decides whether a password is acceptable. userInputs are values the password should not
+// be built from, such as the account's username and email.
+type IPolicy interface {
+	Check(ctx context.Context, password string, userInputs ...string) error
+}
+
+// Policy is an implementation of IPolicy. A nil Common list or BreachChecker disables that check.
+type Policy struct {
+	MinLength     int
+	MaxLength     int
+	MinScore      int
+	Common        *CommonPasswords
+	BreachChecker IBreachChecker
+	Logger        *slog.Logger
+}
+
+const (
+	DefaultMinLength = 10
+	DefaultMaxLength = 128
+	DefaultMinScore  = 3
+)
+
+// DefaultPolicy checks length, strength and the bundled common-password list, without breach screening.
+func DefaultPolicy(logger *slog.Logger) *Policy {
+	return &Policy{
+		MinLength: DefaultMinLength,
+		MaxLength: DefaultMaxLength,
+		MinScore:  DefaultMinScore,
+		Common:    BundledCommonPasswords(),
+		Logger:    logger,
+	}
+}
+
+// Check implements IPolicy.
+// The breach checker is consulted only for passwords that pass every local check, and is best effort:
+// when it fails the password is accepted and the failure logged.
+func (p *Policy) Check(ctx context.Context, password string, userInputs ...string) error {
+	var reasons []Reason
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		reasons = append(reasons, Reason{ReasonTooShort, fmt.Sprintf("must be at least %d characters long", p.MinLength)})
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		reasons = append(reasons, Reason{ReasonTooLong, fmt.Sprintf("must be at most %d characters long", p.MaxLength)})
+	}
+
+	if p.Common != nil && p.Common.Contains(password) {
+		reasons = append(reasons, Reason{ReasonCommon, "is a commonly used password"})
+	}
+
+	if containsUserInput(password, userInputs) {
+		reasons = append(reasons, Reason{ReasonContainsUserInfo, "must not contain your username or email"})
+	}
+
+	strength := EstimateStrength(password, p.Common, userInputs...)
+	if strength.Score < p.MinScore {
+		message := fmt.Sprintf("is too easy to guess (strength %d of 4, at least %d required)", strength.Score, p.MinScore)
+		if len(strength.Feedback) > 0 {
+			message += ": " + strings.Join(strength.Feedback, ", ")
+		}
+		reasons = append(reasons, Reason{ReasonTooWeak, message})
+	}
+
+	if len(reasons) == 0 && p.BreachChecker != nil {
+		breached, err := p.BreachChecker.IsBreached(ctx, password)
+		if err != nil {
+			p.Logger.WarnContext(ctx, "Failed to check password against breached passwords", "error", err)
+		} else if breached {
+			reasons = append(reasons, Reason{ReasonBreached, "has appeared in a data breach"})
+		}
+	}
+
+	if len(reasons) > 0 {
+		return &ValidationError{Reasons: reasons}
+	}
+	return nil
+}
+
+// MinUserInputLength is the shortest user input, or email local part, matched inside a password.
+const MinUserInputLength = 3
+
+func containsUserInput(password string, userInputs []string) bool {
+	lowered := strings.ToLower(password)
+	for _, input := range userInputs {
+		for _, candidate := range userInputVariants(input) {
+			if utf8.RuneCountInString(candidate) >= MinUserInputLength && strings.Contains(lowered, candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// userInputVariants returns the lowercased input and, for an email, its local part.
+func userInputVariants(input string) []string {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if local, _, ok := strings.Cut(input, "@"); ok {
+		return []string{input, local}
+	}
+	return []string{input}
+}
+
+// Ensure Policy implements IPolicy
+var _ IPolicy = (*Policy)(nil)
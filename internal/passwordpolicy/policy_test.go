@@ -0,0 +1,135 @@
+package passwordpolicy_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/passwordpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIBreachChecker struct {
+	mock.Mock
+}
+
+// IsBreached implements passwordpolicy.IBreachChecker.
+func (m *MockIBreachChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	args := m.Called(ctx, password)
+	return args.Bool(0), args.Error(1)
+}
+
+func reasonCodes(t *testing.T, err error) []passwordpolicy.ReasonCode {
+	var validationError *passwordpolicy.ValidationError
+	if !errors.As(err, &validationError) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+
+	codes := make([]passwordpolicy.ReasonCode, len(validationError.Reasons))
+	for i, reason := range validationError.Reasons {
+		codes[i] = reason.Code
+	}
+	return codes
+}
+
+func TestPolicy_RejectsWeakPasswords(t *testing.T) {
+	cases := map[string]struct {
+		password string
+		expected []passwordpolicy.ReasonCode
+	}{
+		"common with suffix": {"password123", []passwordpolicy.ReasonCode{passwordpolicy.ReasonCommon, passwordpolicy.ReasonTooWeak}},
+		"common substituted": {"P@ssw0rd", []passwordpolicy.ReasonCode{passwordpolicy.ReasonTooShort, passwordpolicy.ReasonCommon, passwordpolicy.ReasonTooWeak}},
+		"repeated":           {"aaaaaaaaaaaa", []passwordpolicy.ReasonCode{passwordpolicy.ReasonTooWeak}},
+		"sequence":           {"abcdefghijk", []passwordpolicy.ReasonCode{passwordpolicy.ReasonTooWeak}},
+		"keyboard walk":      {"qwertyuiop", []passwordpolicy.ReasonCode{passwordpolicy.ReasonCommon, passwordpolicy.ReasonTooWeak}},
+		"username and year":  {"johnsmith1990", []passwordpolicy.ReasonCode{passwordpolicy.ReasonContainsUserInfo, passwordpolicy.ReasonTooWeak}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			err := passwordpolicy.DefaultPolicy(logging.NewLogger(io.Discard, slog.LevelInfo)).Check(context.Background(), tc.password, "johnsmith", "john@mail.com")
+
+			// Assert
+			assert.ErrorIs(t, err, passwordpolicy.ErrWeakPassword)
+			assert.Equal(t, tc.expected, reasonCodes(t, err))
+		})
+	}
+}
+
+func TestPolicy_AcceptsStrongPasswords(t *testing.T) {
+	for _, password := range []string{"Tr0ub4dor&3", "correct horse battery staple", "kX9#mQ2!vLp7", "blueberry-Lamp-42"} {
+		assert.NoError(t, passwordpolicy.DefaultPolicy(logging.NewLogger(io.Discard, slog.LevelInfo)).Check(context.Background(), password, "johnsmith"), password)
+	}
+}
+
+func TestPolicy_ReasonsAreExplained(t *testing.T) {
+	// Act
+	err := passwordpolicy.DefaultPolicy(logging.NewLogger(io.Discard, slog.LevelInfo)).Check(context.Background(), "summer2024")
+
+	// Assert
+	assert.EqualError(t, err, "password does not meet the password policy: is a commonly used password; "+
+		"is too easy to guess (strength 1 of 4, at least 3 required): avoid common words and passwords, avoid years and dates")
+}
+
+func TestPolicy_TooLong(t *testing.T) {
+	// Arrange
+	policy := passwordpolicy.DefaultPolicy(logging.NewLogger(io.Discard, slog.LevelInfo))
+	policy.MaxLength = 12
+
+	// Act
+	err := policy.Check(context.Background(), "kX9#mQ2!vLp7-kX9#mQ2!vLp7")
+
+	// Assert
+	assert.Equal(t, []passwordpolicy.ReasonCode{passwordpolicy.ReasonTooLong}, reasonCodes(t, err))
+}
+
+func TestPolicy_BreachedPassword(t *testing.T) {
+	// Arrange
+	policy := passwordpolicy.DefaultPolicy(logging.NewLogger(io.Discard, slog.LevelInfo))
+	policy.BreachChecker = passwordpolicy.NewKAnonymityBreachChecker(passwordpolicy.NewFixtureRangeSource())
+
+	// Act
+	err := policy.Check(context.Background(), "Tr0ub4dor&3")
+
+	// Assert
+	assert.Equal(t, []passwordpolicy.ReasonCode{passwordpolicy.ReasonBreached}, reasonCodes(t, err))
+	assert.NoError(t, policy.Check(context.Background(), "kX9#mQ2!vLp7"))
+}
+
+func TestPolicy_BreachCheckSkippedForLocallyRejectedPasswords(t *testing.T) {
+	// Arrange
+	checker := new(MockIBreachChecker)
+	policy := passwordpolicy.DefaultPolicy(logging.NewLogger(io.Discard, slog.LevelInfo))
+	policy.BreachChecker = checker
+
+	// Act
+	err := policy.Check(context.Background(), "password123")
+
+	// Assert
+	assert.ErrorIs(t, err, passwordpolicy.ErrWeakPassword)
+	checker.AssertNotCalled(t, "IsBreached", mock.Anything, mock.Anything)
+}
+
+func TestPolicy_BreachCheckFailureAcceptsPassword(t *testing.T) {
+	// Arrange
+	checker := new(MockIBreachChecker)
+	checker.On("IsBreached", mock.Anything, "kX9#mQ2!vLp7").Return(false, errors.New("unavailable"))
+	var logs bytes.Buffer
+	policy := passwordpolicy.DefaultPolicy(logging.NewLogger(&logs, slog.LevelInfo))
+	policy.BreachChecker = checker
+
+	// Act
+	err := policy.Check(context.Background(), "kX9#mQ2!vLp7")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, logs.String(), `"error":"unavailable"`)
+	assert.NotContains(t, logs.String(), "kX9#mQ2!vLp7")
+	checker.AssertExpectations(t)
+}
@@ -0,0 +1,349 @@
+package passwordpolicy
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Strength is an estimate of how many guesses an attacker who knows common passwords and patterns
+// needs, in the spirit of zxcvbn. Score ranges from 0 (trivial) to 4 (very hard to guess).
+type Strength struct {
+	Guesses  float64
+	Score    int
+	Feedback []string
+}
+
+type patternKind int
+
+const (
+	patternBruteforce patternKind = iota
+	patternDictionary
+	patternUserInput
+	patternRepeat
+	patternSequence
+	patternKeyboard
+	patternYear
+)
+
+var patternFeedback = map[patternKind]string{
+	patternDictionary: "avoid common words and passwords",
+	patternUserInput:  "avoid your username and email",
+	patternRepeat:     "avoid repeated characters",
+	patternSequence:   "avoid sequences like abc or 6543",
+	patternKeyboard:   "avoid keyboard patterns like qwerty",
+	patternYear:       "avoid years and dates",
+}
+
+type match struct {
+	start, end int
+	kind       patternKind
+	guesses    float64
+}
+
+// Score thresholds on guesses, as used by zxcvbn.
+var scoreThresholds = []float64{1e3, 1e6, 1e8, 1e10}
+
+// minSubmatchGuesses keeps short patterns from looking cheaper than guessing them character by character.
+const minSubmatchGuesses = 10
+
+// EstimateStrength finds the cheapest way to build the password from dictionary words, user inputs,
+// repeats, sequences, keyboard walks, years and brute-forced characters. Feedback is only given for
+// scores below 3.
+func EstimateStrength(password string, common *CommonPasswords, userInputs ...string) Strength {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return Strength{Guesses: 1, Feedback: []string{"use a longer password"}}
+	}
+
+	matches := findMatches(runes, common, userInputs)
+	cardinality := float64(charsetCardinality(runes))
+
+	// best[i] is the fewest guesses needed for the first i runes; via[i] the match ending there
+	best := make([]float64, len(runes)+1)
+	via := make([]*match, len(runes)+1)
+	for i := 1; i <= len(runes); i++ {
+		best[i] = math.Inf(1)
+	}
+	best[0] = 1
+	for i := 0; i < len(runes); i++ {
+		if guesses := best[i] * cardinality; guesses < best[i+1] {
+			best[i+1], via[i+1] = guesses, nil
+		}
+		for j := range matches {
+			m := &matches[j]
+			if m.start != i {
+				continue
+			}
+			if guesses := best[i] * m.guesses; guesses < best[m.end] {
+				best[m.end], via[m.end] = guesses, m
+			}
+		}
+	}
+
+	guesses := best[len(runes)]
+	strength := Strength{Guesses: guesses}
+	for _, threshold := range scoreThresholds {
+		if guesses < threshold {
+			break
+		}
+		strength.Score++
+	}
+
+	if strength.Score >= 3 {
+		return strength
+	}
+
+	var path []*match
+	for i := len(runes); i > 0; {
+		if via[i] == nil {
+			i--
+			continue
+		}
+		path = append(path, via[i])
+		i = via[i].start
+	}
+	seen := map[patternKind]bool{}
+	for i := len(path) - 1; i >= 0; i-- {
+		if !seen[path[i].kind] {
+			seen[path[i].kind] = true
+			strength.Feedback = append(strength.Feedback, patternFeedback[path[i].kind])
+		}
+	}
+	if len(strength.Feedback) == 0 {
+		strength.Feedback = append(strength.Feedback, "use a longer password with a mix of characters")
+	}
+
+	return strength
+}
+
+func findMatches(runes []rune, common *CommonPasswords, userInputs []string) []match {
+	lowered := make([]rune, len(runes))
+	for i, r := range runes {
+		lowered[i] = unicode.ToLower(r)
+	}
+
+	var matches []match
+	matches = append(matches, dictionaryMatches(runes, lowered, common)...)
+	matches = append(matches, userInputMatches(lowered, userInputs)...)
+	matches = append(matches, repeatMatches(lowered)...)
+	matches = append(matches, sequenceMatches(lowered)...)
+	matches = append(matches, keyboardMatches(lowered)...)
+	matches = append(matches, yearMatches(lowered)...)
+	return matches
+}
+
+func dictionaryMatches(runes []rune, lowered []rune, common *CommonPasswords) []match {
+	var matches []match
+	for i := 0; i < len(lowered); i++ {
+		for j := i + 3; j <= len(lowered); j++ {
+			word := string(lowered[i:j])
+			variants := unleet(word)
+			for v, variant := range variants {
+				rank := common.rank(variant)
+				if rank == 0 {
+					rank = common.rank(reverse(variant))
+					if rank != 0 {
+						rank *= 2
+					}
+				}
+				if rank == 0 {
+					continue
+				}
+				guesses := float64(rank) * uppercaseVariations(runes[i:j])
+				if v > 0 {
+					guesses *= 2
+				}
+				matches = append(matches, match{i, j, patternDictionary, math.Max(guesses, minSubmatchGuesses)})
+				break
+			}
+		}
+	}
+	return matches
+}
+
+func userInputMatches(lowered []rune, userInputs []string) []match {
+	var matches []match
+	password := string(lowered)
+	for rank, input := range userInputs {
+		for _, candidate := range userInputVariants(input) {
+			if len([]rune(candidate)) < MinUserInputLength {
+				continue
+			}
+			for offset := 0; ; {
+				index := strings.Index(password[offset:], candidate)
+				if index < 0 {
+					break
+				}
+				start := len([]rune(password[:offset+index]))
+				matches = append(matches, match{start, start + len([]rune(candidate)), patternUserInput, float64(rank+1) * minSubmatchGuesses})
+				offset += index + len(candidate)
+			}
+		}
+	}
+	return matches
+}
+
+func repeatMatches(lowered []rune) []match {
+	var matches []match
+	for i := 0; i < len(lowered); {
+		j := i + 1
+		for j < len(lowered) && lowered[j] == lowered[i] {
+			j++
+		}
+		if j-i >= 3 {
+			matches = append(matches, match{i, j, patternRepeat, float64(charsetCardinality(lowered[i:i+1]) * (j - i))})
+		}
+		i = j
+	}
+	return matches
+}
+
+func sequenceMatches(lowered []rune) []match {
+	var matches []match
+	for i := 0; i+2 < len(lowered); {
+		delta := lowered[i+1] - lowered[i]
+		if delta != 1 && delta != -1 || !sameClass(lowered[i], lowered[i+1]) {
+			i++
+			continue
+		}
+		j := i + 2
+		for j < len(lowered) && lowered[j]-lowered[j-1] == delta && sameClass(lowered[j], lowered[i]) {
+			j++
+		}
+		if j-i >= 3 {
+			base := 26.0
+			switch {
+			case strings.ContainsRune("aAzZ019", lowered[i]):
+				base = 4
+			case unicode.IsDigit(lowered[i]):
+				base = 10
+			}
+			if delta < 0 {
+				base *= 2
+			}
+			matches = append(matches, match{i, j, patternSequence, base * float64(j-i)})
+			i = j
+			continue
+		}
+		i++
+	}
+	return matches
+}
+
+var keyboardRows = []string{"1234567890-=", "qwertyuiop[]", "asdfghjkl;'", "zxcvbnm,./", "qaz", "wsx", "edc", "rfv", "tgb", "yhn", "ujm", "zaq", "xsw", "cde", "vfr"}
+
+func keyboardMatches(lowered []rune) []match {
+	var matches []match
+	for i := 0; i < len(lowered); i++ {
+		// Only the longest walk starting at each position is kept
+		for j := len(lowered); j >= i+3; j-- {
+			if isKeyboardWalk(string(lowered[i:j])) {
+				matches = append(matches, match{i, j, patternKeyboard, 40 * float64(j-i)})
+				break
+			}
+		}
+	}
+	return matches
+}
+
+func isKeyboardWalk(walk string) bool {
+	for _, row := range keyboardRows {
+		if strings.Contains(row, walk) || strings.Contains(row, reverse(walk)) {
+			return true
+		}
+	}
+	return false
+}
+
+func yearMatches(lowered []rune) []match {
+	var matches []match
+	for i := 0; i+4 <= len(lowered); i++ {
+		year := string(lowered[i : i+4])
+		if (strings.HasPrefix(year, "19") || strings.HasPrefix(year, "20")) && isDigits(year) {
+			matches = append(matches, match{i, i + 4, patternYear, 50})
+		}
+	}
+	return matches
+}
+
+// uppercaseVariations counts the capitalisations an attacker tries for a word, as zxcvbn does.
+func uppercaseVariations(word []rune) float64 {
+	upper, lower := 0, 0
+	for _, r := range word {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if upper == 0 || lower == 0 || (upper == 1 && (unicode.IsUpper(word[0]) || unicode.IsUpper(word[len(word)-1]))) {
+		if upper == 0 {
+			return 1
+		}
+		return 2
+	}
+	variations := 0.0
+	for k := 1; k <= int(math.Min(float64(upper), float64(lower))); k++ {
+		variations += binomial(upper+lower, k)
+	}
+	return variations
+}
+
+func charsetCardinality(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < 0x80:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	cardinality := 0
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.present {
+			cardinality += class.size
+		}
+	}
+	return cardinality
+}
+
+func sameClass(a, b rune) bool {
+	return unicode.IsDigit(a) == unicode.IsDigit(b) && unicode.IsLetter(a) == unicode.IsLetter(b)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+func binomial(n, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
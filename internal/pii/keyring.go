@@ -0,0 +1,276 @@
+package pii
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/secretbox"
+)
+
+// IndexKeyID is the fixed id of the blind index key, so that instances starting at the same time
+// cannot create two of them.
+const IndexKeyID = "index"
+
+// encryptedPrefix marks encrypted field values. Values without it are plaintext written before
+// encryption was enabled.
+const encryptedPrefix = "pii:"
+
+var (
+	// ErrNotLoaded is returned when the keyring is used before Load.
+	ErrNotLoaded = errors.New("pii: keyring is not loaded")
+	// ErrUnknownKey is returned for a value encrypted under a data key the keyring does not have.
+	ErrUnknownKey = errors.New("pii: value is encrypted under an unknown key")
+	// ErrDecrypt is returned for an encrypted value that is malformed or has been tampered with.
+	ErrDecrypt = errors.New("pii: cannot decrypt value")
+)
+
+// Keyring holds the unwrapped data keys. It encrypts PII fields under the newest encryption key,
+// decrypts values under any of them, and computes blind indexes for equality lookups.
+type Keyring struct {
+	Keys   repository.IDataKeyRepository
+	Master *MasterKey
+	// Previous master keys only unwrap data keys, which Load then rewraps with Master
+	Previous []*MasterKey
+	Now      func() time.Time
+
+	mu          sync.RWMutex
+	boxes       map[string]*secretbox.Box
+	active      string
+	activeSince time.Time
+	index       []byte
+}
+
+// NewKeyring creates a new instance of Keyring. It must be loaded before use.
+func NewKeyring(keys repository.IDataKeyRepository, master *MasterKey, previous ...*MasterKey) *Keyring {
+	return &Keyring{
+		Keys:     keys,
+		Master:   master,
+		Previous: previous,
+		Now:      time.Now,
+	}
+}
+
+// Load reads the data keys from the store, creating the first encryption and index keys when there
+// are none. It can be called again to pick up keys added by other instances.
+func (k *Keyring) Load(ctx context.Context) error {
+	keys, err := k.Keys.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	hasEncryptionKey, hasIndexKey := false, false
+	for _, key := range keys {
+		hasEncryptionKey = hasEncryptionKey || key.Purpose == model.DataKeyEncryption
+		hasIndexKey = hasIndexKey || key.Purpose == model.DataKeyIndex
+	}
+	if !hasIndexKey {
+		if _, err := k.addKey(ctx, IndexKeyID, model.DataKeyIndex); err != nil && !isConflict(err) {
+			return err
+		}
+	}
+	if !hasEncryptionKey {
+		if _, err := k.addKey(ctx, "", model.DataKeyEncryption); err != nil {
+			return err
+		}
+	}
+	if !hasIndexKey || !hasEncryptionKey {
+		if keys, err = k.Keys.List(ctx); err != nil {
+			return err
+		}
+	}
+
+	boxes := map[string]*secretbox.Box{}
+	var active *model.DataKey
+	var index []byte
+	for _, key := range keys {
+		dataKey, err := k.unwrap(ctx, key)
+		if err != nil {
+			return fmt.Errorf("data key %s: %w", key.ID, err)
+		}
+
+		switch key.Purpose {
+		case model.DataKeyIndex:
+			index = dataKey
+		case model.DataKeyEncryption:
+			box, err := secretbox.NewBox(dataKey)
+			if err != nil {
+				return err
+			}
+			boxes[key.ID] = box
+			// Instances that created keys concurrently agree on the newest, ties broken by id
+			if active == nil || key.CreatedAt.After(active.CreatedAt) || (key.CreatedAt.Equal(active.CreatedAt) && key.ID > active.ID) {
+				active = key
+			}
+		}
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.boxes = boxes
+	k.active = active.ID
+	k.activeSince = active.CreatedAt
+	k.index = index
+	return nil
+}
+
+// Rotate adds a new encryption key and makes it the active one. Values encrypted under older keys
+// stay readable until they are re-encrypted.
+func (k *Keyring) Rotate(ctx context.Context) (string, error) {
+	key, err := k.addKey(ctx, "", model.DataKeyEncryption)
+	if err != nil {
+		return "", err
+	}
+	if err := k.Load(ctx); err != nil {
+		return "", err
+	}
+	return key.ID, nil
+}
+
+// ActiveKeyID returns the id of the key new values are encrypted under.
+func (k *Keyring) ActiveKeyID() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.active
+}
+
+// ActiveSince returns when the active key was created.
+func (k *Keyring) ActiveSince() time.Time {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.activeSince
+}
+
+// Encrypt encrypts the value of a field under the active key. The field name is authenticated,
+// so a value cannot be moved to another field.
+func (k *Keyring) Encrypt(field string, plaintext string) (string, error) {
+	k.mu.RLock()
+	box, id := k.boxes[k.active], k.active
+	k.mu.RUnlock()
+	if box == nil {
+		return "", ErrNotLoaded
+	}
+
+	sealed, err := box.Seal([]byte(plaintext), []byte(field))
+	if err != nil {
+		return "", err
+	}
+	return encryptedPrefix + id + ":" + sealed, nil
+}
+
+// Decrypt decrypts a value produced by Encrypt. Plaintext values are returned unchanged.
+func (k *Keyring) Decrypt(field string, value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedPrefix) {
+		return value, nil
+	}
+	id, sealed, ok := strings.Cut(strings.TrimPrefix(value, encryptedPrefix), ":")
+	if !ok {
+		return "", ErrDecrypt
+	}
+
+	k.mu.RLock()
+	box := k.boxes[id]
+	k.mu.RUnlock()
+	if box == nil {
+		return "", ErrUnknownKey
+	}
+
+	plaintext, err := box.Open(sealed, []byte(field))
+	if err != nil {
+		return "", ErrDecrypt
+	}
+	return string(plaintext), nil
+}
+
+// BlindIndex returns a keyed hash of a field value. Equal values have equal indexes, so the index
+// supports unique constraints and exact lookups without revealing the value.
+func (k *Keyring) BlindIndex(field string, plaintext string) string {
+	k.mu.RLock()
+	index := k.index
+	k.mu.RUnlock()
+
+	mac := hmac.New(sha256.New, index)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write([]byte(plaintext))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// addKey generates, wraps and stores a data key. An empty id is replaced with a random one.
+func (k *Keyring) addKey(ctx context.Context, id string, purpose model.DataKeyPurpose) (*model.DataKey, error) {
+	if id == "" {
+		random := make([]byte, 8)
+		if _, err := rand.Read(random); err != nil {
+			return nil, err
+		}
+		id = hex.EncodeToString(random)
+	}
+
+	dataKey := make([]byte, secretbox.KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	wrapped, err := k.Master.Wrap(dataKey, id)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &model.DataKey{
+		ID:          id,
+		Purpose:     purpose,
+		WrappedKey:  wrapped,
+		MasterKeyID: k.Master.ID,
+		CreatedAt:   k.Now(),
+	}
+	if err := k.Keys.Add(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// unwrap unwraps a data key with the master key that wrapped it, rewrapping it with the current
+// master key if that was a previous one.
+func (k *Keyring) unwrap(ctx context.Context, key *model.DataKey) ([]byte, error) {
+	if key.MasterKeyID == k.Master.ID {
+		return k.Master.Unwrap(key.WrappedKey, key.ID)
+	}
+
+	for _, previous := range k.Previous {
+		if key.MasterKeyID != previous.ID {
+			continue
+		}
+		dataKey, err := previous.Unwrap(key.WrappedKey, key.ID)
+		if err != nil {
+			return nil, err
+		}
+		wrapped, err := k.Master.Wrap(dataKey, key.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := k.Keys.Rewrap(ctx, key.ID, wrapped, k.Master.ID); err != nil {
+			return nil, err
+		}
+		return dataKey, nil
+	}
+
+	return nil, ErrUnwrap
+}
+
+func isConflict(err error) bool {
+	var serviceError *common_error.ServiceError
+	return errors.As(err, &serviceError) && serviceError.Code == common_error.Conflict
+}
+
+// Ensure Keyring implements IFieldCipher
+var _ repository.IFieldCipher = (*Keyring)(nil)
@@ -0,0 +1,224 @@
+package pii_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/pii"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryKeys is an in-memory IDataKeyRepository.
+type memoryKeys struct {
+	keys []*model.DataKey
+}
+
+func (m *memoryKeys) List(ctx context.Context) ([]*model.DataKey, error) {
+	keys := make([]*model.DataKey, len(m.keys))
+	for i, key := range m.keys {
+		copied := *key
+		keys[i] = &copied
+	}
+	return keys, nil
+}
+
+func (m *memoryKeys) Add(ctx context.Context, key *model.DataKey) error {
+	for _, existing := range m.keys {
+		if existing.ID == key.ID {
+			return common_error.NewServiceError(common_error.Conflict, "Data key already exists", nil)
+		}
+	}
+	copied := *key
+	m.keys = append(m.keys, &copied)
+	return nil
+}
+
+func (m *memoryKeys) Rewrap(ctx context.Context, id string, wrappedKey string, masterKeyID string) error {
+	for _, key := range m.keys {
+		if key.ID == id {
+			key.WrappedKey = wrappedKey
+			key.MasterKeyID = masterKeyID
+		}
+	}
+	return nil
+}
+
+var _ repository.IDataKeyRepository = (*memoryKeys)(nil)
+
+func newTestKeyring(t *testing.T, keys *memoryKeys, secret string) *pii.Keyring {
+	master, err := pii.NewMasterKey(secret)
+	require.NoError(t, err)
+	return pii.NewKeyring(keys, master)
+}
+
+func TestKeyring_LoadCreatesKeys(t *testing.T) {
+	// Setup
+	keys := &memoryKeys{}
+	keyring := newTestKeyring(t, keys, "master")
+
+	// Test
+	err := keyring.Load(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, keys.keys, 2)
+	assert.Equal(t, pii.IndexKeyID, keys.keys[0].ID)
+	assert.Equal(t, model.DataKeyIndex, keys.keys[0].Purpose)
+	assert.Equal(t, model.DataKeyEncryption, keys.keys[1].Purpose)
+	assert.Equal(t, keys.keys[1].ID, keyring.ActiveKeyID())
+
+	// Loading again, as another instance would, reuses the stored keys
+	assert.NoError(t, newTestKeyring(t, keys, "master").Load(context.Background()))
+	assert.Len(t, keys.keys, 2)
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	// Setup
+	keyring := newTestKeyring(t, &memoryKeys{}, "master")
+	require.NoError(t, keyring.Load(context.Background()))
+
+	// Test
+	first, err := keyring.Encrypt("email", "test@mail.com")
+	require.NoError(t, err)
+	second, err := keyring.Encrypt("email", "test@mail.com")
+	require.NoError(t, err)
+	decrypted, err := keyring.Decrypt("email", first)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "test@mail.com", decrypted)
+	assert.NotContains(t, first, "test@mail.com")
+	assert.NotEqual(t, first, second)
+	assert.True(t, strings.HasPrefix(first, "pii:"+keyring.ActiveKeyID()+":"))
+
+	// A value cannot be moved to another field
+	_, err = keyring.Decrypt("profile.display_name", first)
+	assert.ErrorIs(t, err, pii.ErrDecrypt)
+}
+
+func TestKeyring_DecryptPlaintext(t *testing.T) {
+	// Setup
+	keyring := newTestKeyring(t, &memoryKeys{}, "master")
+	require.NoError(t, keyring.Load(context.Background()))
+
+	// Test
+	decrypted, err := keyring.Decrypt("email", "legacy@mail.com")
+	_, unknownErr := keyring.Decrypt("email", "pii:unknown:v1:AAAA")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy@mail.com", decrypted)
+	assert.ErrorIs(t, unknownErr, pii.ErrUnknownKey)
+}
+
+func TestKeyring_BlindIndex(t *testing.T) {
+	// Setup
+	keys := &memoryKeys{}
+	keyring := newTestKeyring(t, keys, "master")
+	require.NoError(t, keyring.Load(context.Background()))
+	other := newTestKeyring(t, keys, "master")
+	require.NoError(t, other.Load(context.Background()))
+
+	// Assert
+	index := keyring.BlindIndex("email", "test@mail.com")
+	assert.Equal(t, index, other.BlindIndex("email", "test@mail.com"))
+	assert.NotEqual(t, index, keyring.BlindIndex("email", "Test@mail.com"))
+	assert.NotEqual(t, index, keyring.BlindIndex("username", "test@mail.com"))
+	assert.NotContains(t, index, "test")
+}
+
+func TestKeyring_Rotate(t *testing.T) {
+	// Setup
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	keyring := newTestKeyring(t, &memoryKeys{}, "master")
+	keyring.Now = func() time.Time { return now }
+	require.NoError(t, keyring.Load(context.Background()))
+	previousKeyID := keyring.ActiveKeyID()
+	encrypted, err := keyring.Encrypt("email", "test@mail.com")
+	require.NoError(t, err)
+
+	// Test
+	now = now.Add(time.Hour)
+	keyID, err := keyring.Rotate(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEqual(t, previousKeyID, keyID)
+	assert.Equal(t, keyID, keyring.ActiveKeyID())
+	assert.Equal(t, now, keyring.ActiveSince())
+	decrypted, err := keyring.Decrypt("email", encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "test@mail.com", decrypted)
+}
+
+func TestKeyring_RewrapsWithNewMasterKey(t *testing.T) {
+	// Setup
+	keys := &memoryKeys{}
+	oldKeyring := newTestKeyring(t, keys, "old master")
+	require.NoError(t, oldKeyring.Load(context.Background()))
+	encrypted, err := oldKeyring.Encrypt("email", "test@mail.com")
+	require.NoError(t, err)
+
+	oldMaster, err := pii.NewMasterKey("old master")
+	require.NoError(t, err)
+	newMaster, err := pii.NewMasterKey("new master")
+	require.NoError(t, err)
+
+	// Test
+	keyring := pii.NewKeyring(keys, newMaster, oldMaster)
+	err = keyring.Load(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	decrypted, err := keyring.Decrypt("email", encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "test@mail.com", decrypted)
+	for _, key := range keys.keys {
+		assert.Equal(t, newMaster.ID, key.MasterKeyID)
+	}
+
+	// Without the previous master key the rewrapped keys still load
+	assert.NoError(t, pii.NewKeyring(keys, newMaster).Load(context.Background()))
+}
+
+func TestKeyring_WrongMasterKey(t *testing.T) {
+	// Setup
+	keys := &memoryKeys{}
+	require.NoError(t, newTestKeyring(t, keys, "master").Load(context.Background()))
+
+	// Test
+	err := newTestKeyring(t, keys, "other master").Load(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, err, pii.ErrUnwrap)
+}
+
+func TestLoadMasterKeyFile(t *testing.T) {
+	// Setup
+	path := filepath.Join(t.TempDir(), "master.key")
+	require.NoError(t, os.WriteFile(path, []byte("master\n"), 0o600))
+
+	// Test
+	fromFile, err := pii.LoadMasterKeyFile(path)
+	require.NoError(t, err)
+	fromSecret, err := pii.NewMasterKey("master")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, fromSecret.ID, fromFile.ID)
+	wrapped, err := fromFile.Wrap([]byte("data key"), "key-1")
+	require.NoError(t, err)
+	unwrapped, err := fromSecret.Unwrap(wrapped, "key-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data key"), unwrapped)
+	_, err = fromSecret.Unwrap(wrapped, "key-2")
+	assert.ErrorIs(t, err, pii.ErrUnwrap)
+}
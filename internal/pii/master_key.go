@@ -0,0 +1,60 @@
+package pii
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/secretbox"
+)
+
+// ErrUnwrap is returned when a data key was wrapped by another master key or has been tampered with.
+var ErrUnwrap = errors.New("pii: cannot unwrap data key")
+
+// MasterKey wraps data keys. It never encrypts user data itself, so replacing it only means
+// rewrapping the data keys, not re-encrypting every document.
+type MasterKey struct {
+	// ID identifies the key in stored data keys without revealing it
+	ID  string
+	box *secretbox.Box
+}
+
+// NewMasterKey creates a master key from a secret of any length, such as a value read from Vault.
+func NewMasterKey(secret string) (*MasterKey, error) {
+	key, err := secretbox.DeriveKey(secret, "user-service/pii-master-key")
+	if err != nil {
+		return nil, err
+	}
+	box, err := secretbox.NewBox(key)
+	if err != nil {
+		return nil, err
+	}
+
+	id := sha256.Sum256(append([]byte("key-id:"), key...))
+	return &MasterKey{ID: hex.EncodeToString(id[:8]), box: box}, nil
+}
+
+// LoadMasterKeyFile creates a master key from the contents of a file, for development setups without Vault.
+func LoadMasterKeyFile(path string) (*MasterKey, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewMasterKey(strings.TrimSpace(string(contents)))
+}
+
+// Wrap encrypts a data key, binding it to its id.
+func (k *MasterKey) Wrap(dataKey []byte, dataKeyID string) (string, error) {
+	return k.box.Seal(dataKey, []byte(dataKeyID))
+}
+
+// Unwrap decrypts a data key wrapped by Wrap.
+func (k *MasterKey) Unwrap(wrappedKey string, dataKeyID string) ([]byte, error) {
+	dataKey, err := k.box.Open(wrappedKey, []byte(dataKeyID))
+	if err != nil {
+		return nil, ErrUnwrap
+	}
+	return dataKey, nil
+}
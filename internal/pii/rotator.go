@@ -0,0 +1,77 @@
+package pii
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	DefaultReencryptBatchSize = 100
+	DefaultRotationInterval   = time.Minute
+	DefaultRotateAfter        = 90 * 24 * time.Hour
+)
+
+// IReencrypter re-encrypts stored PII under the active data key.
+type IReencrypter interface {
+	ReencryptPII(ctx context.Context, limit int64) (int, error)
+}
+
+// Rotator rotates the active data key once it is older than RotateAfter, and re-encrypts users
+// still encrypted under an older key, or not at all, one batch per interval.
+type Rotator struct {
+	Keyring     *Keyring
+	Users       IReencrypter
+	BatchSize   int64
+	Interval    time.Duration
+	RotateAfter time.Duration
+	Logger      *slog.Logger
+}
+
+// NewRotator creates a new instance of Rotator with default batching and rotation settings.
+func NewRotator(keyring *Keyring, users IReencrypter, logger *slog.Logger) *Rotator {
+	return &Rotator{
+		Keyring:     keyring,
+		Users:       users,
+		BatchSize:   DefaultReencryptBatchSize,
+		Interval:    DefaultRotationInterval,
+		RotateAfter: DefaultRotateAfter,
+		Logger:      logger,
+	}
+}
+
+// Run rotates and re-encrypts until the context is done.
+func (r *Rotator) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RotateOnce(ctx); err != nil {
+			r.Logger.ErrorContext(ctx, "PII key rotation failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RotateOnce reloads the keyring to pick up keys rotated by other instances, rotates the active key
+// if it is due, and re-encrypts one batch of users. It returns how many users were re-encrypted.
+func (r *Rotator) RotateOnce(ctx context.Context) (int, error) {
+	if err := r.Keyring.Load(ctx); err != nil {
+		return 0, err
+	}
+
+	if r.Keyring.Now().Sub(r.Keyring.ActiveSince()) >= r.RotateAfter {
+		keyID, err := r.Keyring.Rotate(ctx)
+		if err != nil {
+			return 0, err
+		}
+		r.Logger.InfoContext(ctx, "Rotated PII data key", "key_id", keyID)
+	}
+
+	return r.Users.ReencryptPII(ctx, r.BatchSize)
+}
@@ -0,0 +1,69 @@
+package pii_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/pii"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockIReencrypter struct {
+	mock.Mock
+}
+
+// ReencryptPII implements pii.IReencrypter.
+func (m *MockIReencrypter) ReencryptPII(ctx context.Context, limit int64) (int, error) {
+	args := m.Called(ctx, limit)
+	return args.Int(0), args.Error(1)
+}
+
+// Ensure that MockIReencrypter implements IReencrypter.
+var _ pii.IReencrypter = &MockIReencrypter{}
+
+func TestRotateOnce_NotDue(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	keyring := newTestKeyring(t, &memoryKeys{}, "master")
+	require.NoError(t, keyring.Load(ctx))
+	activeKeyID := keyring.ActiveKeyID()
+	users := new(MockIReencrypter)
+	users.On("ReencryptPII", ctx, int64(pii.DefaultReencryptBatchSize)).Return(3, nil)
+
+	// Test
+	reencrypted, err := pii.NewRotator(keyring, users, logging.NewLogger(io.Discard, slog.LevelInfo)).RotateOnce(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, reencrypted)
+	assert.Equal(t, activeKeyID, keyring.ActiveKeyID())
+	users.AssertExpectations(t)
+}
+
+func TestRotateOnce_Due(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	keyring := newTestKeyring(t, &memoryKeys{}, "master")
+	keyring.Now = func() time.Time { return now }
+	require.NoError(t, keyring.Load(ctx))
+	activeKeyID := keyring.ActiveKeyID()
+	users := new(MockIReencrypter)
+	users.On("ReencryptPII", ctx, int64(pii.DefaultReencryptBatchSize)).Return(0, nil)
+
+	// Test
+	now = now.Add(pii.DefaultRotateAfter)
+	_, err := pii.NewRotator(keyring, users, logging.NewLogger(io.Discard, slog.LevelInfo)).RotateOnce(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEqual(t, activeKeyID, keyring.ActiveKeyID())
+	assert.Equal(t, now, keyring.ActiveSince())
+	users.AssertExpectations(t)
+}
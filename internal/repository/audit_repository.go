@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditFilter narrows ListAuditEvents. Zero values leave the corresponding criterion out.
+type AuditFilter struct {
+	TenantID     string
+	TargetUserID string
+	From         time.Time
+	To           time.Time
+	Limit        int64
+}
+
+// IAuditRepository defines the interface for the append-only audit log.
+type IAuditRepository interface {
+	Append(ctx context.Context, event *model.AuditEvent) error
+	FindLatest(ctx context.Context) (*model.AuditEvent, error)
+	List(ctx context.Context, filter AuditFilter) ([]*model.AuditEvent, error)
+	Scrub(ctx context.Context, userID string, scrubbedAt time.Time) (int, error)
+}
+
+// MongoAuditRepository is an implementation of IAuditRepository using MongoDB.
+type MongoAuditRepository struct {
+	Collection IUserMongoAdapter
+}
+
+// NewAuditRepository creates a new instance of MongoAuditRepository.
+func NewAuditRepository(collection IUserMongoAdapter) *MongoAuditRepository {
+	return &MongoAuditRepository{
+		Collection: collection,
+	}
+}
+
+// Append implements IAuditRepository.
+// A duplicate sequence means another writer extended the chain first and is reported as a conflict.
+func (m *MongoAuditRepository) Append(ctx context.Context, event *model.AuditEvent) error {
+	_, err := m.Collection.InsertOne(ctx, event)
+
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return common_error.NewServiceError(common_error.Conflict, "Audit sequence taken", err)
+		}
+	}
+
+	return err
+}
+
+// FindLatest implements IAuditRepository. It returns nil without error when the log is empty.
+func (m *MongoAuditRepository) FindLatest(ctx context.Context) (*model.AuditEvent, error) {
+	var event model.AuditEvent
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "sequence", Value: -1}})
+	err := m.Collection.FindOne(ctx, bson.M{}, findOptions).Decode(&event)
+
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// List implements IAuditRepository. Events are returned in chain order.
+func (m *MongoAuditRepository) List(ctx context.Context, filter AuditFilter) ([]*model.AuditEvent, error) {
+	query := bson.M{}
+	if filter.TenantID != "" {
+		query["tenant_id"] = filter.TenantID
+	}
+	if filter.TargetUserID != "" {
+		query["target_user_id"] = filter.TargetUserID
+	}
+	createdAt := bson.M{}
+	if !filter.From.IsZero() {
+		createdAt["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		createdAt["$lt"] = filter.To
+	}
+	if len(createdAt) > 0 {
+		query["created_at"] = createdAt
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "sequence", Value: 1}})
+	if filter.Limit > 0 {
+		findOptions.SetLimit(filter.Limit)
+	}
+
+	cursor, err := m.Collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []*model.AuditEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Scrub implements IAuditRepository.
+// It removes the personal data of an erased user from the events they performed or were the target
+// of, as model.AuditEvent.Scrub describes, and returns how many events it changed.
+func (m *MongoAuditRepository) Scrub(ctx context.Context, userID string, scrubbedAt time.Time) (int, error) {
+	query := bson.M{
+		"$or":         bson.A{bson.M{"actor": userID}, bson.M{"target_user_id": userID}},
+		"scrubbed_at": bson.M{"$exists": false},
+	}
+	cursor, err := m.Collection.Find(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []*model.AuditEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return 0, err
+	}
+
+	scrubbed := 0
+	for _, event := range events {
+		if !event.Scrub(userID, scrubbedAt) {
+			continue
+		}
+
+		update := bson.M{
+			"$set":   bson.M{"source_ip": event.SourceIP, "changes": event.Changes, "scrubbed_at": event.ScrubbedAt},
+			"$unset": bson.M{"salt": ""},
+		}
+		if _, err := m.Collection.UpdateOne(ctx, bson.M{"_id": event.ID}, update); err != nil {
+			return scrubbed, err
+		}
+		scrubbed++
+	}
+
+	return scrubbed, nil
+}
+
+var _ IAuditRepository = (*MongoAuditRepository)(nil)
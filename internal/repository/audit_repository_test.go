@@ -0,0 +1,102 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestAppend_SequenceConflict(t *testing.T) {
+	ctx := context.Background()
+	event := &model.AuditEvent{Sequence: 1}
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("InsertOne", ctx, event).Return(&mongo.InsertOneResult{}, mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}})
+
+	repo := repository.NewAuditRepository(mockMongo)
+	err := repo.Append(ctx, event)
+
+	// Assertions
+	serviceError, ok := err.(*common_error.ServiceError)
+	assert.True(t, ok)
+	assert.Equal(t, common_error.Conflict, serviceError.Code)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestFindLatest_EmptyLog(t *testing.T) {
+	ctx := context.Background()
+
+	mockMongo := new(MockMongoOperations)
+	sr := mongo.NewSingleResultFromDocument(&model.AuditEvent{}, mongo.ErrNoDocuments, bson.DefaultRegistry)
+	mockMongo.On("FindOne", ctx, bson.M{}).Return(sr)
+
+	repo := repository.NewAuditRepository(mockMongo)
+	event, err := repo.FindLatest(ctx)
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Nil(t, event)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestListAuditEvents_Filter(t *testing.T) {
+	ctx := context.Background()
+	from := time.Now().Add(-time.Hour)
+	to := time.Now()
+
+	mockMongo := new(MockMongoOperations)
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{&model.AuditEvent{Sequence: 1}}, nil, bson.DefaultRegistry)
+	assert.Nil(t, err)
+	mockMongo.On("Find", ctx, bson.M{
+		"target_user_id": "target",
+		"created_at":     bson.M{"$gte": from, "$lt": to},
+	}).Return(cursor, nil)
+
+	repo := repository.NewAuditRepository(mockMongo)
+	events, err := repo.List(ctx, repository.AuditFilter{TargetUserID: "target", From: from, To: to})
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Len(t, events, 1)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestScrubAuditEvents(t *testing.T) {
+	ctx := context.Background()
+	scrubbedAt := time.Now()
+	legacy := &model.AuditEvent{ID: primitive.NewObjectID(), Sequence: 1, Actor: "user", TargetUserID: "user", SourceIP: "10.0.0.1",
+		Changes: []model.FieldChange{{Field: "email", New: "test@mail.com"}}}
+	lookup := &model.AuditEvent{ID: primitive.NewObjectID(), Sequence: 2, Actor: "admin", TargetUserID: "user", SourceIP: "10.0.0.2", HashVersion: model.AuditHashVersion}
+
+	mockMongo := new(MockMongoOperations)
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{legacy, lookup}, nil, bson.DefaultRegistry)
+	assert.Nil(t, err)
+	mockMongo.On("Find", ctx, bson.M{
+		"$or":         bson.A{bson.M{"actor": "user"}, bson.M{"target_user_id": "user"}},
+		"scrubbed_at": bson.M{"$exists": false},
+	}).Return(cursor, nil)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": legacy.ID}, mock.MatchedBy(func(update bson.M) bool {
+		set := update["$set"].(bson.M)
+		return set["source_ip"] == "" &&
+			assert.ObjectsAreEqual([]model.FieldChange{{Field: "email", New: model.RedactedValue}}, set["changes"]) &&
+			assert.ObjectsAreEqual(bson.M{"salt": ""}, update["$unset"])
+	})).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	repo := repository.NewAuditRepository(mockMongo)
+	scrubbed, err := repo.Scrub(ctx, "user", scrubbedAt)
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Equal(t, 1, scrubbed)
+	mockMongo.AssertExpectations(t)
+	mockMongo.AssertNumberOfCalls(t, "UpdateOne", 1)
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IDataKeyRepository defines the interface for data key storage. Keys are shared by every tenant.
+type IDataKeyRepository interface {
+	List(ctx context.Context) ([]*model.DataKey, error)
+	Add(ctx context.Context, key *model.DataKey) error
+	Rewrap(ctx context.Context, id string, wrappedKey string, masterKeyID string) error
+}
+
+// MongoDataKeyRepository is an implementation of IDataKeyRepository using MongoDB.
+type MongoDataKeyRepository struct {
+	Collection IUserMongoAdapter
+}
+
+// NewDataKeyRepository creates a new instance of MongoDataKeyRepository.
+func NewDataKeyRepository(collection IUserMongoAdapter) *MongoDataKeyRepository {
+	return &MongoDataKeyRepository{
+		Collection: collection,
+	}
+}
+
+// List implements IDataKeyRepository. Keys are returned oldest first.
+func (m *MongoDataKeyRepository) List(ctx context.Context) ([]*model.DataKey, error) {
+	cursor, err := m.Collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := []*model.DataKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// Add implements IDataKeyRepository.
+// Adding a key whose id is taken is reported as a conflict.
+func (m *MongoDataKeyRepository) Add(ctx context.Context, key *model.DataKey) error {
+	_, err := m.Collection.InsertOne(ctx, key)
+
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return common_error.NewServiceError(common_error.Conflict, "Data key already exists", err)
+		}
+	}
+
+	return err
+}
+
+// Rewrap implements IDataKeyRepository.
+func (m *MongoDataKeyRepository) Rewrap(ctx context.Context, id string, wrappedKey string, masterKeyID string) error {
+	_, err := m.Collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"wrapped_key": wrappedKey, "master_key_id": masterKeyID}})
+	return err
+}
+
+var _ IDataKeyRepository = (*MongoDataKeyRepository)(nil)
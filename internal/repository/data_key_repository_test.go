@@ -0,0 +1,63 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestListDataKeys(t *testing.T) {
+	ctx := context.Background()
+	key := &model.DataKey{ID: "key-1", Purpose: model.DataKeyEncryption, WrappedKey: "v1:wrapped", MasterKeyID: "master", CreatedAt: time.Now().UTC().Truncate(time.Millisecond)}
+
+	mockMongo := new(MockMongoOperations)
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{key}, nil, bson.DefaultRegistry)
+	assert.Nil(t, err)
+	mockMongo.On("Find", ctx, bson.M{}).Return(cursor, nil)
+
+	repo := repository.NewDataKeyRepository(mockMongo)
+	keys, err := repo.List(ctx)
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.DataKey{key}, keys)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestAddDataKey_Conflict(t *testing.T) {
+	ctx := context.Background()
+	key := &model.DataKey{ID: "index", Purpose: model.DataKeyIndex}
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("InsertOne", ctx, key).Return(&mongo.InsertOneResult{}, mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}})
+
+	repo := repository.NewDataKeyRepository(mockMongo)
+	err := repo.Add(ctx, key)
+
+	// Assertions
+	var serviceError *common_error.ServiceError
+	assert.ErrorAs(t, err, &serviceError)
+	assert.Equal(t, common_error.Conflict, serviceError.Code)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestRewrapDataKey(t *testing.T) {
+	ctx := context.Background()
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": "key-1"}, bson.M{"$set": bson.M{"wrapped_key": "v1:rewrapped", "master_key_id": "master-2"}}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	repo := repository.NewDataKeyRepository(mockMongo)
+	err := repo.Rewrap(ctx, "key-1", "v1:rewrapped", "master-2")
+
+	// Assertions
+	assert.Nil(t, err)
+	mockMongo.AssertExpectations(t)
+}
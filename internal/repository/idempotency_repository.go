@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// IIdempotencyRepository defines the interface for idempotency record operations.
+type IIdempotencyRepository interface {
+	Reserve(ctx context.Context, record *model.IdempotencyRecord) error
+	Find(ctx context.Context, caller string, key string) (*model.IdempotencyRecord, error)
+	TakeOver(ctx context.Context, record *model.IdempotencyRecord, pendingUntil time.Time) (bool, error)
+	Complete(ctx context.Context, caller string, key string, response json.RawMessage) error
+	Delete(ctx context.Context, caller string, key string) error
+}
+
+// MongoIdempotencyRepository is an implementation of IIdempotencyRepository using MongoDB.
+type MongoIdempotencyRepository struct {
+	Collection IUserMongoAdapter
+}
+
+// NewIdempotencyRepository creates a new instance of MongoIdempotencyRepository.
+func NewIdempotencyRepository(collection IUserMongoAdapter) *MongoIdempotencyRepository {
+	return &MongoIdempotencyRepository{
+		Collection: collection,
+	}
+}
+
+// Reserve implements IIdempotencyRepository.
+// Reserving a caller and key pair that is already stored is reported as a conflict.
+func (m *MongoIdempotencyRepository) Reserve(ctx context.Context, record *model.IdempotencyRecord) error {
+	_, err := m.Collection.InsertOne(ctx, record)
+
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return common_error.NewServiceError(common_error.Conflict, "Idempotency key already used", err)
+		}
+	}
+
+	return err
+}
+
+// Find implements IIdempotencyRepository.
+func (m *MongoIdempotencyRepository) Find(ctx context.Context, caller string, key string) (*model.IdempotencyRecord, error) {
+	var record model.IdempotencyRecord
+	err := m.Collection.FindOne(ctx, bson.M{"caller": caller, "key": key}).Decode(&record)
+
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, common_error.NewServiceError(common_error.NotFound, "Idempotency record not found", err)
+		} else {
+			return nil, err
+		}
+	}
+
+	return &record, nil
+}
+
+// TakeOver implements IIdempotencyRepository.
+// It extends the lease of a pending record to pendingUntil, provided that the record is still pending
+// under the lease it was read with, and reports whether it did. Of concurrent retries only one wins.
+func (m *MongoIdempotencyRepository) TakeOver(ctx context.Context, record *model.IdempotencyRecord, pendingUntil time.Time) (bool, error) {
+	filter := bson.M{"caller": record.Caller, "key": record.Key, "status": model.IdempotencyPending, "pending_until": record.PendingUntil}
+	if record.PendingUntil.IsZero() {
+		filter["pending_until"] = bson.M{"$exists": false}
+	}
+	result, err := m.Collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"pending_until": pendingUntil}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+// Complete implements IIdempotencyRepository.
+func (m *MongoIdempotencyRepository) Complete(ctx context.Context, caller string, key string, response json.RawMessage) error {
+	update := bson.M{
+		"$set":   bson.M{"status": model.IdempotencyCompleted, "response": response},
+		"$unset": bson.M{"pending_until": ""},
+	}
+	_, err := m.Collection.UpdateOne(ctx, bson.M{"caller": caller, "key": key}, update)
+	return err
+}
+
+// Delete implements IIdempotencyRepository.
+func (m *MongoIdempotencyRepository) Delete(ctx context.Context, caller string, key string) error {
+	_, err := m.Collection.DeleteOne(ctx, bson.M{"caller": caller, "key": key})
+	return err
+}
+
+var _ IIdempotencyRepository = (*MongoIdempotencyRepository)(nil)
@@ -0,0 +1,70 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestReserve_KeyTaken(t *testing.T) {
+	ctx := context.Background()
+	record := &model.IdempotencyRecord{Caller: "-1", Key: "key"}
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("InsertOne", ctx, record).Return(&mongo.InsertOneResult{}, mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}})
+
+	repo := repository.NewIdempotencyRepository(mockMongo)
+	err := repo.Reserve(ctx, record)
+
+	// Assertions
+	serviceError, ok := err.(*common_error.ServiceError)
+	assert.True(t, ok)
+	assert.Equal(t, common_error.Conflict, serviceError.Code)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestFindIdempotencyRecord(t *testing.T) {
+	ctx := context.Background()
+	expected := &model.IdempotencyRecord{Caller: "-1", Key: "key", Status: model.IdempotencyCompleted}
+
+	mockMongo := new(MockMongoOperations)
+	sr := mongo.NewSingleResultFromDocument(expected, nil, bson.DefaultRegistry)
+	mockMongo.On("FindOne", ctx, bson.M{"caller": "-1", "key": "key"}).Return(sr)
+
+	repo := repository.NewIdempotencyRepository(mockMongo)
+	record, err := repo.Find(ctx, "-1", "key")
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Equal(t, model.IdempotencyCompleted, record.Status)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestTakeOverIdempotencyRecord(t *testing.T) {
+	ctx := context.Background()
+	expired := time.Now().Add(-time.Second)
+	pendingUntil := time.Now().Add(time.Minute)
+	record := &model.IdempotencyRecord{Caller: "-1", Key: "key", Status: model.IdempotencyPending, PendingUntil: expired}
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("UpdateOne", ctx, bson.M{"caller": "-1", "key": "key", "status": model.IdempotencyPending, "pending_until": expired}, bson.M{"$set": bson.M{"pending_until": pendingUntil}}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+	mockMongo.On("UpdateOne", ctx, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, nil)
+
+	repo := repository.NewIdempotencyRepository(mockMongo)
+	taken, err := repo.TakeOver(ctx, record, pendingUntil)
+	retaken, retakeErr := repo.TakeOver(ctx, record, pendingUntil)
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.True(t, taken)
+	assert.Nil(t, retakeErr)
+	assert.False(t, retaken)
+}
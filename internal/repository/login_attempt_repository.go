@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LoginAttemptFilter narrows List. Zero values leave the corresponding criterion out.
+type LoginAttemptFilter struct {
+	UserID   string
+	SourceIP string
+	Results  []model.LoginResult
+	Since    time.Time
+	Limit    int64
+}
+
+// ILoginAttemptRepository defines the interface for login attempt tracking.
+type ILoginAttemptRepository interface {
+	Add(ctx context.Context, attempt *model.LoginAttempt) error
+	List(ctx context.Context, filter LoginAttemptFilter) ([]*model.LoginAttempt, error)
+	DeleteByUser(ctx context.Context, userID string) (int64, error)
+}
+
+// MongoLoginAttemptRepository is an implementation of ILoginAttemptRepository using MongoDB.
+type MongoLoginAttemptRepository struct {
+	Collection IUserMongoAdapter
+}
+
+// NewLoginAttemptRepository creates a new instance of MongoLoginAttemptRepository.
+func NewLoginAttemptRepository(collection IUserMongoAdapter) *MongoLoginAttemptRepository {
+	return &MongoLoginAttemptRepository{
+		Collection: collection,
+	}
+}
+
+// Add implements ILoginAttemptRepository.
+// The attempt is placed in the tenant of ctx unless it already names one.
+func (m *MongoLoginAttemptRepository) Add(ctx context.Context, attempt *model.LoginAttempt) error {
+	if attempt.TenantID == "" {
+		attempt.TenantID = tenant.FromContext(ctx)
+	}
+	_, err := m.Collection.InsertOne(ctx, attempt)
+	return err
+}
+
+// List implements ILoginAttemptRepository. Attempts are returned newest first.
+func (m *MongoLoginAttemptRepository) List(ctx context.Context, filter LoginAttemptFilter) ([]*model.LoginAttempt, error) {
+	query := bson.M{}
+	if filter.UserID != "" {
+		query["user_id"] = filter.UserID
+	}
+	if filter.SourceIP != "" {
+		query["source_ip"] = filter.SourceIP
+	}
+	if len(filter.Results) > 0 {
+		query["result"] = bson.M{"$in": filter.Results}
+	}
+	if !filter.Since.IsZero() {
+		query["created_at"] = bson.M{"$gte": filter.Since}
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if filter.Limit > 0 {
+		findOptions.SetLimit(filter.Limit)
+	}
+
+	cursor, err := m.Collection.Find(ctx, scoped(ctx, query), findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	attempts := []*model.LoginAttempt{}
+	if err := cursor.All(ctx, &attempts); err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// DeleteByUser implements ILoginAttemptRepository. It returns how many attempts were deleted.
+func (m *MongoLoginAttemptRepository) DeleteByUser(ctx context.Context, userID string) (int64, error) {
+	result, err := m.Collection.DeleteMany(ctx, scoped(ctx, bson.M{"user_id": userID}))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+var _ ILoginAttemptRepository = (*MongoLoginAttemptRepository)(nil)
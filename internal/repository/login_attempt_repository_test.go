@@ -0,0 +1,91 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestAddLoginAttempt_Tenant(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), "acme")
+	attempt := &model.LoginAttempt{UserID: "user", Result: model.LoginFailed}
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("InsertOne", ctx, attempt).Return(&mongo.InsertOneResult{}, nil)
+
+	repo := repository.NewLoginAttemptRepository(mockMongo)
+	err := repo.Add(ctx, attempt)
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Equal(t, "acme", attempt.TenantID)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestListLoginAttempts_Filter(t *testing.T) {
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+
+	mockMongo := new(MockMongoOperations)
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{&model.LoginAttempt{UserID: "user", Result: model.LoginFailed}}, nil, bson.DefaultRegistry)
+	assert.Nil(t, err)
+	mockMongo.On("Find", ctx, bson.M{
+		"user_id":    "user",
+		"result":     bson.M{"$in": []model.LoginResult{model.LoginFailed, model.LoginSucceeded}},
+		"created_at": bson.M{"$gte": since},
+		"tenant_id":  tenant.DefaultTenantID,
+	}).Return(cursor, nil)
+
+	repo := repository.NewLoginAttemptRepository(mockMongo)
+	attempts, err := repo.List(ctx, repository.LoginAttemptFilter{
+		UserID:  "user",
+		Results: []model.LoginResult{model.LoginFailed, model.LoginSucceeded},
+		Since:   since,
+		Limit:   10,
+	})
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Len(t, attempts, 1)
+	assert.Equal(t, model.LoginFailed, attempts[0].Result)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestListLoginAttempts_AllTenants(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), tenant.AllTenants)
+
+	mockMongo := new(MockMongoOperations)
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{}, nil, bson.DefaultRegistry)
+	assert.Nil(t, err)
+	mockMongo.On("Find", ctx, bson.M{"source_ip": "10.0.0.1"}).Return(cursor, nil)
+
+	repo := repository.NewLoginAttemptRepository(mockMongo)
+	attempts, err := repo.List(ctx, repository.LoginAttemptFilter{SourceIP: "10.0.0.1"})
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Empty(t, attempts)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestDeleteLoginAttemptsByUser(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), "acme")
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("DeleteMany", ctx, bson.M{"user_id": "user", "tenant_id": "acme"}).Return(&mongo.DeleteResult{DeletedCount: 3}, nil)
+
+	repo := repository.NewLoginAttemptRepository(mockMongo)
+	deleted, err := repo.DeleteByUser(ctx, "user")
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), deleted)
+	mockMongo.AssertExpectations(t)
+}
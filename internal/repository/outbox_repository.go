@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IOutboxRepository defines the interface for outbox operations.
+type IOutboxRepository interface {
+	Add(ctx context.Context, event *model.OutboxEvent) error
+	FindPending(ctx context.Context, now time.Time, limit int64) ([]*model.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id primitive.ObjectID, publishedAt time.Time) error
+	MarkFailed(ctx context.Context, id primitive.ObjectID, reason string, nextAttemptAt time.Time) error
+}
+
+// MongoOutboxRepository is an implementation of IOutboxRepository using MongoDB.
+type MongoOutboxRepository struct {
+	Collection IUserMongoAdapter
+}
+
+// NewOutboxRepository creates a new instance of MongoOutboxRepository.
+func NewOutboxRepository(collection IUserMongoAdapter) *MongoOutboxRepository {
+	return &MongoOutboxRepository{
+		Collection: collection,
+	}
+}
+
+// Add implements IOutboxRepository.
+func (m *MongoOutboxRepository) Add(ctx context.Context, event *model.OutboxEvent) error {
+	_, err := m.Collection.InsertOne(ctx, event)
+	return err
+}
+
+// FindPending implements IOutboxRepository.
+func (m *MongoOutboxRepository) FindPending(ctx context.Context, now time.Time, limit int64) ([]*model.OutboxEvent, error) {
+	filter := bson.M{"published_at": nil, "next_attempt_at": bson.M{"$lte": now}}
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetLimit(limit)
+	cursor, err := m.Collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []*model.OutboxEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// MarkPublished implements IOutboxRepository.
+func (m *MongoOutboxRepository) MarkPublished(ctx context.Context, id primitive.ObjectID, publishedAt time.Time) error {
+	update := bson.M{"$set": bson.M{"published_at": publishedAt}, "$inc": bson.M{"attempts": 1}}
+	_, err := m.Collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// MarkFailed implements IOutboxRepository.
+func (m *MongoOutboxRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, reason string, nextAttemptAt time.Time) error {
+	update := bson.M{
+		"$set": bson.M{"last_error": reason, "next_attempt_at": nextAttemptAt},
+		"$inc": bson.M{"attempts": 1},
+	}
+	_, err := m.Collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+var _ IOutboxRepository = (*MongoOutboxRepository)(nil)
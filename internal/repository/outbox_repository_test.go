@@ -0,0 +1,58 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestFindPending(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	mockMongo := new(MockMongoOperations)
+
+	expectedEvent := &model.OutboxEvent{
+		ID:   primitive.NewObjectID(),
+		Type: model.UserCreatedEvent,
+	}
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{expectedEvent}, nil, bson.DefaultRegistry)
+	assert.Nil(t, err)
+	mockMongo.On("Find", ctx, bson.M{"published_at": nil, "next_attempt_at": bson.M{"$lte": now}}).Return(cursor, nil)
+
+	repo := repository.NewOutboxRepository(mockMongo)
+	events, err := repo.FindPending(ctx, now, 10)
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, expectedEvent.ID, events[0].ID)
+
+	mockMongo.AssertExpectations(t)
+}
+
+func TestMarkFailed(t *testing.T) {
+	ctx := context.Background()
+	id := primitive.NewObjectID()
+	next := time.Now().Add(time.Minute)
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"last_error": "boom", "next_attempt_at": next},
+		"$inc": bson.M{"attempts": 1},
+	}).Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil)
+
+	repo := repository.NewOutboxRepository(mockMongo)
+	err := repo.MarkFailed(ctx, id, "boom", next)
+
+	// Assertions
+	assert.Nil(t, err)
+	mockMongo.AssertExpectations(t)
+}
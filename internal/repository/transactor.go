@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ITransactor runs a function inside a database transaction. Repository calls made with the
+// context passed to fn take part in the transaction.
+type ITransactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// MongoTransactor is an implementation of ITransactor using MongoDB sessions.
+// Transactions require the server to run as a replica set.
+type MongoTransactor struct {
+	Client *mongo.Client
+}
+
+// NewTransactor creates a new instance of MongoTransactor.
+func NewTransactor(client *mongo.Client) *MongoTransactor {
+	return &MongoTransactor{
+		Client: client,
+	}
+}
+
+// WithTransaction implements ITransactor.
+func (t *MongoTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := t.Client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessionContext)
+	})
+	return err
+}
+
+var _ ITransactor = (*MongoTransactor)(nil)
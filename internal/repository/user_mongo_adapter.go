@@ -12,7 +12,9 @@ type IUserMongoAdapter interface {
 	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
 	UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
 	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error)
 	// Add other methods if needed
 }
 
@@ -26,9 +28,19 @@ func (m *UserMongoAdapter) DeleteOne(ctx context.Context, filter interface{}, op
 	return m.Adapter.DeleteOne(ctx, filter, opts...)
 }
 
+// DeleteMany implements IUserMongoAdapter.
+func (m *UserMongoAdapter) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return m.Adapter.DeleteMany(ctx, filter, opts...)
+}
+
 // FindOne implements IUserMongoAdapter.
 func (m *UserMongoAdapter) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
-	return m.Adapter.FindOne(ctx, filter)
+	return m.Adapter.FindOne(ctx, filter, opts...)
+}
+
+// Find implements IUserMongoAdapter.
+func (m *UserMongoAdapter) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	return m.Adapter.Find(ctx, filter, opts...)
 }
 
 // InsertOne implements IUserMongoAdapter.
@@ -31,6 +31,16 @@ func (m *MockMongoAdapter) DeleteOne(ctx context.Context, filter interface{}, op
 	return args.Get(0).(*mongo.DeleteResult), args.Error(1)
 }
 
+func (m *MockMongoAdapter) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(*mongo.DeleteResult), args.Error(1)
+}
+
+func (m *MockMongoAdapter) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(*mongo.Cursor), args.Error(1)
+}
+
 func (m *MockMongoAdapter) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
 	args := m.Called(ctx, filter)
 	return args.Get(0).(*mongo.SingleResult)
@@ -154,3 +164,27 @@ func TestFindOne_Success(t *testing.T) {
 
 	// Additional assertions as needed
 }
+
+func TestFind_Success(t *testing.T) {
+	// Create an instance of the mock adapter
+	mockAdapter := new(MockMongoAdapter)
+
+	// Define the expected behavior of the mock Find method
+	mockCursor := &mongo.Cursor{}
+	mockAdapter.On("Find", mock.Anything, mock.Anything).Return(mockCursor, nil)
+
+	// Create an instance of the UserMongoAdapter with the mock adapter
+	userAdapter := repository.NewMongoAdapter(mockAdapter)
+
+	// Call the Find method in your adapter
+	ctx := context.TODO()
+	filter := bson.M{"_id": bson.M{"$in": []string{"some_id"}}}
+	cursor, err := userAdapter.Find(ctx, filter)
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Equal(t, mockCursor, cursor)
+
+	// Assert that the mock Find method was called with the expected arguments
+	mockAdapter.AssertCalled(t, "Find", ctx, filter)
+}
@@ -7,9 +7,17 @@ import (
 
 	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Names of the encrypted fields, which are authenticated along with their values.
+const (
+	piiFieldEmail       = "email"
+	piiFieldDisplayName = "profile.display_name"
 )
 
 // IUserRepository defines the interface for user repository operations.
@@ -17,14 +25,36 @@ type IUserRepository interface {
 	FindById(ctx context.Context, id string) (*model.PrivateUserModel, error)
 	FindByEmail(ctx context.Context, email string) (*model.PrivateUserModel, error)
 	FindByUsername(ctx context.Context, username string) (*model.PrivateUserModel, error)
+	FindByIds(ctx context.Context, ids []string) ([]*model.PrivateUserModel, error)
+	FindByEmails(ctx context.Context, emails []string) ([]*model.PrivateUserModel, error)
+	FindByUsernames(ctx context.Context, usernames []string) ([]*model.PrivateUserModel, error)
 	Create(ctx context.Context, user *model.PrivateUserModel) error
 	Update(ctx context.Context, user *model.PrivateUserModel) error
 	Delete(ctx context.Context, user *model.PrivateUserModel) error
+	SetLockedUntil(ctx context.Context, id string, until *time.Time) error
+	SetMFA(ctx context.Context, id string, enabled bool, state *model.MFAState) error
+	// AdvanceMFAStep and ConsumeRecoveryCode report false when a concurrent request used the code first.
+	AdvanceMFAStep(ctx context.Context, id string, step int64) (bool, error)
+	ConsumeRecoveryCode(ctx context.Context, id string, hash string) (bool, error)
+	// Erase replaces a user with the tombstone built by PrivateUserModel.Tombstone.
+	Erase(ctx context.Context, tombstone *model.PrivateUserModel) error
+}
+
+// IFieldCipher encrypts PII fields at rest. Decrypt returns values that were never encrypted unchanged,
+// so documents written before encryption was enabled stay readable.
+type IFieldCipher interface {
+	Encrypt(field string, plaintext string) (string, error)
+	Decrypt(field string, value string) (string, error)
+	// BlindIndex returns a deterministic keyed hash that backs unique indexes and exact lookups.
+	BlindIndex(field string, plaintext string) string
+	ActiveKeyID() string
 }
 
 // MongoUserRepository is an implementation of IUserRepository using MongoDB.
 type MongoUserRepository struct {
 	Collection IUserMongoAdapter
+	// Cipher, when set, encrypts the email and display name of users at rest
+	Cipher IFieldCipher
 }
 
 // NewUserRepository creates a new instance of MongoUserRepository.
@@ -35,10 +65,21 @@ func NewUserRepository(collection IUserMongoAdapter) *MongoUserRepository {
 }
 
 // Create implements IUserRepository.
+// The user is placed in the tenant of ctx unless it already names one.
 func (m *MongoUserRepository) Create(ctx context.Context, user *model.PrivateUserModel) error {
+	if user.TenantID == "" {
+		if tenant.IsAllTenants(ctx) {
+			return tenant.ErrTenantRequired
+		}
+		user.TenantID = tenant.FromContext(ctx)
+	}
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
-	_, err := m.Collection.InsertOne(ctx, user)
+	sealed, err := m.seal(user)
+	if err != nil {
+		return err
+	}
+	_, err = m.Collection.InsertOne(ctx, sealed)
 
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
@@ -51,7 +92,11 @@ func (m *MongoUserRepository) Create(ctx context.Context, user *model.PrivateUse
 
 // Delete implements IUserRepository.
 func (m *MongoUserRepository) Delete(ctx context.Context, user *model.PrivateUserModel) error {
-	_, err := m.Collection.DeleteOne(ctx, user)
+	if !tenant.IsAllTenants(ctx) && user.TenantID != tenant.FromContext(ctx) {
+		return common_error.NewServiceError(common_error.NotFound, "User not found", nil)
+	}
+
+	_, err := m.Collection.DeleteOne(ctx, bson.M{"_id": user.ID, "tenant_id": user.TenantID})
 
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
@@ -65,7 +110,7 @@ func (m *MongoUserRepository) Delete(ctx context.Context, user *model.PrivateUse
 // FindByEmail implements IUserRepository.
 func (m *MongoUserRepository) FindByEmail(ctx context.Context, email string) (*model.PrivateUserModel, error) {
 	var user model.PrivateUserModel
-	filter := bson.M{"email": email}
+	filter := scoped(ctx, m.emailFilter(email))
 	err := m.Collection.FindOne(ctx, filter).Decode(&user)
 
 	if err != nil {
@@ -76,7 +121,11 @@ func (m *MongoUserRepository) FindByEmail(ctx context.Context, email string) (*m
 		}
 	}
 
-	return &user, err
+	if err := m.open(&user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
 }
 
 // FindById implements IUserRepository.
@@ -86,7 +135,7 @@ func (m *MongoUserRepository) FindById(ctx context.Context, id string) (*model.P
 		return nil, err
 	}
 	var user model.PrivateUserModel
-	err = m.Collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&user)
+	err = m.Collection.FindOne(ctx, scoped(ctx, bson.M{"_id": objectID})).Decode(&user)
 
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
@@ -96,13 +145,17 @@ func (m *MongoUserRepository) FindById(ctx context.Context, id string) (*model.P
 		}
 	}
 
-	return &user, err
+	if err := m.open(&user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
 }
 
 // FindByUsername implements IUserRepository.
 func (m *MongoUserRepository) FindByUsername(ctx context.Context, username string) (*model.PrivateUserModel, error) {
 	var user model.PrivateUserModel
-	filter := bson.M{"username": username}
+	filter := scoped(ctx, bson.M{"username": username})
 	err := m.Collection.FindOne(ctx, filter).Decode(&user)
 
 	if err != nil {
@@ -113,18 +166,101 @@ func (m *MongoUserRepository) FindByUsername(ctx context.Context, username strin
 		}
 	}
 
-	return &user, err
+	if err := m.open(&user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// FindByIds implements IUserRepository.
+func (m *MongoUserRepository) FindByIds(ctx context.Context, ids []string) ([]*model.PrivateUserModel, error) {
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+
+	return m.findMany(ctx, bson.M{"_id": bson.M{"$in": objectIDs}})
+}
+
+// FindByEmails implements IUserRepository.
+func (m *MongoUserRepository) FindByEmails(ctx context.Context, emails []string) ([]*model.PrivateUserModel, error) {
+	if m.Cipher == nil {
+		return m.findMany(ctx, bson.M{"email": bson.M{"$in": emails}})
+	}
+
+	indexes := make([]string, len(emails))
+	for i, email := range emails {
+		indexes[i] = m.Cipher.BlindIndex(piiFieldEmail, email)
+	}
+	return m.findMany(ctx, bson.M{"$or": bson.A{
+		bson.M{"email_index": bson.M{"$in": indexes}},
+		bson.M{"email": bson.M{"$in": emails}},
+	}})
+}
+
+// FindByUsernames implements IUserRepository.
+func (m *MongoUserRepository) FindByUsernames(ctx context.Context, usernames []string) ([]*model.PrivateUserModel, error) {
+	return m.findMany(ctx, bson.M{"username": bson.M{"$in": usernames}})
+}
+
+// findMany decodes every user matching the filter. Missing users are not an error.
+func (m *MongoUserRepository) findMany(ctx context.Context, filter bson.M) ([]*model.PrivateUserModel, error) {
+	cursor, err := m.Collection.Find(ctx, scoped(ctx, filter))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	users := []*model.PrivateUserModel{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if err := m.open(user); err != nil {
+			return nil, err
+		}
+	}
+
+	return users, nil
 }
 
 // Update implements IUserRepository.
+// It sets only the account fields it owns, so a stale copy of the user cannot
+// write back lockout, MFA or role state that changed since it was read.
 func (m *MongoUserRepository) Update(ctx context.Context, user *model.PrivateUserModel) error {
 	user.UpdatedAt = time.Now()
-	filter := bson.M{"_id": user.ID}
-	update := bson.M{"$set": user}
-	_, err := m.Collection.UpdateOne(ctx, filter, update)
+	sealed, err := m.seal(user)
+	if err != nil {
+		return err
+	}
+	fields := bson.M{
+		"email":      sealed.Email,
+		"username":   sealed.Username,
+		"password":   sealed.Hash,
+		"updated_at": sealed.UpdatedAt,
+	}
+	if sealed.Profile != nil {
+		fields["profile"] = sealed.Profile
+	}
+	if sealed.EmailIndex != "" {
+		fields["email_index"] = sealed.EmailIndex
+	}
+	if sealed.PIIKeyID != "" {
+		fields["pii_key_id"] = sealed.PIIKeyID
+	}
+	filter := scoped(ctx, bson.M{"_id": user.ID})
+	update := bson.M{"$set": fields}
+	_, err = m.Collection.UpdateOne(ctx, filter, update)
 
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
+		if mongo.IsDuplicateKeyError(err) {
+			return common_error.NewServiceError(common_error.Conflict, "User update failed", err)
+		} else if errors.Is(err, mongo.ErrNoDocuments) {
 			return common_error.NewServiceError(common_error.NotFound, "User not found", err)
 		} else {
 			return err
@@ -134,4 +270,276 @@ func (m *MongoUserRepository) Update(ctx context.Context, user *model.PrivateUse
 	return err
 }
 
+// SetLockedUntil implements IUserRepository.
+// A nil until clears the lockout and leaves the rest of the document untouched.
+func (m *MongoUserRepository) SetLockedUntil(ctx context.Context, id string, until *time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$unset": bson.M{"locked_until": ""}}
+	if until != nil {
+		update = bson.M{"$set": bson.M{"locked_until": *until}}
+	}
+
+	result, err := m.Collection.UpdateOne(ctx, scoped(ctx, bson.M{"_id": objectID}), update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return common_error.NewServiceError(common_error.NotFound, "User not found", nil)
+	}
+
+	return nil
+}
+
+// SetMFA implements IUserRepository.
+// A nil state removes the enrolment, which also disables MFA.
+func (m *MongoUserRepository) SetMFA(ctx context.Context, id string, enabled bool, state *model.MFAState) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"mfa_enabled": false}, "$unset": bson.M{"mfa": ""}}
+	if state != nil {
+		update = bson.M{"$set": bson.M{"mfa_enabled": enabled, "mfa": state}}
+	}
+
+	result, err := m.Collection.UpdateOne(ctx, scoped(ctx, bson.M{"_id": objectID}), update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return common_error.NewServiceError(common_error.NotFound, "User not found", nil)
+	}
+
+	return nil
+}
+
+// AdvanceMFAStep implements IUserRepository.
+// The step only moves forward, so a TOTP code cannot be used twice even by concurrent requests.
+func (m *MongoUserRepository) AdvanceMFAStep(ctx context.Context, id string, step int64) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := scoped(ctx, bson.M{"_id": objectID, "mfa.last_step": bson.M{"$lt": step}})
+	result, err := m.Collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"mfa.last_step": step}})
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount == 1, nil
+}
+
+// ConsumeRecoveryCode implements IUserRepository.
+func (m *MongoUserRepository) ConsumeRecoveryCode(ctx context.Context, id string, hash string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := scoped(ctx, bson.M{"_id": objectID, "mfa.recovery_codes": hash})
+	result, err := m.Collection.UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"mfa.recovery_codes": hash}})
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount == 1, nil
+}
+
+// Erase implements IUserRepository.
+// Unlike Update it removes the fields the tombstone leaves out instead of keeping their stored values.
+func (m *MongoUserRepository) Erase(ctx context.Context, tombstone *model.PrivateUserModel) error {
+	sealed, err := m.seal(tombstone)
+	if err != nil {
+		return err
+	}
+
+	set := bson.M{
+		"email":               sealed.Email,
+		"password":            "",
+		"mfa_enabled":         false,
+		"erased_at":           tombstone.ErasedAt,
+		"erasure_ref":         tombstone.ErasureRef,
+		"username_held_until": tombstone.UsernameHeldUntil,
+		"updated_at":          tombstone.UpdatedAt,
+	}
+	unset := bson.M{"profile": "", "roles": "", "mfa": "", "locked_until": "", "email_index": "", "pii_key_id": ""}
+	if m.Cipher != nil {
+		set["email_index"], set["pii_key_id"] = sealed.EmailIndex, sealed.PIIKeyID
+		delete(unset, "email_index")
+		delete(unset, "pii_key_id")
+	}
+
+	result, err := m.Collection.UpdateOne(ctx, scoped(ctx, bson.M{"_id": tombstone.ID}), bson.M{"$set": set, "$unset": unset})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return common_error.NewServiceError(common_error.NotFound, "User not found", nil)
+	}
+
+	return nil
+}
+
+// ReleaseUsernames replaces the usernames of up to limit erased users whose hold period ended by
+// now, in every tenant, and returns how many were released.
+func (m *MongoUserRepository) ReleaseUsernames(ctx context.Context, now time.Time, limit int64) (int, error) {
+	due := bson.M{"username_held_until": bson.M{"$lte": now}}
+	cursor, err := m.Collection.Find(ctx, due, options.Find().SetLimit(limit))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	users := []*model.PrivateUserModel{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, user := range users {
+		filter := bson.M{"_id": user.ID, "username_held_until": due["username_held_until"]}
+		update := bson.M{"$set": bson.M{"username": model.ErasedUsername(user.ID)}, "$unset": bson.M{"username_held_until": ""}}
+		result, err := m.Collection.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return released, err
+		}
+		if result.MatchedCount == 1 {
+			released++
+		}
+	}
+
+	return released, nil
+}
+
+// ReencryptPII re-encrypts up to limit users whose PII is not encrypted under the active key, in
+// every tenant, and returns how many were updated. Users changed meanwhile are skipped, since
+// their update already encrypted them under the active key.
+func (m *MongoUserRepository) ReencryptPII(ctx context.Context, limit int64) (int, error) {
+	if m.Cipher == nil {
+		return 0, nil
+	}
+
+	active := m.Cipher.ActiveKeyID()
+	cursor, err := m.Collection.Find(ctx, bson.M{"pii_key_id": bson.M{"$ne": active}}, options.Find().SetLimit(limit))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	users := []*model.PrivateUserModel{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return 0, err
+	}
+
+	reencrypted := 0
+	for _, user := range users {
+		previousKeyID := user.PIIKeyID
+		if err := m.open(user); err != nil {
+			return reencrypted, err
+		}
+		sealed, err := m.seal(user)
+		if err != nil {
+			return reencrypted, err
+		}
+
+		filter := bson.M{"_id": user.ID, "pii_key_id": previousKeyID}
+		if previousKeyID == "" {
+			filter["pii_key_id"] = bson.M{"$exists": false}
+		}
+		set := bson.M{"email": sealed.Email, "email_index": sealed.EmailIndex, "pii_key_id": sealed.PIIKeyID}
+		if sealed.Profile != nil {
+			set["profile.display_name"] = sealed.Profile.DisplayName
+		}
+
+		result, err := m.Collection.UpdateOne(ctx, filter, bson.M{"$set": set})
+		if err != nil {
+			return reencrypted, err
+		}
+		if result.MatchedCount == 1 {
+			reencrypted++
+		}
+	}
+
+	return reencrypted, nil
+}
+
+// emailFilter matches a user by email. With a cipher, users already encrypted are matched by blind
+// index and users not yet encrypted by their plaintext email.
+func (m *MongoUserRepository) emailFilter(email string) bson.M {
+	if m.Cipher == nil {
+		return bson.M{"email": email}
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{"email_index": m.Cipher.BlindIndex(piiFieldEmail, email)},
+		bson.M{"email": email},
+	}}
+}
+
+// seal returns a copy of the user with its PII encrypted for storage. The user itself is unchanged.
+func (m *MongoUserRepository) seal(user *model.PrivateUserModel) (*model.PrivateUserModel, error) {
+	if m.Cipher == nil {
+		return user, nil
+	}
+
+	sealed := *user
+	email, err := m.Cipher.Encrypt(piiFieldEmail, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	sealed.Email = email
+	sealed.EmailIndex = m.Cipher.BlindIndex(piiFieldEmail, user.Email)
+
+	if user.Profile != nil && user.Profile.DisplayName != "" {
+		profile := *user.Profile
+		if profile.DisplayName, err = m.Cipher.Encrypt(piiFieldDisplayName, user.Profile.DisplayName); err != nil {
+			return nil, err
+		}
+		sealed.Profile = &profile
+	}
+
+	sealed.PIIKeyID = m.Cipher.ActiveKeyID()
+	return &sealed, nil
+}
+
+// open decrypts the PII of a user read from storage in place.
+func (m *MongoUserRepository) open(user *model.PrivateUserModel) error {
+	return OpenPII(m.Cipher, user)
+}
+
+// OpenPII decrypts the PII of a user document in place, for readers of the collection other than
+// the repository, such as change streams. A nil cipher leaves the user unchanged.
+func OpenPII(cipher IFieldCipher, user *model.PrivateUserModel) error {
+	if cipher == nil {
+		return nil
+	}
+
+	email, err := cipher.Decrypt(piiFieldEmail, user.Email)
+	if err != nil {
+		return err
+	}
+	user.Email = email
+
+	if user.Profile != nil && user.Profile.DisplayName != "" {
+		if user.Profile.DisplayName, err = cipher.Decrypt(piiFieldDisplayName, user.Profile.DisplayName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scoped restricts a filter to the tenant of ctx. In cross-tenant mode the filter is returned unchanged.
+func scoped(ctx context.Context, filter bson.M) bson.M {
+	if !tenant.IsAllTenants(ctx) {
+		filter["tenant_id"] = tenant.FromContext(ctx)
+	}
+	return filter
+}
+
 var _ IUserRepository = (*MongoUserRepository)(nil)
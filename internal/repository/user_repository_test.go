@@ -3,11 +3,15 @@ package repository_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson"
@@ -35,6 +39,16 @@ func (m *MockMongoOperations) DeleteOne(ctx context.Context, filter interface{},
 	return args.Get(0).(*mongo.DeleteResult), args.Error(1)
 }
 
+func (m *MockMongoOperations) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(*mongo.DeleteResult), args.Error(1)
+}
+
+func (m *MockMongoOperations) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(*mongo.Cursor), args.Error(1)
+}
+
 func (m *MockMongoOperations) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
 	args := m.Called(ctx, filter)
 	return args.Get(0).(*mongo.SingleResult)
@@ -125,7 +139,7 @@ func TestUpdateUser(t *testing.T) {
 	}
 
 	// Mock setup: expect UpdateOne() to be called with context and user, return mock result and nil error
-	mockMongo.On("UpdateOne", ctx, bson.M{"_id": user.ID}, mock.MatchedBy(func(u bson.M) bool {
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": user.ID, "tenant_id": tenant.DefaultTenantID}, mock.MatchedBy(func(u bson.M) bool {
 		return u != nil
 	})).Return(mockUpdateResult, nil)
 
@@ -142,6 +156,65 @@ func TestUpdateUser(t *testing.T) {
 	mockMongo.ExpectedCalls = nil
 }
 
+func TestUpdateUser_LeavesLockoutAndMFAState(t *testing.T) {
+	ctx := context.Background()
+	id := primitive.NewObjectID()
+	until := time.Now().Add(time.Minute)
+
+	// Setup
+	stale := &model.PrivateUserModel{
+		ID:       id,
+		Username: "test",
+		Email:    "test@mail.com",
+		Hash:     "test",
+		MFA:      &model.MFAState{Secret: "v1:sealed", LastStep: 3},
+	}
+	var update bson.M
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": id, "tenant_id": tenant.DefaultTenantID}, bson.M{"$set": bson.M{"locked_until": until}}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": id, "mfa.last_step": bson.M{"$lt": int64(7)}, "tenant_id": tenant.DefaultTenantID}, bson.M{"$set": bson.M{"mfa.last_step": int64(7)}}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": id, "tenant_id": tenant.DefaultTenantID}, mock.Anything).Run(func(args mock.Arguments) {
+		update = args.Get(2).(bson.M)
+	}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+	repo := repository.NewUserRepository(mockMongo)
+
+	// Test
+	assert.NoError(t, repo.SetLockedUntil(ctx, id.Hex(), &until))
+	advanced, err := repo.AdvanceMFAStep(ctx, id.Hex(), 7)
+	assert.NoError(t, err)
+	assert.True(t, advanced)
+	stale.Username = "renamed"
+	assert.NoError(t, repo.Update(ctx, stale))
+
+	// Assert
+	fields := update["$set"].(bson.M)
+	assert.Equal(t, "renamed", fields["username"])
+	for _, field := range []string{"locked_until", "mfa", "mfa.last_step", "mfa_enabled", "roles", "tenant_id", "created_at"} {
+		assert.NotContains(t, fields, field)
+	}
+	assert.NotContains(t, update, "$unset")
+	mockMongo.AssertExpectations(t)
+}
+
+func TestUpdateUser_Conflict(t *testing.T) {
+	mockMongo := new(MockMongoOperations)
+	repo := repository.NewUserRepository(mockMongo)
+	ctx := context.Background()
+	user := &model.PrivateUserModel{
+		ID:       primitive.NewObjectID(),
+		Username: "taken",
+	}
+
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": user.ID, "tenant_id": tenant.DefaultTenantID}, mock.Anything).Return(&mongo.UpdateResult{}, mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}})
+
+	err := repo.Update(ctx, user)
+
+	var serviceError *common_error.ServiceError
+	assert.ErrorAs(t, err, &serviceError)
+	assert.Equal(t, common_error.Conflict, serviceError.Code)
+	mockMongo.AssertExpectations(t)
+}
+
 func TestDeleteUser(t *testing.T) {
 	mockMongo := new(MockMongoOperations)
 	mockDeleteResult := &mongo.DeleteResult{
@@ -155,12 +228,11 @@ func TestDeleteUser(t *testing.T) {
 		Username: "test",
 		Email:    "test@mail.com",
 		Hash:     "test",
+		TenantID: tenant.DefaultTenantID,
 	}
 
-	// Mock setup: expect DeleteOne() to be called with context and user, return mock result and nil error
-	mockMongo.On("DeleteOne", ctx, mock.MatchedBy(func(u *model.PrivateUserModel) bool {
-		return u != nil && u.ID != primitive.NilObjectID
-	})).Return(mockDeleteResult, nil)
+	// Mock setup: expect DeleteOne() to be called with context and the id of the user, return mock result and nil error
+	mockMongo.On("DeleteOne", ctx, bson.M{"_id": user.ID, "tenant_id": tenant.DefaultTenantID}).Return(mockDeleteResult, nil)
 
 	err := repo.Delete(ctx, user)
 
@@ -189,7 +261,7 @@ func TestFindById(t *testing.T) {
 	sr := mongo.NewSingleResultFromDocument(expectedUser, nil, bson.DefaultRegistry)
 
 	// Setup mockMongo to return the real mongo.SingleResult
-	mockMongo.On("FindOne", ctx, bson.M{"_id": id}).Return(sr)
+	mockMongo.On("FindOne", ctx, bson.M{"_id": id, "tenant_id": tenant.DefaultTenantID}).Return(sr)
 
 	// Create userRepo with the mocked MongoDB operations
 	userRepo := repository.NewUserRepository(mockMongo)
@@ -214,7 +286,7 @@ func TestFindById_UserNotFound(t *testing.T) {
 	sr := mongo.NewSingleResultFromDocument(&model.PrivateUserModel{}, mongo.ErrNoDocuments, bson.DefaultRegistry)
 
 	// Setup mockMongo to return an empty result
-	mockMongo.On("FindOne", ctx, bson.M{"_id": id}).Return(sr)
+	mockMongo.On("FindOne", ctx, bson.M{"_id": id, "tenant_id": tenant.DefaultTenantID}).Return(sr)
 
 	// Create userRepo with the mocked MongoDB operations
 	userRepo := repository.NewUserRepository(mockMongo)
@@ -267,7 +339,7 @@ func TestFindById_UnknownError(t *testing.T) {
 	sr := mongo.NewSingleResultFromDocument(&model.PrivateUserModel{}, unknownError, bson.DefaultRegistry)
 
 	// Setup mockMongo to return an empty result
-	mockMongo.On("FindOne", ctx, bson.M{"_id": id}).Return(sr)
+	mockMongo.On("FindOne", ctx, bson.M{"_id": id, "tenant_id": tenant.DefaultTenantID}).Return(sr)
 
 	userRepo := repository.NewUserRepository(mockMongo)
 
@@ -297,7 +369,7 @@ func TestFindByUsername(t *testing.T) {
 	sr := mongo.NewSingleResultFromDocument(expectedUser, nil, bson.DefaultRegistry)
 
 	// Setup mockMongo to return the real mongo.SingleResult
-	mockMongo.On("FindOne", ctx, bson.M{"username": username}).Return(sr)
+	mockMongo.On("FindOne", ctx, bson.M{"username": username, "tenant_id": tenant.DefaultTenantID}).Return(sr)
 
 	repo := repository.NewUserRepository(mockMongo)
 	user, err := repo.FindByUsername(ctx, username)
@@ -321,7 +393,7 @@ func TestFindByUsername_UserNotFound(t *testing.T) {
 	sr := mongo.NewSingleResultFromDocument(&model.PrivateUserModel{}, mongo.ErrNoDocuments, bson.DefaultRegistry)
 
 	// Setup mockMongo to return an empty result
-	mockMongo.On("FindOne", ctx, bson.M{"username": username}).Return(sr)
+	mockMongo.On("FindOne", ctx, bson.M{"username": username, "tenant_id": tenant.DefaultTenantID}).Return(sr)
 
 	repo := repository.NewUserRepository(mockMongo)
 	user, err := repo.FindByUsername(ctx, username)
@@ -351,7 +423,7 @@ func TestFindByUsername_UnknownError(t *testing.T) {
 	sr := mongo.NewSingleResultFromDocument(&model.PrivateUserModel{}, unknownError, bson.DefaultRegistry)
 
 	// Setup mockMongo to return a result with an error
-	mockMongo.On("FindOne", ctx, bson.M{"username": username}).Return(sr)
+	mockMongo.On("FindOne", ctx, bson.M{"username": username, "tenant_id": tenant.DefaultTenantID}).Return(sr)
 
 	repo := repository.NewUserRepository(mockMongo)
 	user, err := repo.FindByUsername(ctx, username)
@@ -378,7 +450,7 @@ func TestFindByEmail(t *testing.T) {
 		Email: email,
 	}
 	sr := mongo.NewSingleResultFromDocument(expectedUser, nil, bson.DefaultRegistry)
-	mockMongo.On("FindOne", ctx, bson.M{"email": email}).Return(sr)
+	mockMongo.On("FindOne", ctx, bson.M{"email": email, "tenant_id": tenant.DefaultTenantID}).Return(sr)
 
 	repo := repository.NewUserRepository(mockMongo)
 	user, err := repo.FindByEmail(ctx, email)
@@ -398,7 +470,7 @@ func TestFindByEmail_UserNotFound(t *testing.T) {
 	mockMongo := new(MockMongoOperations)
 
 	sr := mongo.NewSingleResultFromDocument(&model.PrivateUserModel{}, mongo.ErrNoDocuments, bson.DefaultRegistry)
-	mockMongo.On("FindOne", ctx, bson.M{"email": email}).Return(sr)
+	mockMongo.On("FindOne", ctx, bson.M{"email": email, "tenant_id": tenant.DefaultTenantID}).Return(sr)
 
 	repo := repository.NewUserRepository(mockMongo)
 	user, err := repo.FindByEmail(ctx, email)
@@ -422,7 +494,7 @@ func TestFindByEmail_UnknownError(t *testing.T) {
 
 	unknownError := errors.New("unknown error")
 	sr := mongo.NewSingleResultFromDocument(&model.PrivateUserModel{}, unknownError, bson.DefaultRegistry)
-	mockMongo.On("FindOne", ctx, bson.M{"email": email}).Return(sr)
+	mockMongo.On("FindOne", ctx, bson.M{"email": email, "tenant_id": tenant.DefaultTenantID}).Return(sr)
 
 	repo := repository.NewUserRepository(mockMongo)
 	user, err := repo.FindByEmail(ctx, email)
@@ -435,3 +507,404 @@ func TestFindByEmail_UnknownError(t *testing.T) {
 	mockMongo.AssertExpectations(t)
 	mockMongo.ExpectedCalls = nil
 }
+
+func TestFindByEmails(t *testing.T) {
+	ctx := context.Background()
+	emails := []string{"test@mail.com", "missing@mail.com"}
+
+	mockMongo := new(MockMongoOperations)
+
+	expectedUser := &model.PrivateUserModel{
+		ID:    primitive.NewObjectID(),
+		Email: "test@mail.com",
+	}
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{expectedUser}, nil, bson.DefaultRegistry)
+	assert.Nil(t, err)
+	mockMongo.On("Find", ctx, bson.M{"email": bson.M{"$in": emails}, "tenant_id": tenant.DefaultTenantID}).Return(cursor, nil)
+
+	repo := repository.NewUserRepository(mockMongo)
+	users, err := repo.FindByEmails(ctx, emails)
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, expectedUser.ID, users[0].ID)
+
+	mockMongo.AssertExpectations(t)
+	mockMongo.ExpectedCalls = nil
+}
+
+func TestFindByIds_InvalidId(t *testing.T) {
+	ctx := context.Background()
+
+	mockMongo := new(MockMongoOperations)
+
+	repo := repository.NewUserRepository(mockMongo)
+	users, err := repo.FindByIds(ctx, []string{primitive.NewObjectID().Hex(), "invalid"})
+
+	// Assertions
+	assert.NotNil(t, err)
+	assert.Nil(t, users)
+
+	mockMongo.AssertExpectations(t)
+}
+
+func TestFindByUsernames_UnknownError(t *testing.T) {
+	ctx := context.Background()
+	usernames := []string{"testuser"}
+
+	mockMongo := new(MockMongoOperations)
+
+	unknownError := errors.New("unknown error")
+	mockMongo.On("Find", ctx, bson.M{"username": bson.M{"$in": usernames}, "tenant_id": tenant.DefaultTenantID}).Return((*mongo.Cursor)(nil), unknownError)
+
+	repo := repository.NewUserRepository(mockMongo)
+	users, err := repo.FindByUsernames(ctx, usernames)
+
+	// Assertions
+	assert.Equal(t, unknownError, err)
+	assert.Nil(t, users)
+
+	mockMongo.AssertExpectations(t)
+	mockMongo.ExpectedCalls = nil
+}
+
+func TestFindById_TenantScope(t *testing.T) {
+	mockMongo := new(MockMongoOperations)
+	repo := repository.NewUserRepository(mockMongo)
+	id := primitive.NewObjectID()
+	expectedUser := &model.PrivateUserModel{ID: id, TenantID: "acme"}
+
+	sr := mongo.NewSingleResultFromDocument(expectedUser, nil, nil)
+	mockMongo.On("FindOne", mock.Anything, bson.M{"_id": id, "tenant_id": "acme"}).Return(sr).Once()
+	user, err := repo.FindById(tenant.WithTenant(context.Background(), "acme"), id.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", user.TenantID)
+
+	// Cross-tenant mode drops the tenant filter
+	sr = mongo.NewSingleResultFromDocument(expectedUser, nil, nil)
+	mockMongo.On("FindOne", mock.Anything, bson.M{"_id": id}).Return(sr).Once()
+	_, err = repo.FindById(tenant.WithTenant(context.Background(), tenant.AllTenants), id.Hex())
+	assert.NoError(t, err)
+
+	mockMongo.AssertExpectations(t)
+}
+
+func TestCreateUser_Tenant(t *testing.T) {
+	mockMongo := new(MockMongoOperations)
+	repo := repository.NewUserRepository(mockMongo)
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test"}
+
+	mockMongo.On("InsertOne", mock.Anything, mock.Anything).Return(&mongo.InsertOneResult{}, nil)
+
+	err := repo.Create(tenant.WithTenant(context.Background(), "acme"), user)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", user.TenantID)
+
+	err = repo.Create(tenant.WithTenant(context.Background(), tenant.AllTenants), &model.PrivateUserModel{Username: "other"})
+	assert.ErrorIs(t, err, tenant.ErrTenantRequired)
+}
+
+func TestDeleteUser_OtherTenant(t *testing.T) {
+	mockMongo := new(MockMongoOperations)
+	repo := repository.NewUserRepository(mockMongo)
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID(), TenantID: "globex"}
+
+	err := repo.Delete(tenant.WithTenant(context.Background(), "acme"), user)
+
+	var serviceError *common_error.ServiceError
+	assert.ErrorAs(t, err, &serviceError)
+	assert.Equal(t, common_error.NotFound, serviceError.Code)
+	mockMongo.AssertNotCalled(t, "DeleteOne", mock.Anything, mock.Anything)
+}
+
+func TestSetLockedUntil(t *testing.T) {
+	ctx := context.Background()
+	id := primitive.NewObjectID()
+	until := time.Now().Add(time.Minute)
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": id, "tenant_id": tenant.DefaultTenantID}, bson.M{"$set": bson.M{"locked_until": until}}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": id, "tenant_id": tenant.DefaultTenantID}, bson.M{"$unset": bson.M{"locked_until": ""}}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	repo := repository.NewUserRepository(mockMongo)
+
+	// Assertions
+	assert.NoError(t, repo.SetLockedUntil(ctx, id.Hex(), &until))
+	assert.NoError(t, repo.SetLockedUntil(ctx, id.Hex(), nil))
+	mockMongo.AssertExpectations(t)
+}
+
+func TestSetLockedUntil_NotFound(t *testing.T) {
+	ctx := context.Background()
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("UpdateOne", ctx, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, nil)
+
+	repo := repository.NewUserRepository(mockMongo)
+	err := repo.SetLockedUntil(ctx, primitive.NewObjectID().Hex(), nil)
+
+	// Assertions
+	var serviceError *common_error.ServiceError
+	assert.ErrorAs(t, err, &serviceError)
+	assert.Equal(t, common_error.NotFound, serviceError.Code)
+}
+
+func TestSetMFA(t *testing.T) {
+	ctx := context.Background()
+	id := primitive.NewObjectID()
+	state := &model.MFAState{Secret: "v1:sealed", LastStep: 4}
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": id, "tenant_id": tenant.DefaultTenantID}, bson.M{"$set": bson.M{"mfa_enabled": true, "mfa": state}}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": id, "tenant_id": tenant.DefaultTenantID}, bson.M{"$set": bson.M{"mfa_enabled": false}, "$unset": bson.M{"mfa": ""}}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	repo := repository.NewUserRepository(mockMongo)
+
+	// Assertions
+	assert.NoError(t, repo.SetMFA(ctx, id.Hex(), true, state))
+	assert.NoError(t, repo.SetMFA(ctx, id.Hex(), false, nil))
+	mockMongo.AssertExpectations(t)
+}
+
+func TestAdvanceMFAStep(t *testing.T) {
+	ctx := context.Background()
+	id := primitive.NewObjectID()
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": id, "mfa.last_step": bson.M{"$lt": int64(7)}, "tenant_id": tenant.DefaultTenantID}, bson.M{"$set": bson.M{"mfa.last_step": int64(7)}}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+	mockMongo.On("UpdateOne", ctx, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, nil)
+
+	repo := repository.NewUserRepository(mockMongo)
+	advanced, err := repo.AdvanceMFAStep(ctx, id.Hex(), 7)
+	replayed, replayErr := repo.AdvanceMFAStep(ctx, id.Hex(), 7)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.True(t, advanced)
+	assert.NoError(t, replayErr)
+	assert.False(t, replayed)
+}
+
+func TestConsumeRecoveryCode(t *testing.T) {
+	ctx := context.Background()
+	id := primitive.NewObjectID()
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": id, "mfa.recovery_codes": "hash", "tenant_id": tenant.DefaultTenantID}, bson.M{"$pull": bson.M{"mfa.recovery_codes": "hash"}}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	repo := repository.NewUserRepository(mockMongo)
+	consumed, err := repo.ConsumeRecoveryCode(ctx, id.Hex(), "hash")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.True(t, consumed)
+	mockMongo.AssertExpectations(t)
+}
+
+// fakeCipher marks values instead of encrypting them, so tests can see what is stored.
+type fakeCipher struct{}
+
+func (fakeCipher) Encrypt(field string, plaintext string) (string, error) {
+	return "enc:" + field + ":" + plaintext, nil
+}
+
+func (fakeCipher) Decrypt(field string, value string) (string, error) {
+	return strings.TrimPrefix(value, "enc:"+field+":"), nil
+}
+
+func (fakeCipher) BlindIndex(field string, plaintext string) string {
+	return "idx:" + field + ":" + plaintext
+}
+
+func (fakeCipher) ActiveKeyID() string {
+	return "key-2"
+}
+
+func TestCreateUser_EncryptsPII(t *testing.T) {
+	ctx := context.Background()
+	user := &model.PrivateUserModel{
+		Username: "test",
+		Email:    "test@mail.com",
+		Profile:  &publicModel.ProfileModel{DisplayName: "Test User", Bio: "bio"},
+	}
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("InsertOne", ctx, mock.MatchedBy(func(u *model.PrivateUserModel) bool {
+		return u.Email == "enc:email:test@mail.com" &&
+			u.EmailIndex == "idx:email:test@mail.com" &&
+			u.Profile.DisplayName == "enc:profile.display_name:Test User" &&
+			u.Profile.Bio == "bio" &&
+			u.PIIKeyID == "key-2"
+	})).Return(&mongo.InsertOneResult{}, nil)
+
+	repo := repository.NewUserRepository(mockMongo)
+	repo.Cipher = fakeCipher{}
+	err := repo.Create(ctx, user)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "test@mail.com", user.Email)
+	assert.Equal(t, "Test User", user.Profile.DisplayName)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestFindByEmail_BlindIndex(t *testing.T) {
+	ctx := context.Background()
+	email := "test@mail.com"
+
+	stored := &model.PrivateUserModel{
+		Email:   "enc:email:" + email,
+		Profile: &publicModel.ProfileModel{DisplayName: "enc:profile.display_name:Test User"},
+	}
+	sr := mongo.NewSingleResultFromDocument(stored, nil, bson.DefaultRegistry)
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("FindOne", ctx, bson.M{
+		"$or": bson.A{
+			bson.M{"email_index": "idx:email:" + email},
+			bson.M{"email": email},
+		},
+		"tenant_id": tenant.DefaultTenantID,
+	}).Return(sr)
+
+	repo := repository.NewUserRepository(mockMongo)
+	repo.Cipher = fakeCipher{}
+	user, err := repo.FindByEmail(ctx, email)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, email, user.Email)
+	assert.Equal(t, "Test User", user.Profile.DisplayName)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestFindByEmails_BlindIndex(t *testing.T) {
+	ctx := context.Background()
+	emails := []string{"test@mail.com", "legacy@mail.com"}
+
+	documents := []interface{}{
+		&model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "enc:email:test@mail.com"},
+		&model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "legacy@mail.com"},
+	}
+	cursor, err := mongo.NewCursorFromDocuments(documents, nil, bson.DefaultRegistry)
+	assert.Nil(t, err)
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("Find", ctx, bson.M{
+		"$or": bson.A{
+			bson.M{"email_index": bson.M{"$in": []string{"idx:email:test@mail.com", "idx:email:legacy@mail.com"}}},
+			bson.M{"email": bson.M{"$in": emails}},
+		},
+		"tenant_id": tenant.DefaultTenantID,
+	}).Return(cursor, nil)
+
+	repo := repository.NewUserRepository(mockMongo)
+	repo.Cipher = fakeCipher{}
+	users, err := repo.FindByEmails(ctx, emails)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, "test@mail.com", users[0].Email)
+	assert.Equal(t, "legacy@mail.com", users[1].Email)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestReencryptPII(t *testing.T) {
+	ctx := context.Background()
+	rotated := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "enc:email:old@mail.com", PIIKeyID: "key-1"}
+	legacy := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "legacy@mail.com"}
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{rotated, legacy}, nil, bson.DefaultRegistry)
+	assert.Nil(t, err)
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("Find", ctx, bson.M{"pii_key_id": bson.M{"$ne": "key-2"}}).Return(cursor, nil)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": rotated.ID, "pii_key_id": "key-1"}, bson.M{"$set": bson.M{
+		"email":       "enc:email:old@mail.com",
+		"email_index": "idx:email:old@mail.com",
+		"pii_key_id":  "key-2",
+	}}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+	// The legacy user changed meanwhile, so its update matches nothing
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": legacy.ID, "pii_key_id": bson.M{"$exists": false}}, mock.Anything).Return(&mongo.UpdateResult{}, nil)
+
+	repo := repository.NewUserRepository(mockMongo)
+	repo.Cipher = fakeCipher{}
+	reencrypted, err := repo.ReencryptPII(ctx, 10)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, reencrypted)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestEraseUser(t *testing.T) {
+	ctx := context.Background()
+	erasedAt := time.Now()
+	heldUntil := erasedAt.Add(time.Hour)
+	tombstone := (&model.PrivateUserModel{ID: primitive.NewObjectID(), TenantID: tenant.DefaultTenantID, Email: "test@mail.com", Username: "test"}).Tombstone(erasedAt, heldUntil, []byte("erasure key"))
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": tombstone.ID, "tenant_id": tenant.DefaultTenantID}, bson.M{
+		"$set": bson.M{
+			"email":               tombstone.Email,
+			"password":            "",
+			"mfa_enabled":         false,
+			"erased_at":           tombstone.ErasedAt,
+			"erasure_ref":         tombstone.ErasureRef,
+			"username_held_until": tombstone.UsernameHeldUntil,
+			"updated_at":          tombstone.UpdatedAt,
+		},
+		"$unset": bson.M{"profile": "", "roles": "", "mfa": "", "locked_until": "", "email_index": "", "pii_key_id": ""},
+	}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	repo := repository.NewUserRepository(mockMongo)
+	err := repo.Erase(ctx, tombstone)
+
+	// Assertions
+	assert.NoError(t, err)
+	mockMongo.AssertExpectations(t)
+}
+
+func TestEraseUser_NotFound(t *testing.T) {
+	ctx := context.Background()
+	tombstone := (&model.PrivateUserModel{ID: primitive.NewObjectID()}).Tombstone(time.Now(), time.Now(), []byte("erasure key"))
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("UpdateOne", ctx, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, nil)
+
+	repo := repository.NewUserRepository(mockMongo)
+	err := repo.Erase(ctx, tombstone)
+
+	// Assertions
+	var serviceError *common_error.ServiceError
+	assert.ErrorAs(t, err, &serviceError)
+	assert.Equal(t, common_error.NotFound, serviceError.Code)
+}
+
+func TestReleaseUsernames(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	due := bson.M{"$lte": now}
+	released := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "released"}
+	reerased := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "reerased"}
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{released, reerased}, nil, bson.DefaultRegistry)
+	assert.Nil(t, err)
+
+	mockMongo := new(MockMongoOperations)
+	mockMongo.On("Find", ctx, bson.M{"username_held_until": due}).Return(cursor, nil)
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": released.ID, "username_held_until": due}, bson.M{
+		"$set":   bson.M{"username": model.ErasedUsername(released.ID)},
+		"$unset": bson.M{"username_held_until": ""},
+	}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+	// The hold was extended meanwhile, so the update matches nothing
+	mockMongo.On("UpdateOne", ctx, bson.M{"_id": reerased.ID, "username_held_until": due}, mock.Anything).Return(&mongo.UpdateResult{}, nil)
+
+	repo := repository.NewUserRepository(mockMongo)
+	count, err := repo.ReleaseUsernames(ctx, now, 10)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	mockMongo.AssertExpectations(t)
+}
@@ -0,0 +1,89 @@
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// KeySize is the length of the AES-256 keys a Box is created with.
+const KeySize = 32
+
+// sealedPrefix marks the format of sealed values so that it can change without ambiguity.
+const sealedPrefix = "v1:"
+
+var (
+	ErrInvalidKey = errors.New("secretbox: key must be 32 bytes")
+	// ErrOpen is returned for values that are malformed, were sealed with another key or were tampered with.
+	ErrOpen = errors.New("secretbox: cannot open sealed value")
+)
+
+// Box encrypts small values with AES-256-GCM. Sealed values are self-contained strings that
+// are safe to store in a document field.
+type Box struct {
+	aead cipher.AEAD
+}
+
+// NewBox creates a Box from a 32 byte key.
+func NewBox(key []byte) (*Box, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Box{aead: aead}, nil
+}
+
+// DeriveKey derives a key for one purpose from a secret of any length, such as a value read
+// from Vault. Different purposes yield unrelated keys from the same secret.
+func DeriveKey(secret string, purpose string) ([]byte, error) {
+	if secret == "" {
+		return nil, ErrInvalidKey
+	}
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, []byte(secret), nil, []byte(purpose)), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Seal encrypts plaintext. The associated data is authenticated but not stored; the same
+// value must be passed to Open, which binds the sealed value to, for example, a record id.
+func (b *Box) Seal(plaintext []byte, associatedData []byte) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := b.aead.Seal(nonce, nonce, plaintext, associatedData)
+	return sealedPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a value produced by Seal.
+func (b *Box) Open(sealed string, associatedData []byte) ([]byte, error) {
+	if !strings.HasPrefix(sealed, sealedPrefix) {
+		return nil, ErrOpen
+	}
+	raw, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(sealed, sealedPrefix))
+	if err != nil || len(raw) < b.aead.NonceSize()+b.aead.Overhead() {
+		return nil, ErrOpen
+	}
+	nonce, ciphertext := raw[:b.aead.NonceSize()], raw[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, ciphertext, associatedData)
+	if err != nil {
+		return nil, ErrOpen
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,75 @@
+package secretbox_test
+
+import (
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/secretbox"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBox(t *testing.T, purpose string) *secretbox.Box {
+	key, err := secretbox.DeriveKey("vault secret", purpose)
+	assert.NoError(t, err)
+	box, err := secretbox.NewBox(key)
+	assert.NoError(t, err)
+	return box
+}
+
+func TestSealOpen_RoundTrip(t *testing.T) {
+	box := newTestBox(t, "test")
+
+	sealed, err := box.Seal([]byte("JBSWY3DPEHPK3PXP"), []byte("user-1"))
+	assert.NoError(t, err)
+	assert.NotContains(t, sealed, "JBSWY3DPEHPK3PXP")
+
+	plaintext, err := box.Open(sealed, []byte("user-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", string(plaintext))
+}
+
+func TestSeal_RandomNonce(t *testing.T) {
+	box := newTestBox(t, "test")
+
+	first, _ := box.Seal([]byte("secret"), nil)
+	second, _ := box.Seal([]byte("secret"), nil)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestOpen_Rejects(t *testing.T) {
+	box := newTestBox(t, "test")
+	sealed, _ := box.Seal([]byte("secret"), []byte("user-1"))
+	tampered := []byte(sealed)
+	if tampered[10] == 'A' {
+		tampered[10] = 'B'
+	} else {
+		tampered[10] = 'A'
+	}
+
+	tests := map[string]struct {
+		box            *secretbox.Box
+		sealed         string
+		associatedData string
+	}{
+		"other record":    {box: box, sealed: sealed, associatedData: "user-2"},
+		"other purpose":   {box: newTestBox(t, "other"), sealed: sealed, associatedData: "user-1"},
+		"tampered":        {box: box, sealed: string(tampered), associatedData: "user-1"},
+		"missing version": {box: box, sealed: sealed[3:], associatedData: "user-1"},
+		"truncated":       {box: box, sealed: "v1:AAAA", associatedData: "user-1"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := test.box.Open(test.sealed, []byte(test.associatedData))
+			assert.ErrorIs(t, err, secretbox.ErrOpen)
+		})
+	}
+}
+
+func TestNewBox_InvalidKey(t *testing.T) {
+	_, err := secretbox.NewBox([]byte("short"))
+	assert.ErrorIs(t, err, secretbox.ErrInvalidKey)
+
+	_, err = secretbox.DeriveKey("", "test")
+	assert.ErrorIs(t, err, secretbox.ErrInvalidKey)
+}
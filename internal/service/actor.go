@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+)
+
+// Actor identifies the caller behind a request so that services can attribute their actions.
+type Actor struct {
+	UserID    string
+	SourceIP  string
+	RequestID string
+}
+
+type actorContextKey struct{}
+
+// WithActor returns a copy of ctx carrying the actor.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor, or the zero Actor for anonymous calls.
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorContextKey{}).(Actor)
+	return actor
+}
+
+// Audience returns the profile visibility the actor is granted on other users' profiles.
+func (a Actor) Audience() publicModel.Visibility {
+	if a.UserID == "" {
+		return publicModel.VisibilityPublic
+	}
+	return publicModel.VisibilityAuthenticated
+}
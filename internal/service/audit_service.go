@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+)
+
+const (
+	DefaultAuditListLimit   = 100
+	MaxAuditListLimit       = 1000
+	DefaultAuditMaxAttempts = 8
+	// DefaultAuditRetryBackoff is the longest wait before the first retry. It doubles with every
+	// further retry, and the actual wait is drawn at random so that contending writers spread out.
+	DefaultAuditRetryBackoff = 5 * time.Millisecond
+)
+
+// ErrAuditContention is returned when an event could not be appended because other writers kept extending the chain.
+var ErrAuditContention = errors.New("audit log is under contention")
+
+// ErrAuditChainBroken is returned by VerifyAuditChain when an event was altered or removed.
+var ErrAuditChainBroken = errors.New("audit chain is broken")
+
+type IAuditService interface {
+	Record(ctx context.Context, action string, targetUserID string, before *model.PrivateUserModel, after *model.PrivateUserModel) error
+	List(ctx context.Context, filter repository.AuditFilter) ([]*model.AuditEvent, error)
+}
+
+type AuditService struct {
+	Repository   repository.IAuditRepository
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+// NewAuditService creates a new instance of AuditService.
+func NewAuditService(repository repository.IAuditRepository) *AuditService {
+	return &AuditService{
+		Repository:   repository,
+		MaxAttempts:  DefaultAuditMaxAttempts,
+		RetryBackoff: DefaultAuditRetryBackoff,
+	}
+}
+
+// Record implements IAuditService.
+// The actor, source IP and request id are taken from the context set up by the transport. When
+// another writer extends the chain first, Record retries after a jittered, growing backoff.
+func (s *AuditService) Record(ctx context.Context, action string, targetUserID string, before *model.PrivateUserModel, after *model.PrivateUserModel) error {
+	actor := ActorFromContext(ctx)
+	changes := model.DiffUsers(before, after)
+	tenantID := tenant.FromContext(ctx)
+	if after != nil && after.TenantID != "" {
+		tenantID = after.TenantID
+	} else if before != nil && before.TenantID != "" {
+		tenantID = before.TenantID
+	}
+
+	for attempt := 0; attempt < s.MaxAttempts; attempt++ {
+		latest, err := s.Repository.FindLatest(ctx)
+		if err != nil {
+			return err
+		}
+
+		event := &model.AuditEvent{
+			TenantID:     tenantID,
+			Sequence:     1,
+			Actor:        actor.UserID,
+			Action:       action,
+			TargetUserID: targetUserID,
+			SourceIP:     actor.SourceIP,
+			RequestID:    actor.RequestID,
+			Changes:      changes,
+			CreatedAt:    time.Now().UTC().Truncate(time.Millisecond),
+		}
+		if latest != nil {
+			event.Sequence = latest.Sequence + 1
+			event.PrevHash = latest.Hash
+		}
+		if err := event.DigestSourceIP(); err != nil {
+			return err
+		}
+		event.Hash = event.ComputeHash()
+
+		err = s.Repository.Append(ctx, event)
+		var serviceError *common_error.ServiceError
+		if errors.As(err, &serviceError) && serviceError.Code == common_error.Conflict {
+			if attempt+1 < s.MaxAttempts {
+				if err := s.backoff(ctx, attempt); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		return err
+	}
+
+	return ErrAuditContention
+}
+
+// backoff waits a random time of up to RetryBackoff doubled attempt times, or until ctx is done.
+func (s *AuditService) backoff(ctx context.Context, attempt int) error {
+	wait := time.Duration(rand.Int63n(int64(s.RetryBackoff<<attempt) + 1))
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// List implements IAuditService.
+func (s *AuditService) List(ctx context.Context, filter repository.AuditFilter) ([]*model.AuditEvent, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultAuditListLimit
+	}
+	// Outside cross-tenant mode callers only see their own tenant's events
+	if !tenant.IsAllTenants(ctx) {
+		filter.TenantID = tenant.FromContext(ctx)
+	}
+	if filter.Limit > MaxAuditListLimit {
+		filter.Limit = MaxAuditListLimit
+	}
+
+	return s.Repository.List(ctx, filter)
+}
+
+// VerifyAuditChain checks events listed in sequence order. Every event must match its own hash,
+// and consecutive sequence numbers must link through PrevHash. Hash versions never decrease along
+// the chain, so only events older than the first recent one can pass for scrubbed legacy events.
+func VerifyAuditChain(events []*model.AuditEvent) error {
+	for i, event := range events {
+		if !event.MatchesHash() {
+			return fmt.Errorf("%w: event %d does not match its hash", ErrAuditChainBroken, event.Sequence)
+		}
+		if i > 0 && event.HashVersion < events[i-1].HashVersion {
+			return fmt.Errorf("%w: event %d predates the hash version of event %d", ErrAuditChainBroken, event.Sequence, events[i-1].Sequence)
+		}
+		if i > 0 && events[i-1].Sequence+1 == event.Sequence && events[i-1].Hash != event.PrevHash {
+			return fmt.Errorf("%w: event %d does not follow event %d", ErrAuditChainBroken, event.Sequence, events[i-1].Sequence)
+		}
+	}
+
+	return nil
+}
+
+// Ensure AuditService implements IAuditService
+var _ IAuditService = &AuditService{}
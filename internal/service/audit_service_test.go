@@ -0,0 +1,259 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MockIAuditRepository struct {
+	mock.Mock
+}
+
+// Append implements repository.IAuditRepository.
+func (m *MockIAuditRepository) Append(ctx context.Context, event *model.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// FindLatest implements repository.IAuditRepository.
+func (m *MockIAuditRepository) FindLatest(ctx context.Context) (*model.AuditEvent, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AuditEvent), args.Error(1)
+}
+
+// List implements repository.IAuditRepository.
+func (m *MockIAuditRepository) List(ctx context.Context, filter repository.AuditFilter) ([]*model.AuditEvent, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.AuditEvent), args.Error(1)
+}
+
+// Scrub implements repository.IAuditRepository.
+func (m *MockIAuditRepository) Scrub(ctx context.Context, userID string, scrubbedAt time.Time) (int, error) {
+	args := m.Called(ctx, userID, scrubbedAt)
+	return args.Int(0), args.Error(1)
+}
+
+// Ensure that MockIAuditRepository implements IAuditRepository.
+var _ repository.IAuditRepository = &MockIAuditRepository{}
+
+func TestRecord_ChainsToLatestEvent(t *testing.T) {
+	mockRepository := new(MockIAuditRepository)
+	auditService := service.NewAuditService(mockRepository)
+	ctx := service.WithActor(context.Background(), service.Actor{UserID: "-1", SourceIP: "10.0.0.1", RequestID: "request-1"})
+	latest := &model.AuditEvent{Sequence: 4, Hash: "previous"}
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "test@mail.com", Username: "test", Hash: "secret"}
+
+	mockRepository.On("FindLatest", ctx).Return(latest, nil)
+	mockRepository.On("Append", ctx, mock.Anything).Return(nil)
+
+	err := auditService.Record(ctx, model.AuditActionCreate, user.ID.Hex(), nil, user)
+
+	assert.NoError(t, err)
+	event := mockRepository.Calls[1].Arguments.Get(1).(*model.AuditEvent)
+	assert.Equal(t, int64(5), event.Sequence)
+	assert.Equal(t, "previous", event.PrevHash)
+	assert.Equal(t, event.ComputeHash(), event.Hash)
+	assert.Equal(t, "-1", event.Actor)
+	assert.Equal(t, "10.0.0.1", event.SourceIP)
+	assert.Equal(t, model.AuditHashVersion, event.HashVersion)
+	assert.NotEmpty(t, event.SourceIPDigest)
+	assert.True(t, event.MatchesHash())
+	assert.Equal(t, "request-1", event.RequestID)
+	assert.Contains(t, event.Changes, model.FieldChange{Field: "password", New: model.RedactedValue})
+	assert.Contains(t, event.Changes, model.FieldChange{Field: "email", New: model.RedactedValue})
+	assert.Contains(t, event.Changes, model.FieldChange{Field: "username", New: model.RedactedValue})
+	mockRepository.AssertExpectations(t)
+}
+
+func TestRecord_RedactsPersonalData(t *testing.T) {
+	mockRepository := new(MockIAuditRepository)
+	auditService := service.NewAuditService(mockRepository)
+	ctx := context.Background()
+	lockedUntil := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "old@mail.com", Username: "old", Hash: "secret"}
+	after := *before
+	after.Email, after.Username, after.LockedUntil = "new@mail.com", "new", &lockedUntil
+	after.Profile = &publicModel.ProfileModel{DisplayName: "New Name"}
+
+	mockRepository.On("FindLatest", ctx).Return(nil, nil)
+	mockRepository.On("Append", ctx, mock.Anything).Return(nil)
+
+	err := auditService.Record(ctx, model.AuditActionUpdate, before.ID.Hex(), before, &after)
+
+	assert.NoError(t, err)
+	event := mockRepository.Calls[1].Arguments.Get(1).(*model.AuditEvent)
+	assert.Equal(t, []model.FieldChange{
+		{Field: "email", Old: model.RedactedValue, New: model.RedactedValue},
+		{Field: "username", Old: model.RedactedValue, New: model.RedactedValue},
+		{Field: "profile", New: model.RedactedValue},
+		{Field: "locked_until", New: "2030-01-01T00:00:00Z"},
+	}, event.Changes)
+}
+
+func TestRecord_RetriesOnSequenceConflict(t *testing.T) {
+	mockRepository := new(MockIAuditRepository)
+	auditService := service.NewAuditService(mockRepository)
+	ctx := context.Background()
+	conflict := common_error.NewServiceError(common_error.Conflict, "Audit sequence taken", assert.AnError)
+
+	mockRepository.On("FindLatest", ctx).Return(nil, nil).Once()
+	mockRepository.On("Append", ctx, mock.Anything).Return(conflict).Once()
+	mockRepository.On("FindLatest", ctx).Return(&model.AuditEvent{Sequence: 1, Hash: "first"}, nil).Once()
+	mockRepository.On("Append", ctx, mock.MatchedBy(func(event *model.AuditEvent) bool {
+		return event.Sequence == 2 && event.PrevHash == "first"
+	})).Return(nil).Once()
+
+	err := auditService.Record(ctx, model.AuditActionLookupPrivate, "target", nil, nil)
+
+	assert.NoError(t, err)
+	mockRepository.AssertExpectations(t)
+}
+
+func TestRecord_GivesUpUnderContention(t *testing.T) {
+	mockRepository := new(MockIAuditRepository)
+	auditService := service.NewAuditService(mockRepository)
+	auditService.MaxAttempts = 2
+	auditService.RetryBackoff = 0
+	ctx := context.Background()
+	conflict := common_error.NewServiceError(common_error.Conflict, "Audit sequence taken", assert.AnError)
+
+	mockRepository.On("FindLatest", ctx).Return(nil, nil)
+	mockRepository.On("Append", ctx, mock.Anything).Return(conflict)
+
+	err := auditService.Record(ctx, model.AuditActionLookupPrivate, "target", nil, nil)
+
+	assert.ErrorIs(t, err, service.ErrAuditContention)
+	mockRepository.AssertNumberOfCalls(t, "Append", 2)
+}
+
+func TestRecord_BackoffEndsWithContext(t *testing.T) {
+	mockRepository := new(MockIAuditRepository)
+	auditService := service.NewAuditService(mockRepository)
+	auditService.RetryBackoff = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	conflict := common_error.NewServiceError(common_error.Conflict, "Audit sequence taken", assert.AnError)
+
+	mockRepository.On("FindLatest", ctx).Return(nil, nil)
+	mockRepository.On("Append", ctx, mock.Anything).Return(conflict)
+
+	err := auditService.Record(ctx, model.AuditActionLookupPrivate, "target", nil, nil)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	mockRepository.AssertNumberOfCalls(t, "Append", 1)
+}
+
+func TestList_ClampsLimit(t *testing.T) {
+	mockRepository := new(MockIAuditRepository)
+	auditService := service.NewAuditService(mockRepository)
+	ctx := context.Background()
+
+	mockRepository.On("List", ctx, repository.AuditFilter{TenantID: tenant.DefaultTenantID, Limit: service.MaxAuditListLimit}).Return([]*model.AuditEvent{}, nil)
+
+	events, err := auditService.List(ctx, repository.AuditFilter{Limit: 1 << 20})
+
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	mockRepository.AssertExpectations(t)
+}
+
+func TestList_CrossTenant(t *testing.T) {
+	mockRepository := new(MockIAuditRepository)
+	auditService := service.NewAuditService(mockRepository)
+	ctx := tenant.WithTenant(context.Background(), tenant.AllTenants)
+
+	mockRepository.On("List", ctx, repository.AuditFilter{Limit: service.DefaultAuditListLimit}).Return([]*model.AuditEvent{}, nil)
+
+	_, err := auditService.List(ctx, repository.AuditFilter{})
+
+	assert.NoError(t, err)
+	mockRepository.AssertExpectations(t)
+}
+
+func TestComputeHash_UntenantedEventsKeepTheirHash(t *testing.T) {
+	event := &model.AuditEvent{Sequence: 1, Action: model.AuditActionCreate, TargetUserID: "a", CreatedAt: time.UnixMilli(1700000000000)}
+
+	// Hash of this event as computed before audit events carried a tenant
+	assert.Equal(t, "5c5ac1d390f82079c407545c2e2c7b4ed95d63baba416b8d3961708e62e334fb", event.ComputeHash())
+
+	event.TenantID = "acme"
+	assert.NotEqual(t, "5c5ac1d390f82079c407545c2e2c7b4ed95d63baba416b8d3961708e62e334fb", event.ComputeHash())
+}
+
+func TestVerifyAuditChain(t *testing.T) {
+	first := &model.AuditEvent{Sequence: 1, Action: model.AuditActionCreate, TargetUserID: "a"}
+	first.Hash = first.ComputeHash()
+	second := &model.AuditEvent{Sequence: 2, Action: model.AuditActionLookupPrivate, TargetUserID: "a", PrevHash: first.Hash}
+	second.Hash = second.ComputeHash()
+
+	assert.NoError(t, service.VerifyAuditChain([]*model.AuditEvent{first, second}))
+
+	// Editing a stored event invalidates its hash
+	tampered := *first
+	tampered.Actor = "someone else"
+	assert.ErrorIs(t, service.VerifyAuditChain([]*model.AuditEvent{&tampered, second}), service.ErrAuditChainBroken)
+
+	// Rewriting an event together with its hash breaks the link to its successor
+	tampered.Hash = tampered.ComputeHash()
+	assert.ErrorIs(t, service.VerifyAuditChain([]*model.AuditEvent{&tampered, second}), service.ErrAuditChainBroken)
+}
+
+func TestVerifyAuditChain_ScrubbedEvents(t *testing.T) {
+	scrubbedAt := time.UnixMilli(1700000000000)
+	// A legacy event recorded before diffs were redacted, and events recorded since
+	legacy := &model.AuditEvent{Sequence: 1, Actor: "a", Action: model.AuditActionCreate, TargetUserID: "a", SourceIP: "10.0.0.1",
+		Changes: []model.FieldChange{{Field: "email", New: "test@mail.com"}, {Field: "mfa_enabled", New: "true"}}}
+	legacy.Hash = legacy.ComputeHash()
+	update := &model.AuditEvent{Sequence: 2, Actor: "a", Action: model.AuditActionUpdate, TargetUserID: "a", SourceIP: "10.0.0.1", PrevHash: legacy.Hash,
+		Changes: []model.FieldChange{{Field: "username", Old: model.RedactedValue, New: model.RedactedValue}}}
+	assert.NoError(t, update.DigestSourceIP())
+	update.Hash = update.ComputeHash()
+	lookup := &model.AuditEvent{Sequence: 3, Actor: "admin", Action: model.AuditActionLookupPrivate, TargetUserID: "a", SourceIP: "10.0.0.2", PrevHash: update.Hash}
+	assert.NoError(t, lookup.DigestSourceIP())
+	lookup.Hash = lookup.ComputeHash()
+
+	// Scrubbing removes the user's personal data but not other actors' IPs
+	assert.True(t, legacy.Scrub("a", scrubbedAt))
+	assert.True(t, update.Scrub("a", scrubbedAt))
+	assert.False(t, lookup.Scrub("a", scrubbedAt))
+	assert.Empty(t, legacy.SourceIP)
+	assert.Equal(t, []model.FieldChange{{Field: "email", New: model.RedactedValue}, {Field: "mfa_enabled", New: "true"}}, legacy.Changes)
+	assert.Empty(t, update.SourceIP)
+	assert.Empty(t, update.Salt)
+	assert.Equal(t, "10.0.0.2", lookup.SourceIP)
+	assert.NoError(t, service.VerifyAuditChain([]*model.AuditEvent{legacy, update, lookup}))
+
+	// Scrubbed events of the current version still cover everything but the IP
+	tampered := *update
+	tampered.Action = model.AuditActionDelete
+	assert.ErrorIs(t, service.VerifyAuditChain([]*model.AuditEvent{legacy, &tampered, lookup}), service.ErrAuditChainBroken)
+
+	// An IP that was not scrubbed must match its digest
+	tampered = *lookup
+	tampered.SourceIP = "10.0.0.3"
+	assert.ErrorIs(t, service.VerifyAuditChain([]*model.AuditEvent{legacy, update, &tampered}), service.ErrAuditChainBroken)
+
+	// An event recorded after a recent one cannot pass for a scrubbed legacy event
+	tampered = *lookup
+	tampered.Action = model.AuditActionDelete
+	tampered.HashVersion = 0
+	tampered.ScrubbedAt = &scrubbedAt
+	assert.ErrorIs(t, service.VerifyAuditChain([]*model.AuditEvent{legacy, update, &tampered}), service.ErrAuditChainBroken)
+}
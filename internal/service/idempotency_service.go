@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+)
+
+const (
+	DefaultIdempotencyTTL   = 24 * time.Hour
+	DefaultIdempotencyLease = time.Minute
+	MaxIdempotencyKeyLength = 255
+)
+
+var (
+	// ErrInvalidIdempotencyKey is returned for keys longer than MaxIdempotencyKeyLength.
+	ErrInvalidIdempotencyKey = errors.New("idempotency key is too long")
+	// ErrIdempotencyKeyReused is returned when a key is sent again with a different request.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different request")
+	// ErrIdempotencyInProgress is returned when a retry arrives while the first request is still running.
+	ErrIdempotencyInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
+// IIdempotencyService tracks requests made with an idempotency key.
+// Begin returns the stored response of a completed identical request, or nil when the caller
+// should run the request and then report its outcome with Complete or Abandon. Requests are
+// fingerprinted with an HMAC keyed by a server secret and may hold secrets, but responses are
+// stored as they are and must not hold secrets or personal data.
+type IIdempotencyService interface {
+	Begin(ctx context.Context, caller string, key string, request interface{}) (json.RawMessage, error)
+	Complete(ctx context.Context, caller string, key string, response interface{}) error
+	Abandon(ctx context.Context, caller string, key string) error
+}
+
+type IdempotencyService struct {
+	Repository repository.IIdempotencyRepository
+	// FingerprintKey keys the request fingerprints
+	FingerprintKey []byte
+	TTL            time.Duration
+	// Lease is how long a pending request holds its key before a retry may take it over, so that
+	// a request lost in a crash does not block its key until the record expires
+	Lease time.Duration
+	Now   func() time.Time
+}
+
+// NewIdempotencyService creates a new instance of IdempotencyService.
+func NewIdempotencyService(repository repository.IIdempotencyRepository, fingerprintKey []byte) *IdempotencyService {
+	return &IdempotencyService{
+		Repository:     repository,
+		FingerprintKey: fingerprintKey,
+		TTL:            DefaultIdempotencyTTL,
+		Lease:          DefaultIdempotencyLease,
+		Now:            time.Now,
+	}
+}
+
+// Begin implements IIdempotencyService.
+// A pending request whose lease has run out is taken over by the retry, which then runs the request again.
+func (s *IdempotencyService) Begin(ctx context.Context, caller string, key string, request interface{}) (json.RawMessage, error) {
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	requestHash, err := s.fingerprint(request)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.Now()
+	err = s.Repository.Reserve(ctx, &model.IdempotencyRecord{
+		Caller:       caller,
+		Key:          key,
+		RequestHash:  requestHash,
+		Status:       model.IdempotencyPending,
+		PendingUntil: now.Add(s.Lease),
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(s.TTL),
+	})
+	// A fresh reservation (nil error) means this is the first request with the key
+	var serviceError *common_error.ServiceError
+	if !errors.As(err, &serviceError) || serviceError.Code != common_error.Conflict {
+		return nil, err
+	}
+
+	record, err := s.Repository.Find(ctx, caller, key)
+	if err != nil {
+		return nil, err
+	}
+	if record.RequestHash != requestHash {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if record.Status != model.IdempotencyCompleted {
+		if now.Before(record.PendingUntil) {
+			return nil, ErrIdempotencyInProgress
+		}
+		taken, err := s.Repository.TakeOver(ctx, record, now.Add(s.Lease))
+		if err != nil {
+			return nil, err
+		}
+		if !taken {
+			return nil, ErrIdempotencyInProgress
+		}
+		return nil, nil
+	}
+
+	return record.Response, nil
+}
+
+// Complete implements IIdempotencyService.
+func (s *IdempotencyService) Complete(ctx context.Context, caller string, key string, response interface{}) error {
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	return s.Repository.Complete(ctx, caller, key, encoded)
+}
+
+// Abandon implements IIdempotencyService. It frees the key so that a retry runs the request again.
+func (s *IdempotencyService) Abandon(ctx context.Context, caller string, key string) error {
+	return s.Repository.Delete(ctx, caller, key)
+}
+
+func (s *IdempotencyService) fingerprint(request interface{}) (string, error) {
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, s.FingerprintKey)
+	mac.Write(encoded)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx carrying the idempotency key sent by the client.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the key stored by WithIdempotencyKey, or an empty string.
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// Ensure IdempotencyService implements IIdempotencyService
+var _ IIdempotencyService = &IdempotencyService{}
@@ -0,0 +1,174 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIIdempotencyRepository struct {
+	mock.Mock
+}
+
+// Reserve implements repository.IIdempotencyRepository.
+func (m *MockIIdempotencyRepository) Reserve(ctx context.Context, record *model.IdempotencyRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+// Find implements repository.IIdempotencyRepository.
+func (m *MockIIdempotencyRepository) Find(ctx context.Context, caller string, key string) (*model.IdempotencyRecord, error) {
+	args := m.Called(ctx, caller, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.IdempotencyRecord), args.Error(1)
+}
+
+// TakeOver implements repository.IIdempotencyRepository.
+func (m *MockIIdempotencyRepository) TakeOver(ctx context.Context, record *model.IdempotencyRecord, pendingUntil time.Time) (bool, error) {
+	args := m.Called(ctx, record, pendingUntil)
+	return args.Bool(0), args.Error(1)
+}
+
+// Complete implements repository.IIdempotencyRepository.
+func (m *MockIIdempotencyRepository) Complete(ctx context.Context, caller string, key string, response json.RawMessage) error {
+	args := m.Called(ctx, caller, key, response)
+	return args.Error(0)
+}
+
+// Delete implements repository.IIdempotencyRepository.
+func (m *MockIIdempotencyRepository) Delete(ctx context.Context, caller string, key string) error {
+	args := m.Called(ctx, caller, key)
+	return args.Error(0)
+}
+
+// Ensure that MockIIdempotencyRepository implements IIdempotencyRepository.
+var _ repository.IIdempotencyRepository = &MockIIdempotencyRepository{}
+
+var fingerprintKey = []byte("fingerprint key")
+
+var keyTaken = common_error.NewServiceError(common_error.Conflict, "Idempotency key already used", assert.AnError)
+
+func TestBegin_FirstRequest(t *testing.T) {
+	mockRepository := new(MockIIdempotencyRepository)
+	idempotencyService := service.NewIdempotencyService(mockRepository, fingerprintKey)
+	ctx := context.Background()
+
+	mockRepository.On("Reserve", ctx, mock.MatchedBy(func(record *model.IdempotencyRecord) bool {
+		return record.Caller == "-1" && record.Key == "key" && record.Status == model.IdempotencyPending &&
+			record.ExpiresAt.Sub(record.CreatedAt) == service.DefaultIdempotencyTTL &&
+			record.PendingUntil.Sub(record.CreatedAt) == service.DefaultIdempotencyLease
+	})).Return(nil)
+
+	replay, err := idempotencyService.Begin(ctx, "-1", "key", map[string]string{"username": "test"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, replay)
+	mockRepository.AssertExpectations(t)
+}
+
+func TestBegin_ReplaysCompletedRequest(t *testing.T) {
+	mockRepository := new(MockIIdempotencyRepository)
+	idempotencyService := service.NewIdempotencyService(mockRepository, fingerprintKey)
+	ctx := context.Background()
+	request := map[string]string{"username": "test"}
+
+	stored := &model.IdempotencyRecord{Status: model.IdempotencyCompleted, Response: json.RawMessage(`{"id":"1"}`)}
+	mockRepository.On("Reserve", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stored.RequestHash = args.Get(1).(*model.IdempotencyRecord).RequestHash
+	}).Return(keyTaken)
+	mockRepository.On("Find", ctx, "-1", "key").Return(stored, nil)
+
+	replay, err := idempotencyService.Begin(ctx, "-1", "key", request)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":"1"}`, string(replay))
+}
+
+func TestBegin_KeyReusedWithDifferentRequest(t *testing.T) {
+	mockRepository := new(MockIIdempotencyRepository)
+	idempotencyService := service.NewIdempotencyService(mockRepository, fingerprintKey)
+	ctx := context.Background()
+
+	mockRepository.On("Reserve", ctx, mock.Anything).Return(keyTaken)
+	mockRepository.On("Find", ctx, "-1", "key").Return(&model.IdempotencyRecord{RequestHash: "other", Status: model.IdempotencyCompleted}, nil)
+
+	replay, err := idempotencyService.Begin(ctx, "-1", "key", map[string]string{"username": "test"})
+
+	assert.ErrorIs(t, err, service.ErrIdempotencyKeyReused)
+	assert.Nil(t, replay)
+}
+
+func TestBegin_SecretsChangeTheFingerprint(t *testing.T) {
+	mockRepository := new(MockIIdempotencyRepository)
+	idempotencyService := service.NewIdempotencyService(mockRepository, fingerprintKey)
+	ctx := context.Background()
+	stored := &model.IdempotencyRecord{}
+
+	mockRepository.On("Reserve", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		*stored = *args.Get(1).(*model.IdempotencyRecord)
+	}).Return(nil).Once()
+	mockRepository.On("Reserve", ctx, mock.Anything).Return(keyTaken)
+	mockRepository.On("Find", ctx, "-1", "key").Return(stored, nil)
+
+	_, err := idempotencyService.Begin(ctx, "-1", "key", map[string]string{"username": "test", "password": "first"})
+	assert.NoError(t, err)
+	_, err = idempotencyService.Begin(ctx, "-1", "key", map[string]string{"username": "test", "password": "second"})
+
+	assert.ErrorIs(t, err, service.ErrIdempotencyKeyReused)
+	assert.NotContains(t, stored.RequestHash, "first")
+}
+
+func TestBegin_PendingRequest(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name         string
+		pendingUntil time.Time
+		takenOver    bool
+		expectedErr  error
+	}{
+		{"lease running", now.Add(time.Second), false, service.ErrIdempotencyInProgress},
+		{"lease expired", now.Add(-time.Second), true, nil},
+		{"lease expired, taken by another retry", now.Add(-time.Second), false, service.ErrIdempotencyInProgress},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepository := new(MockIIdempotencyRepository)
+			idempotencyService := service.NewIdempotencyService(mockRepository, fingerprintKey)
+			idempotencyService.Now = func() time.Time { return now }
+			ctx := context.Background()
+			pending := &model.IdempotencyRecord{Caller: "-1", Key: "key", Status: model.IdempotencyPending, PendingUntil: tc.pendingUntil}
+
+			mockRepository.On("Reserve", ctx, mock.Anything).Run(func(args mock.Arguments) {
+				pending.RequestHash = args.Get(1).(*model.IdempotencyRecord).RequestHash
+			}).Return(keyTaken)
+			mockRepository.On("Find", ctx, "-1", "key").Return(pending, nil)
+			mockRepository.On("TakeOver", ctx, pending, now.Add(service.DefaultIdempotencyLease)).Return(tc.takenOver, nil)
+
+			replay, err := idempotencyService.Begin(ctx, "-1", "key", map[string]string{"username": "test"})
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, replay)
+		})
+	}
+}
+
+func TestBegin_InvalidKey(t *testing.T) {
+	mockRepository := new(MockIIdempotencyRepository)
+	idempotencyService := service.NewIdempotencyService(mockRepository, fingerprintKey)
+
+	replay, err := idempotencyService.Begin(context.Background(), "-1", strings.Repeat("k", service.MaxIdempotencyKeyLength+1), nil)
+
+	assert.ErrorIs(t, err, service.ErrInvalidIdempotencyKey)
+	assert.Nil(t, replay)
+	mockRepository.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything)
+}
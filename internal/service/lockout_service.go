@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+)
+
+const (
+	DefaultLoginHistoryLimit = 50
+	MaxLoginHistoryLimit     = 500
+)
+
+// ErrAccountLocked is wrapped by AccountLockedError.
+var ErrAccountLocked = errors.New("account is temporarily locked")
+
+// ErrLoginThrottled is wrapped by LoginThrottledError.
+var ErrLoginThrottled = errors.New("too many failed sign-in attempts")
+
+// AccountLockedError is returned while an account is locked after repeated failed attempts.
+type AccountLockedError struct {
+	Until time.Time
+}
+
+func (e *AccountLockedError) Error() string {
+	return fmt.Sprintf("%s until %s", ErrAccountLocked, e.Until.UTC().Format(time.RFC3339))
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// LoginThrottledError is returned when an attempt comes too soon after earlier failures.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", ErrLoginThrottled, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return ErrLoginThrottled
+}
+
+// LockoutPolicy configures brute-force protection. Failures older than Window are forgotten.
+type LockoutPolicy struct {
+	Window time.Duration
+	// Threshold consecutive failures lock the account for LockoutDuration.
+	Threshold       int
+	LockoutDuration time.Duration
+	// After DelayAfter consecutive failures each further attempt must wait BaseDelay, doubling per failure up to MaxDelay.
+	DelayAfter int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	// IPThreshold failures from one source IP within Window throttle that IP, whichever accounts it targets.
+	IPThreshold int
+}
+
+// DefaultLockoutPolicy returns the lockout settings used by the service.
+func DefaultLockoutPolicy() LockoutPolicy {
+	return LockoutPolicy{
+		Window:          15 * time.Minute,
+		Threshold:       10,
+		LockoutDuration: 15 * time.Minute,
+		DelayAfter:      3,
+		BaseDelay:       time.Second,
+		MaxDelay:        30 * time.Second,
+		IPThreshold:     100,
+	}
+}
+
+// delay returns how long to wait after the given number of consecutive failures.
+func (p LockoutPolicy) delay(failures int) time.Duration {
+	if failures < p.DelayAfter || p.BaseDelay <= 0 {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := p.DelayAfter; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+type ILockoutService interface {
+	// Allow returns an error when an attempt for the user, which is nil for unknown identifiers, must be refused.
+	Allow(ctx context.Context, user *model.PrivateUserModel, sourceIP string) error
+	RecordFailure(ctx context.Context, user *model.PrivateUserModel, sourceIP string) error
+	RecordSuccess(ctx context.Context, user *model.PrivateUserModel, sourceIP string) error
+	Unlock(ctx context.Context, id string) error
+	History(ctx context.Context, id string, limit int64) ([]*model.LoginAttempt, error)
+}
+
+type LockoutService struct {
+	Attempts repository.ILoginAttemptRepository
+	Users    repository.IUserRepository
+	Audit    IAuditService
+	Policy   LockoutPolicy
+	Now      func() time.Time
+	Logger   *slog.Logger
+}
+
+// NewLockoutService creates a new instance of LockoutService.
+func NewLockoutService(attempts repository.ILoginAttemptRepository, users repository.IUserRepository, policy LockoutPolicy, logger *slog.Logger) *LockoutService {
+	return &LockoutService{
+		Attempts: attempts,
+		Users:    users,
+		Policy:   policy,
+		Now:      time.Now,
+		Logger:   logger,
+	}
+}
+
+// Allow implements ILockoutService.
+// Refused attempts are recorded as rejected and do not count as failures.
+func (s *LockoutService) Allow(ctx context.Context, user *model.PrivateUserModel, sourceIP string) error {
+	now := s.Now()
+	err := s.check(ctx, user, sourceIP, now)
+	if errors.Is(err, ErrAccountLocked) || errors.Is(err, ErrLoginThrottled) {
+		if err := s.record(ctx, user, sourceIP, model.LoginRejected, now); err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to record rejected sign-in attempt", "error", err)
+		}
+	}
+	return err
+}
+
+func (s *LockoutService) check(ctx context.Context, user *model.PrivateUserModel, sourceIP string, now time.Time) error {
+	if user != nil && user.IsLocked(now) {
+		return &AccountLockedError{Until: *user.LockedUntil}
+	}
+
+	if sourceIP != "" && s.Policy.IPThreshold > 0 {
+		failures, err := s.Attempts.List(ctx, repository.LoginAttemptFilter{
+			SourceIP: sourceIP,
+			Results:  []model.LoginResult{model.LoginFailed},
+			Since:    now.Add(-s.Policy.Window),
+			Limit:    int64(s.Policy.IPThreshold),
+		})
+		if err != nil {
+			return err
+		}
+		if len(failures) >= s.Policy.IPThreshold {
+			oldest := failures[len(failures)-1]
+			return &LoginThrottledError{RetryAfter: oldest.CreatedAt.Add(s.Policy.Window).Sub(now)}
+		}
+	}
+
+	if user != nil {
+		failures, err := s.consecutiveFailures(ctx, user, now)
+		if err != nil {
+			return err
+		}
+		if delay := s.Policy.delay(len(failures)); delay > 0 {
+			if retryAfter := failures[0].CreatedAt.Add(delay).Sub(now); retryAfter > 0 {
+				return &LoginThrottledError{RetryAfter: retryAfter}
+			}
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure implements ILockoutService.
+// The account is locked, and the lockout audited, once its consecutive failures reach the threshold.
+func (s *LockoutService) RecordFailure(ctx context.Context, user *model.PrivateUserModel, sourceIP string) error {
+	now := s.Now()
+	if err := s.record(ctx, user, sourceIP, model.LoginFailed, now); err != nil {
+		return err
+	}
+	if user == nil || s.Policy.Threshold <= 0 {
+		return nil
+	}
+
+	failures, err := s.consecutiveFailures(ctx, user, now)
+	if err != nil {
+		return err
+	}
+	if len(failures) < s.Policy.Threshold {
+		return nil
+	}
+
+	until := now.Add(s.Policy.LockoutDuration)
+	if err := s.Users.SetLockedUntil(ctx, user.ID.Hex(), &until); err != nil {
+		return err
+	}
+
+	locked := *user
+	locked.LockedUntil = &until
+	s.audit(ctx, model.AuditActionLockout, user, &locked)
+
+	return nil
+}
+
+// RecordSuccess implements ILockoutService.
+func (s *LockoutService) RecordSuccess(ctx context.Context, user *model.PrivateUserModel, sourceIP string) error {
+	return s.record(ctx, user, sourceIP, model.LoginSucceeded, s.Now())
+}
+
+// Unlock implements ILockoutService.
+// Unlocking also ends the run of failures, so the next failed attempt does not lock the account again.
+func (s *LockoutService) Unlock(ctx context.Context, id string) error {
+	if err := authz.CheckTarget(ctx, id); err != nil {
+		return err
+	}
+
+	user, err := s.Users.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Users.SetLockedUntil(ctx, id, nil); err != nil {
+		return err
+	}
+	if err := s.record(ctx, user, "", model.LoginReset, s.Now()); err != nil {
+		return err
+	}
+
+	unlocked := *user
+	unlocked.LockedUntil = nil
+	s.audit(ctx, model.AuditActionUnlock, user, &unlocked)
+
+	return nil
+}
+
+// History implements ILockoutService. Attempts are returned newest first.
+func (s *LockoutService) History(ctx context.Context, id string, limit int64) ([]*model.LoginAttempt, error) {
+	if err := authz.CheckTarget(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = DefaultLoginHistoryLimit
+	}
+	if limit > MaxLoginHistoryLimit {
+		limit = MaxLoginHistoryLimit
+	}
+
+	return s.Attempts.List(ctx, repository.LoginAttemptFilter{UserID: id, Limit: limit})
+}
+
+// consecutiveFailures returns the user's failures within the window since the last success or unlock,
+// newest first. Rejected attempts neither count nor end the run.
+func (s *LockoutService) consecutiveFailures(ctx context.Context, user *model.PrivateUserModel, now time.Time) ([]*model.LoginAttempt, error) {
+	limit := s.Policy.Threshold
+	if s.Policy.DelayAfter > limit {
+		limit = s.Policy.DelayAfter
+	}
+
+	attempts, err := s.Attempts.List(ctx, repository.LoginAttemptFilter{
+		UserID:  user.ID.Hex(),
+		Results: []model.LoginResult{model.LoginFailed, model.LoginSucceeded, model.LoginReset},
+		Since:   now.Add(-s.Policy.Window),
+		Limit:   int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	failures := []*model.LoginAttempt{}
+	for _, attempt := range attempts {
+		if attempt.Result != model.LoginFailed {
+			break
+		}
+		failures = append(failures, attempt)
+	}
+	return failures, nil
+}
+
+func (s *LockoutService) record(ctx context.Context, user *model.PrivateUserModel, sourceIP string, result model.LoginResult, now time.Time) error {
+	attempt := &model.LoginAttempt{
+		SourceIP:  sourceIP,
+		Result:    result,
+		CreatedAt: now,
+	}
+	if user != nil {
+		attempt.UserID = user.ID.Hex()
+		attempt.TenantID = user.TenantID
+	}
+	return s.Attempts.Add(ctx, attempt)
+}
+
+// audit records a lockout decision. Like other mutations, a failure to audit is only logged.
+func (s *LockoutService) audit(ctx context.Context, action string, before *model.PrivateUserModel, after *model.PrivateUserModel) {
+	if s.Audit == nil {
+		return
+	}
+	if err := s.Audit.Record(ctx, action, before.ID.Hex(), before, after); err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to audit", "action", action, "user_id", before.ID.Hex(), "error", err)
+	}
+}
+
+// Ensure LockoutService implements ILockoutService
+var _ ILockoutService = (*LockoutService)(nil)
@@ -0,0 +1,341 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MockILoginAttemptRepository struct {
+	mock.Mock
+}
+
+// Add implements repository.ILoginAttemptRepository.
+func (m *MockILoginAttemptRepository) Add(ctx context.Context, attempt *model.LoginAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+// List implements repository.ILoginAttemptRepository.
+func (m *MockILoginAttemptRepository) List(ctx context.Context, filter repository.LoginAttemptFilter) ([]*model.LoginAttempt, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.LoginAttempt), args.Error(1)
+}
+
+// DeleteByUser implements repository.ILoginAttemptRepository.
+func (m *MockILoginAttemptRepository) DeleteByUser(ctx context.Context, userID string) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Ensure that MockILoginAttemptRepository implements ILoginAttemptRepository.
+var _ repository.ILoginAttemptRepository = &MockILoginAttemptRepository{}
+
+var lockoutNow = time.Date(2023, 11, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestLockoutService(attempts *MockILoginAttemptRepository, users *MockIUserRepository) *service.LockoutService {
+	lockoutService := service.NewLockoutService(attempts, users, service.LockoutPolicy{
+		Window:          15 * time.Minute,
+		Threshold:       3,
+		LockoutDuration: 10 * time.Minute,
+		DelayAfter:      2,
+		BaseDelay:       time.Second,
+		MaxDelay:        4 * time.Second,
+		IPThreshold:     5,
+	}, testLogger)
+	lockoutService.Now = func() time.Time { return lockoutNow }
+	return lockoutService
+}
+
+// failures returns n failed attempts, newest first, one minute apart.
+func failures(n int) []*model.LoginAttempt {
+	attempts := []*model.LoginAttempt{}
+	for i := 0; i < n; i++ {
+		attempts = append(attempts, &model.LoginAttempt{Result: model.LoginFailed, CreatedAt: lockoutNow.Add(-time.Duration(i+1) * time.Minute)})
+	}
+	return attempts
+}
+
+func isUserFilter(filter repository.LoginAttemptFilter) bool {
+	return filter.UserID != ""
+}
+
+func isIPFilter(filter repository.LoginAttemptFilter) bool {
+	return filter.SourceIP != ""
+}
+
+func TestLockoutAllow_NoFailures(t *testing.T) {
+	// Arrange
+	mockAttempts := new(MockILoginAttemptRepository)
+	lockoutService := newTestLockoutService(mockAttempts, new(MockIUserRepository))
+	ctx := context.Background()
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID()}
+
+	mockAttempts.On("List", ctx, mock.MatchedBy(isIPFilter)).Return([]*model.LoginAttempt{}, nil)
+	mockAttempts.On("List", ctx, mock.MatchedBy(isUserFilter)).Return([]*model.LoginAttempt{}, nil)
+
+	// Act
+	err := lockoutService.Allow(ctx, user, "10.0.0.1")
+
+	// Assert
+	assert.NoError(t, err)
+	mockAttempts.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+}
+
+func TestLockoutAllow_LockedAccountIsRejected(t *testing.T) {
+	// Arrange
+	mockAttempts := new(MockILoginAttemptRepository)
+	lockoutService := newTestLockoutService(mockAttempts, new(MockIUserRepository))
+	ctx := context.Background()
+	until := lockoutNow.Add(5 * time.Minute)
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID(), LockedUntil: &until}
+
+	mockAttempts.On("Add", ctx, mock.MatchedBy(func(attempt *model.LoginAttempt) bool {
+		return attempt.Result == model.LoginRejected && attempt.UserID == user.ID.Hex() && attempt.SourceIP == "10.0.0.1"
+	})).Return(nil)
+
+	// Act
+	err := lockoutService.Allow(ctx, user, "10.0.0.1")
+
+	// Assert
+	var lockedErr *service.AccountLockedError
+	assert.ErrorAs(t, err, &lockedErr)
+	assert.ErrorIs(t, err, service.ErrAccountLocked)
+	assert.Equal(t, until, lockedErr.Until)
+	mockAttempts.AssertExpectations(t)
+}
+
+func TestLockoutAllow_ExpiredLockIsIgnored(t *testing.T) {
+	// Arrange
+	mockAttempts := new(MockILoginAttemptRepository)
+	lockoutService := newTestLockoutService(mockAttempts, new(MockIUserRepository))
+	ctx := context.Background()
+	until := lockoutNow.Add(-time.Second)
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID(), LockedUntil: &until}
+
+	mockAttempts.On("List", ctx, mock.Anything).Return([]*model.LoginAttempt{}, nil)
+
+	// Act
+	err := lockoutService.Allow(ctx, user, "")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestLockoutAllow_ProgressiveDelay(t *testing.T) {
+	// Arrange
+	mockAttempts := new(MockILoginAttemptRepository)
+	lockoutService := newTestLockoutService(mockAttempts, new(MockIUserRepository))
+	ctx := context.Background()
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID()}
+	recent := []*model.LoginAttempt{
+		{Result: model.LoginFailed, CreatedAt: lockoutNow.Add(-500 * time.Millisecond)},
+		{Result: model.LoginFailed, CreatedAt: lockoutNow.Add(-time.Minute)},
+	}
+
+	mockAttempts.On("List", ctx, mock.MatchedBy(isUserFilter)).Return(recent, nil)
+	mockAttempts.On("Add", ctx, mock.MatchedBy(func(attempt *model.LoginAttempt) bool {
+		return attempt.Result == model.LoginRejected
+	})).Return(nil)
+
+	// Act
+	err := lockoutService.Allow(ctx, user, "")
+
+	// Assert
+	var throttledErr *service.LoginThrottledError
+	assert.ErrorAs(t, err, &throttledErr)
+	assert.Equal(t, 500*time.Millisecond, throttledErr.RetryAfter)
+	mockAttempts.AssertExpectations(t)
+}
+
+func TestLockoutAllow_SuccessEndsFailureRun(t *testing.T) {
+	// Arrange
+	mockAttempts := new(MockILoginAttemptRepository)
+	lockoutService := newTestLockoutService(mockAttempts, new(MockIUserRepository))
+	ctx := context.Background()
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID()}
+	recent := []*model.LoginAttempt{
+		{Result: model.LoginSucceeded, CreatedAt: lockoutNow.Add(-100 * time.Millisecond)},
+		{Result: model.LoginFailed, CreatedAt: lockoutNow.Add(-200 * time.Millisecond)},
+		{Result: model.LoginFailed, CreatedAt: lockoutNow.Add(-300 * time.Millisecond)},
+	}
+
+	mockAttempts.On("List", ctx, mock.MatchedBy(isUserFilter)).Return(recent, nil)
+
+	// Act
+	err := lockoutService.Allow(ctx, user, "")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestLockoutAllow_ThrottlesSourceIP(t *testing.T) {
+	// Arrange
+	mockAttempts := new(MockILoginAttemptRepository)
+	lockoutService := newTestLockoutService(mockAttempts, new(MockIUserRepository))
+	ctx := context.Background()
+
+	mockAttempts.On("List", ctx, repository.LoginAttemptFilter{
+		SourceIP: "10.0.0.1",
+		Results:  []model.LoginResult{model.LoginFailed},
+		Since:    lockoutNow.Add(-15 * time.Minute),
+		Limit:    5,
+	}).Return(failures(5), nil)
+	mockAttempts.On("Add", ctx, mock.MatchedBy(func(attempt *model.LoginAttempt) bool {
+		return attempt.Result == model.LoginRejected && attempt.UserID == ""
+	})).Return(nil)
+
+	// Act
+	err := lockoutService.Allow(ctx, nil, "10.0.0.1")
+
+	// Assert
+	var throttledErr *service.LoginThrottledError
+	assert.ErrorAs(t, err, &throttledErr)
+	assert.Equal(t, 10*time.Minute, throttledErr.RetryAfter)
+	mockAttempts.AssertExpectations(t)
+}
+
+func TestLockoutRecordFailure_BelowThreshold(t *testing.T) {
+	// Arrange
+	mockAttempts := new(MockILoginAttemptRepository)
+	mockUsers := new(MockIUserRepository)
+	lockoutService := newTestLockoutService(mockAttempts, mockUsers)
+	ctx := context.Background()
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID()}
+
+	mockAttempts.On("Add", ctx, mock.MatchedBy(func(attempt *model.LoginAttempt) bool {
+		return attempt.Result == model.LoginFailed && attempt.CreatedAt == lockoutNow
+	})).Return(nil)
+	mockAttempts.On("List", ctx, mock.MatchedBy(isUserFilter)).Return(failures(2), nil)
+
+	// Act
+	err := lockoutService.RecordFailure(ctx, user, "10.0.0.1")
+
+	// Assert
+	assert.NoError(t, err)
+	mockAttempts.AssertExpectations(t)
+	mockUsers.AssertNotCalled(t, "SetLockedUntil", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLockoutRecordFailure_LocksAndAuditsAtThreshold(t *testing.T) {
+	// Arrange
+	mockAttempts := new(MockILoginAttemptRepository)
+	mockUsers := new(MockIUserRepository)
+	mockAudit := new(MockIAuditService)
+	lockoutService := newTestLockoutService(mockAttempts, mockUsers)
+	lockoutService.Audit = mockAudit
+	ctx := context.Background()
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID()}
+	until := lockoutNow.Add(10 * time.Minute)
+
+	mockAttempts.On("Add", ctx, mock.Anything).Return(nil)
+	mockAttempts.On("List", ctx, mock.MatchedBy(isUserFilter)).Return(failures(3), nil)
+	mockUsers.On("SetLockedUntil", ctx, user.ID.Hex(), &until).Return(nil)
+	mockAudit.On("Record", ctx, model.AuditActionLockout, user.ID.Hex(), user, mock.MatchedBy(func(after *model.PrivateUserModel) bool {
+		return after.LockedUntil != nil && after.LockedUntil.Equal(until)
+	})).Return(nil)
+
+	// Act
+	err := lockoutService.RecordFailure(ctx, user, "10.0.0.1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, user.LockedUntil)
+	mockUsers.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestLockoutRecordFailure_UnknownUserOnlyRecorded(t *testing.T) {
+	// Arrange
+	mockAttempts := new(MockILoginAttemptRepository)
+	lockoutService := newTestLockoutService(mockAttempts, new(MockIUserRepository))
+	ctx := context.Background()
+
+	mockAttempts.On("Add", ctx, mock.MatchedBy(func(attempt *model.LoginAttempt) bool {
+		return attempt.UserID == "" && attempt.SourceIP == "10.0.0.1"
+	})).Return(nil)
+
+	// Act
+	err := lockoutService.RecordFailure(ctx, nil, "10.0.0.1")
+
+	// Assert
+	assert.NoError(t, err)
+	mockAttempts.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestLockoutUnlock(t *testing.T) {
+	// Arrange
+	mockAttempts := new(MockILoginAttemptRepository)
+	mockUsers := new(MockIUserRepository)
+	mockAudit := new(MockIAuditService)
+	lockoutService := newTestLockoutService(mockAttempts, mockUsers)
+	lockoutService.Audit = mockAudit
+	ctx := context.Background()
+	until := lockoutNow.Add(5 * time.Minute)
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID(), LockedUntil: &until}
+
+	mockUsers.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	mockUsers.On("SetLockedUntil", ctx, user.ID.Hex(), (*time.Time)(nil)).Return(nil)
+	mockAttempts.On("Add", ctx, mock.MatchedBy(func(attempt *model.LoginAttempt) bool {
+		return attempt.Result == model.LoginReset && attempt.UserID == user.ID.Hex()
+	})).Return(nil)
+	mockAudit.On("Record", ctx, model.AuditActionUnlock, user.ID.Hex(), user, mock.MatchedBy(func(after *model.PrivateUserModel) bool {
+		return after.LockedUntil == nil
+	})).Return(nil)
+
+	// Act
+	err := lockoutService.Unlock(ctx, user.ID.Hex())
+
+	// Assert
+	assert.NoError(t, err)
+	mockUsers.AssertExpectations(t)
+	mockAttempts.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestLockoutUnlock_FailsWhenUserMissing(t *testing.T) {
+	// Arrange
+	mockUsers := new(MockIUserRepository)
+	lockoutService := newTestLockoutService(new(MockILoginAttemptRepository), mockUsers)
+	ctx := context.Background()
+	notFound := errors.New("not found")
+
+	mockUsers.On("FindById", ctx, "missing").Return(nil, notFound)
+
+	// Act
+	err := lockoutService.Unlock(ctx, "missing")
+
+	// Assert
+	assert.ErrorIs(t, err, notFound)
+	mockUsers.AssertNotCalled(t, "SetLockedUntil", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLockoutHistory_ClampsLimit(t *testing.T) {
+	// Arrange
+	mockAttempts := new(MockILoginAttemptRepository)
+	lockoutService := newTestLockoutService(mockAttempts, new(MockIUserRepository))
+	ctx := context.Background()
+
+	mockAttempts.On("List", ctx, repository.LoginAttemptFilter{UserID: "user", Limit: service.DefaultLoginHistoryLimit}).Return([]*model.LoginAttempt{}, nil)
+	mockAttempts.On("List", ctx, repository.LoginAttemptFilter{UserID: "user", Limit: service.MaxLoginHistoryLimit}).Return([]*model.LoginAttempt{}, nil)
+
+	// Act
+	_, defaultErr := lockoutService.History(ctx, "user", 0)
+	_, clampedErr := lockoutService.History(ctx, "user", 10000)
+
+	// Assert
+	assert.NoError(t, defaultErr)
+	assert.NoError(t, clampedErr)
+	mockAttempts.AssertExpectations(t)
+}
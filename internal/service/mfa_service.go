@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/mfa"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/secretbox"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+)
+
+// DefaultMFAIssuer is the name authenticator apps show next to the account.
+const DefaultMFAIssuer = "BitBridge"
+
+const (
+	MFAMethodTOTP         = "totp"
+	MFAMethodRecoveryCode = "recovery_code"
+)
+
+var (
+	ErrMFAAlreadyEnabled = errors.New("multi-factor authentication is already enabled")
+	ErrMFANotEnabled     = errors.New("multi-factor authentication is not enabled")
+	// ErrMFANotEnrolled is returned when confirming without a pending enrolment.
+	ErrMFANotEnrolled = errors.New("no multi-factor enrolment is pending")
+	// ErrInvalidMFACode is returned for a wrong, expired or already used code alike.
+	ErrInvalidMFACode = errors.New("invalid multi-factor code")
+)
+
+type IMFAService interface {
+	Enroll(ctx context.Context, id string) (*publicModel.MFAEnrollmentModel, error)
+	Confirm(ctx context.Context, id string, code string) (*publicModel.MFARecoveryCodesModel, error)
+	Verify(ctx context.Context, id string, verification *publicModel.VerifyMFAModel) (*publicModel.MFAVerificationModel, error)
+	Disable(ctx context.Context, id string, code string) error
+}
+
+type MFAService struct {
+	Users repository.IUserRepository
+	// Secrets encrypts TOTP secrets at rest, bound to the id of the user they belong to
+	Secrets *secretbox.Box
+	TOTP    mfa.TOTP
+	Issuer  string
+	Audit   IAuditService
+	// Lockout, when set, counts wrong codes towards the same lockout as wrong passwords
+	Lockout ILockoutService
+	Now     func() time.Time
+	Logger  *slog.Logger
+}
+
+// NewMFAService creates a new instance of MFAService.
+func NewMFAService(users repository.IUserRepository, secrets *secretbox.Box, logger *slog.Logger) *MFAService {
+	return &MFAService{
+		Users:   users,
+		Secrets: secrets,
+		TOTP:    mfa.DefaultTOTP(),
+		Issuer:  DefaultMFAIssuer,
+		Now:     time.Now,
+		Logger:  logger,
+	}
+}
+
+// Enroll implements IMFAService.
+// It starts an enrolment that takes effect once confirmed, replacing any earlier unconfirmed one.
+func (s *MFAService) Enroll(ctx context.Context, id string) (*publicModel.MFAEnrollmentModel, error) {
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := mfa.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.Secrets.Seal([]byte(secret), []byte(user.ID.Hex()))
+	if err != nil {
+		return nil, err
+	}
+
+	state := &model.MFAState{Secret: sealed, EnrolledAt: s.Now()}
+	if err := s.Users.SetMFA(ctx, id, false, state); err != nil {
+		return nil, err
+	}
+
+	return &publicModel.MFAEnrollmentModel{
+		Secret: secret,
+		URI:    s.TOTP.URI(s.Issuer, user.Username, secret),
+	}, nil
+}
+
+// Confirm implements IMFAService.
+// A first valid code enables MFA and issues recovery codes, which are returned only this once.
+func (s *MFAService) Confirm(ctx context.Context, id string, code string) (*publicModel.MFARecoveryCodesModel, error) {
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.MFA == nil {
+		return nil, ErrMFANotEnrolled
+	}
+
+	step, err := s.validateTOTP(user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := mfa.GenerateRecoveryCodes(mfa.RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.Now()
+	state := *user.MFA
+	state.ConfirmedAt = &now
+	state.LastStep = step
+	state.RecoveryCodes = make([]string, len(codes))
+	for i, code := range codes {
+		state.RecoveryCodes[i] = mfa.HashRecoveryCode(code)
+	}
+	if err := s.Users.SetMFA(ctx, id, true, &state); err != nil {
+		return nil, err
+	}
+
+	enabled := *user
+	enabled.MFAEnabled = true
+	enabled.MFA = &state
+	s.audit(ctx, model.AuditActionMFAEnable, user, &enabled)
+
+	return &publicModel.MFARecoveryCodesModel{RecoveryCodes: codes}, nil
+}
+
+// Verify implements IMFAService.
+// It accepts a TOTP code or an unused recovery code, and either can be used only once.
+func (s *MFAService) Verify(ctx context.Context, id string, verification *publicModel.VerifyMFAModel) (*publicModel.MFAVerificationModel, error) {
+	sourceIP := verification.SourceIP
+	if sourceIP == "" {
+		sourceIP = ActorFromContext(ctx).SourceIP
+	}
+
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MFAEnabled {
+		return nil, ErrMFANotEnabled
+	}
+
+	if s.Lockout != nil {
+		if err := s.Lockout.Allow(ctx, user, sourceIP); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := s.checkCode(ctx, user, verification.Code)
+	if s.Lockout != nil {
+		switch {
+		case errors.Is(err, ErrInvalidMFACode):
+			if err := s.Lockout.RecordFailure(ctx, user, sourceIP); err != nil {
+				s.Logger.ErrorContext(ctx, "Failed to record failed second factor", "user_id", user.ID.Hex(), "error", err)
+			}
+		case err == nil:
+			if err := s.Lockout.RecordSuccess(ctx, user, sourceIP); err != nil {
+				s.Logger.ErrorContext(ctx, "Failed to record sign-in", "user_id", user.ID.Hex(), "error", err)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Disable implements IMFAService.
+// Users disabling their own MFA must confirm with a current code. Administrators, who disable it
+// for users who lost their device, need none. A pending enrolment can always be cancelled.
+// Wrong codes count towards the lockout like failed verifications, so Disable cannot be used to
+// guess codes while sign-in is locked.
+func (s *MFAService) Disable(ctx context.Context, id string, code string) error {
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user.MFA == nil {
+		return ErrMFANotEnabled
+	}
+
+	if scope, ok := authz.ScopeFromContext(ctx); ok && scope.SelfOnly && user.MFAEnabled {
+		sourceIP := ActorFromContext(ctx).SourceIP
+		if s.Lockout != nil {
+			if err := s.Lockout.Allow(ctx, user, sourceIP); err != nil {
+				return err
+			}
+		}
+		if _, err := s.checkCode(ctx, user, code); err != nil {
+			if s.Lockout != nil && errors.Is(err, ErrInvalidMFACode) {
+				if err := s.Lockout.RecordFailure(ctx, user, sourceIP); err != nil {
+					s.Logger.ErrorContext(ctx, "Failed to record failed second factor", "user_id", user.ID.Hex(), "error", err)
+				}
+			}
+			return err
+		}
+	}
+
+	if err := s.Users.SetMFA(ctx, id, false, nil); err != nil {
+		return err
+	}
+
+	if user.MFAEnabled {
+		disabled := *user
+		disabled.MFAEnabled = false
+		disabled.MFA = nil
+		s.audit(ctx, model.AuditActionMFADisable, user, &disabled)
+	}
+
+	return nil
+}
+
+func (s *MFAService) findUser(ctx context.Context, id string) (*model.PrivateUserModel, error) {
+	if err := authz.CheckTarget(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.Users.FindById(ctx, id)
+}
+
+// checkCode verifies and uses up a code of an enabled enrolment.
+func (s *MFAService) checkCode(ctx context.Context, user *model.PrivateUserModel, code string) (*publicModel.MFAVerificationModel, error) {
+	if mfa.IsRecoveryCode(code) {
+		if mfa.MatchRecoveryCode(user.MFA.RecoveryCodes, code) < 0 {
+			return nil, ErrInvalidMFACode
+		}
+		consumed, err := s.Users.ConsumeRecoveryCode(ctx, user.ID.Hex(), mfa.HashRecoveryCode(code))
+		if err != nil {
+			return nil, err
+		}
+		if !consumed {
+			return nil, ErrInvalidMFACode
+		}
+		return &publicModel.MFAVerificationModel{
+			Method:                 MFAMethodRecoveryCode,
+			RemainingRecoveryCodes: len(user.MFA.RecoveryCodes) - 1,
+		}, nil
+	}
+
+	step, err := s.validateTOTP(user, code)
+	if err != nil {
+		return nil, err
+	}
+	advanced, err := s.Users.AdvanceMFAStep(ctx, user.ID.Hex(), step)
+	if err != nil {
+		return nil, err
+	}
+	if !advanced {
+		return nil, ErrInvalidMFACode
+	}
+	return &publicModel.MFAVerificationModel{
+		Method:                 MFAMethodTOTP,
+		RemainingRecoveryCodes: len(user.MFA.RecoveryCodes),
+	}, nil
+}
+
+// validateTOTP returns the time step a TOTP code is valid for, refusing steps already used.
+func (s *MFAService) validateTOTP(user *model.PrivateUserModel, code string) (int64, error) {
+	secret, err := s.Secrets.Open(user.MFA.Secret, []byte(user.ID.Hex()))
+	if err != nil {
+		return 0, err
+	}
+
+	step, ok, err := s.TOTP.Validate(string(secret), code, s.Now(), user.MFA.LastStep)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, ErrInvalidMFACode
+	}
+	return step, nil
+}
+
+// audit records an MFA change. Like other mutations, a failure to audit is only logged.
+func (s *MFAService) audit(ctx context.Context, action string, before *model.PrivateUserModel, after *model.PrivateUserModel) {
+	if s.Audit == nil {
+		return
+	}
+	if err := s.Audit.Record(ctx, action, before.ID.Hex(), before, after); err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to audit", "action", action, "user_id", before.ID.Hex(), "error", err)
+	}
+}
+
+// Ensure MFAService implements IMFAService
+var _ IMFAService = (*MFAService)(nil)
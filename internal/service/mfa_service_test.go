@@ -0,0 +1,380 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/mfa"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/secretbox"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+var mfaNow = time.Unix(1700000000, 0)
+
+func newTestMFAService(users *MockIUserRepository) *service.MFAService {
+	key, _ := secretbox.DeriveKey("test secret", "mfa")
+	box, _ := secretbox.NewBox(key)
+	mfaService := service.NewMFAService(users, box, testLogger)
+	mfaService.Now = func() time.Time { return mfaNow }
+	return mfaService
+}
+
+// newMFAUser returns a user with an enrolment of testTOTPSecret and the given recovery codes.
+func newMFAUser(t *testing.T, mfaService *service.MFAService, enabled bool, recoveryCodes ...string) *model.PrivateUserModel {
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test", MFAEnabled: enabled}
+	sealed, err := mfaService.Secrets.Seal([]byte(testTOTPSecret), []byte(user.ID.Hex()))
+	assert.NoError(t, err)
+	user.MFA = &model.MFAState{Secret: sealed, EnrolledAt: mfaNow.Add(-time.Hour)}
+	for _, code := range recoveryCodes {
+		user.MFA.RecoveryCodes = append(user.MFA.RecoveryCodes, mfa.HashRecoveryCode(code))
+	}
+	return user
+}
+
+func currentCode(t *testing.T) string {
+	totp := mfa.DefaultTOTP()
+	code, err := totp.Code(testTOTPSecret, totp.Step(mfaNow))
+	assert.NoError(t, err)
+	return code
+}
+
+func TestMFAEnroll(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mfaService := newTestMFAService(mockRepository)
+	ctx := context.Background()
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test"}
+	var stored *model.MFAState
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	mockRepository.On("SetMFA", ctx, user.ID.Hex(), false, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(3).(*model.MFAState)
+	}).Return(nil)
+
+	// Act
+	enrollment, err := mfaService.Enroll(ctx, user.ID.Hex())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, enrollment.URI, "otpauth://totp/BitBridge:test?")
+	assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+	if assert.NotNil(t, stored) {
+		assert.NotContains(t, stored.Secret, enrollment.Secret)
+		secret, err := mfaService.Secrets.Open(stored.Secret, []byte(user.ID.Hex()))
+		assert.NoError(t, err)
+		assert.Equal(t, enrollment.Secret, string(secret))
+	}
+}
+
+func TestMFAEnroll_AlreadyEnabled(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mfaService := newTestMFAService(mockRepository)
+	ctx := context.Background()
+	user := newMFAUser(t, mfaService, true)
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+
+	// Act
+	_, err := mfaService.Enroll(ctx, user.ID.Hex())
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrMFAAlreadyEnabled)
+	mockRepository.AssertNotCalled(t, "SetMFA", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMFAEnroll_SelfScopeRejectsOtherUsers(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mfaService := newTestMFAService(mockRepository)
+	ctx := authz.WithScope(context.Background(), authz.Scope{UserID: "self", SelfOnly: true})
+
+	// Act
+	_, err := mfaService.Enroll(ctx, "other")
+
+	// Assert
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+	mockRepository.AssertNotCalled(t, "FindById", mock.Anything, mock.Anything)
+}
+
+func TestMFAConfirm_EnablesAndIssuesRecoveryCodes(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockAudit := new(MockIAuditService)
+	mfaService := newTestMFAService(mockRepository)
+	mfaService.Audit = mockAudit
+	ctx := context.Background()
+	user := newMFAUser(t, mfaService, false)
+	var stored *model.MFAState
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	mockRepository.On("SetMFA", ctx, user.ID.Hex(), true, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(3).(*model.MFAState)
+	}).Return(nil)
+	mockAudit.On("Record", ctx, model.AuditActionMFAEnable, user.ID.Hex(), user, mock.MatchedBy(func(after *model.PrivateUserModel) bool {
+		return after.MFAEnabled
+	})).Return(nil)
+
+	// Act
+	result, err := mfaService.Confirm(ctx, user.ID.Hex(), currentCode(t))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.RecoveryCodes, mfa.RecoveryCodeCount)
+	if assert.NotNil(t, stored) {
+		assert.Equal(t, mfa.DefaultTOTP().Step(mfaNow), stored.LastStep)
+		assert.Equal(t, mfaNow, *stored.ConfirmedAt)
+		assert.Equal(t, 0, mfa.MatchRecoveryCode(stored.RecoveryCodes, result.RecoveryCodes[0]))
+		assert.NotContains(t, stored.RecoveryCodes, result.RecoveryCodes[0])
+	}
+	assert.False(t, user.MFAEnabled)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestMFAConfirm_Errors(t *testing.T) {
+	mfaService := newTestMFAService(nil)
+	tests := map[string]struct {
+		user     *model.PrivateUserModel
+		code     string
+		expected error
+	}{
+		"wrong code":    {user: newMFAUser(t, mfaService, false), code: "000000", expected: service.ErrInvalidMFACode},
+		"not enrolled":  {user: &model.PrivateUserModel{ID: primitive.NewObjectID()}, code: currentCode(t), expected: service.ErrMFANotEnrolled},
+		"already on":    {user: newMFAUser(t, mfaService, true), code: currentCode(t), expected: service.ErrMFAAlreadyEnabled},
+		"recovery code": {user: newMFAUser(t, mfaService, false), code: "abcde-fghjk", expected: service.ErrInvalidMFACode},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			mockRepository := new(MockIUserRepository)
+			mfaService.Users = mockRepository
+			ctx := context.Background()
+			mockRepository.On("FindById", ctx, test.user.ID.Hex()).Return(test.user, nil)
+
+			// Act
+			_, err := mfaService.Confirm(ctx, test.user.ID.Hex(), test.code)
+
+			// Assert
+			assert.ErrorIs(t, err, test.expected)
+			mockRepository.AssertNotCalled(t, "SetMFA", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMFAVerify_TOTP(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockLockout := new(MockILockoutService)
+	mfaService := newTestMFAService(mockRepository)
+	mfaService.Lockout = mockLockout
+	ctx := context.Background()
+	user := newMFAUser(t, mfaService, true, "abcde-fghjk")
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	mockRepository.On("AdvanceMFAStep", ctx, user.ID.Hex(), mfa.DefaultTOTP().Step(mfaNow)).Return(true, nil)
+	mockLockout.On("Allow", ctx, user, "10.0.0.1").Return(nil)
+	mockLockout.On("RecordSuccess", ctx, user, "10.0.0.1").Return(nil)
+
+	// Act
+	result, err := mfaService.Verify(ctx, user.ID.Hex(), &publicModel.VerifyMFAModel{Code: currentCode(t), SourceIP: "10.0.0.1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, service.MFAMethodTOTP, result.Method)
+	assert.Equal(t, 1, result.RemainingRecoveryCodes)
+	mockLockout.AssertExpectations(t)
+}
+
+func TestMFAVerify_ReplayedTOTP(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockLockout := new(MockILockoutService)
+	mfaService := newTestMFAService(mockRepository)
+	mfaService.Lockout = mockLockout
+	ctx := context.Background()
+	user := newMFAUser(t, mfaService, true)
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	mockRepository.On("AdvanceMFAStep", ctx, user.ID.Hex(), mock.Anything).Return(false, nil)
+	mockLockout.On("Allow", ctx, user, "").Return(nil)
+	mockLockout.On("RecordFailure", ctx, user, "").Return(nil)
+
+	// Act
+	_, err := mfaService.Verify(ctx, user.ID.Hex(), &publicModel.VerifyMFAModel{Code: currentCode(t)})
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrInvalidMFACode)
+	mockLockout.AssertExpectations(t)
+	mockLockout.AssertNotCalled(t, "RecordSuccess", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMFAVerify_RecoveryCode(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mfaService := newTestMFAService(mockRepository)
+	ctx := context.Background()
+	user := newMFAUser(t, mfaService, true, "abcde-fghjk", "mnpqr-stuvw")
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	mockRepository.On("ConsumeRecoveryCode", ctx, user.ID.Hex(), mfa.HashRecoveryCode("mnpqr-stuvw")).Return(true, nil)
+
+	// Act
+	result, err := mfaService.Verify(ctx, user.ID.Hex(), &publicModel.VerifyMFAModel{Code: "MNPQR STUVW"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, service.MFAMethodRecoveryCode, result.Method)
+	assert.Equal(t, 1, result.RemainingRecoveryCodes)
+	mockRepository.AssertNotCalled(t, "AdvanceMFAStep", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMFAVerify_UnknownRecoveryCode(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mfaService := newTestMFAService(mockRepository)
+	ctx := context.Background()
+	user := newMFAUser(t, mfaService, true, "abcde-fghjk")
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+
+	// Act
+	_, err := mfaService.Verify(ctx, user.ID.Hex(), &publicModel.VerifyMFAModel{Code: "mnpqr-stuvw"})
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrInvalidMFACode)
+	mockRepository.AssertNotCalled(t, "ConsumeRecoveryCode", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMFAVerify_RefusedDuringLockout(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockLockout := new(MockILockoutService)
+	mfaService := newTestMFAService(mockRepository)
+	mfaService.Lockout = mockLockout
+	ctx := context.Background()
+	user := newMFAUser(t, mfaService, true)
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	mockLockout.On("Allow", ctx, user, "").Return(&service.AccountLockedError{Until: mfaNow.Add(time.Minute)})
+
+	// Act
+	_, err := mfaService.Verify(ctx, user.ID.Hex(), &publicModel.VerifyMFAModel{Code: currentCode(t)})
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrAccountLocked)
+	mockRepository.AssertNotCalled(t, "AdvanceMFAStep", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMFAVerify_NotEnabled(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mfaService := newTestMFAService(mockRepository)
+	ctx := context.Background()
+	user := newMFAUser(t, mfaService, false)
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+
+	// Act
+	_, err := mfaService.Verify(ctx, user.ID.Hex(), &publicModel.VerifyMFAModel{Code: currentCode(t)})
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrMFANotEnabled)
+}
+
+func TestMFADisable_SelfRequiresCode(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mfaService := newTestMFAService(mockRepository)
+	user := newMFAUser(t, mfaService, true)
+	ctx := authz.WithScope(context.Background(), authz.Scope{UserID: user.ID.Hex(), SelfOnly: true})
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	mockRepository.On("AdvanceMFAStep", ctx, user.ID.Hex(), mock.Anything).Return(true, nil)
+	mockRepository.On("SetMFA", ctx, user.ID.Hex(), false, (*model.MFAState)(nil)).Return(nil)
+
+	// Act
+	missingErr := mfaService.Disable(ctx, user.ID.Hex(), "")
+	err := mfaService.Disable(ctx, user.ID.Hex(), currentCode(t))
+
+	// Assert
+	assert.ErrorIs(t, missingErr, service.ErrInvalidMFACode)
+	assert.NoError(t, err)
+	mockRepository.AssertNumberOfCalls(t, "SetMFA", 1)
+}
+
+func TestMFADisable_CountsFailuresTowardsLockout(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockLockout := new(MockILockoutService)
+	mfaService := newTestMFAService(mockRepository)
+	mfaService.Lockout = mockLockout
+	user := newMFAUser(t, mfaService, true)
+	ctx := authz.WithScope(context.Background(), authz.Scope{UserID: user.ID.Hex(), SelfOnly: true})
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	mockLockout.On("Allow", ctx, user, "").Return(nil).Once()
+	mockLockout.On("RecordFailure", ctx, user, "").Return(nil).Once()
+	mockLockout.On("Allow", ctx, user, "").Return(&service.AccountLockedError{Until: mfaNow.Add(time.Minute)})
+
+	// Act
+	wrongErr := mfaService.Disable(ctx, user.ID.Hex(), "000000")
+	lockedErr := mfaService.Disable(ctx, user.ID.Hex(), currentCode(t))
+
+	// Assert
+	assert.ErrorIs(t, wrongErr, service.ErrInvalidMFACode)
+	var lockedError *service.AccountLockedError
+	assert.ErrorAs(t, lockedErr, &lockedError)
+	mockLockout.AssertExpectations(t)
+	mockRepository.AssertNotCalled(t, "AdvanceMFAStep", mock.Anything, mock.Anything, mock.Anything)
+	mockRepository.AssertNotCalled(t, "SetMFA", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMFADisable_AdminWithoutCode(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockAudit := new(MockIAuditService)
+	mfaService := newTestMFAService(mockRepository)
+	mfaService.Audit = mockAudit
+	user := newMFAUser(t, mfaService, true)
+	ctx := authz.WithScope(context.Background(), authz.Scope{UserID: "admin"})
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	mockRepository.On("SetMFA", ctx, user.ID.Hex(), false, (*model.MFAState)(nil)).Return(nil)
+	mockAudit.On("Record", ctx, model.AuditActionMFADisable, user.ID.Hex(), user, mock.MatchedBy(func(after *model.PrivateUserModel) bool {
+		return !after.MFAEnabled && after.MFA == nil
+	})).Return(nil)
+
+	// Act
+	err := mfaService.Disable(ctx, user.ID.Hex(), "")
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepository.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestMFADisable_NotEnrolled(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mfaService := newTestMFAService(mockRepository)
+	ctx := context.Background()
+	user := &model.PrivateUserModel{ID: primitive.NewObjectID()}
+
+	mockRepository.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+
+	// Act
+	err := mfaService.Disable(ctx, user.ID.Hex(), "")
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrMFANotEnabled)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+)
+
+// DefaultUsernameHold is how long the username of an erased user stays reserved.
+const DefaultUsernameHold = 30 * 24 * time.Hour
+
+// ErrUserErased is returned when erasing a user that was already erased.
+var ErrUserErased = errors.New("user has already been erased")
+
+// IPrivacyService answers data subject requests.
+type IPrivacyService interface {
+	Export(ctx context.Context, id string) (*model.UserDataExport, error)
+	Erase(ctx context.Context, id string) (*publicModel.ErasureReceiptModel, error)
+}
+
+type PrivacyService struct {
+	Users    repository.IUserRepository
+	AuditLog repository.IAuditRepository
+	// Logins, when set, adds login attempts to exports and deletes them on erasure
+	Logins repository.ILoginAttemptRepository
+	// Audit records exports and erasures
+	Audit      IAuditService
+	Outbox     repository.IOutboxRepository
+	Transactor repository.ITransactor
+	// ErasureKey keys the erasure references handed out in receipts
+	ErasureKey []byte
+	// UsernameHold is how long an erased user's username stays reserved before it is released
+	UsernameHold time.Duration
+	Now          func() time.Time
+	Logger       *slog.Logger
+}
+
+// NewPrivacyService creates a new instance of PrivacyService.
+func NewPrivacyService(users repository.IUserRepository, auditLog repository.IAuditRepository, erasureKey []byte, logger *slog.Logger) *PrivacyService {
+	return &PrivacyService{
+		Users:        users,
+		AuditLog:     auditLog,
+		ErasureKey:   erasureKey,
+		UsernameHold: DefaultUsernameHold,
+		Now:          time.Now,
+		Logger:       logger,
+	}
+}
+
+// Export implements IPrivacyService.
+// The export is audited like a private lookup, and fails when the audit record cannot be written.
+func (s *PrivacyService) Export(ctx context.Context, id string) (*model.UserDataExport, error) {
+	if err := authz.CheckTarget(ctx, id); err != nil {
+		return nil, err
+	}
+
+	user, err := s.Users.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	auditEvents, err := s.AuditLog.List(ctx, repository.AuditFilter{TenantID: user.TenantID, TargetUserID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	loginAttempts := []*model.LoginAttempt{}
+	if s.Logins != nil {
+		if loginAttempts, err = s.Logins.List(ctx, repository.LoginAttemptFilter{UserID: id}); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.Audit != nil {
+		if err := s.Audit.Record(ctx, model.AuditActionExport, id, nil, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	exported := *user
+	if exported.Hash != "" {
+		exported.Hash = model.RedactedValue
+	}
+
+	return &model.UserDataExport{
+		ExportedAt:    s.Now().UTC(),
+		User:          &exported,
+		AuditEvents:   auditEvents,
+		LoginAttempts: loginAttempts,
+	}, nil
+}
+
+// Erase implements IPrivacyService.
+// The user is replaced with a tombstone and their login attempts are deleted. Audit events are kept to
+// meet legal obligations, but are scrubbed of the user's personal data; see model.AuditEvent.Scrub.
+// The audit record of the erasure itself carries no changes, and is kept as evidence of the request.
+func (s *PrivacyService) Erase(ctx context.Context, id string) (*publicModel.ErasureReceiptModel, error) {
+	if err := authz.CheckTarget(ctx, id); err != nil {
+		return nil, err
+	}
+
+	user, err := s.Users.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsErased() {
+		return nil, ErrUserErased
+	}
+
+	// Login attempts and audit events go first, so that a failure leaves the user in place for a retry
+	if s.Logins != nil {
+		if _, err := s.Logins.DeleteByUser(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	now := s.Now()
+	if _, err := s.AuditLog.Scrub(ctx, id, now); err != nil {
+		return nil, err
+	}
+
+	tombstone := user.Tombstone(now, now.Add(s.UsernameHold), s.ErasureKey)
+	if err := s.withEvent(ctx, tombstone); err != nil {
+		return nil, err
+	}
+
+	if s.Audit != nil {
+		if err := s.Audit.Record(ctx, model.AuditActionErase, id, nil, nil); err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to audit", "action", model.AuditActionErase, "user_id", id, "error", err)
+		}
+	}
+
+	return &publicModel.ErasureReceiptModel{
+		UserID:            id,
+		ErasedAt:          *tombstone.ErasedAt,
+		UsernameReleaseAt: *tombstone.UsernameHeldUntil,
+		ErasureRef:        tombstone.ErasureRef,
+	}, nil
+}
+
+// withEvent erases the user and records a UserErased event, together when a transactor is configured.
+// The event already carries the username the user will have once it is released.
+func (s *PrivacyService) withEvent(ctx context.Context, tombstone *model.PrivateUserModel) error {
+	erase := func(ctx context.Context) error {
+		if err := s.Users.Erase(ctx, tombstone); err != nil {
+			return err
+		}
+		if s.Outbox == nil {
+			return nil
+		}
+
+		released := *tombstone
+		released.Username = model.ErasedUsername(tombstone.ID)
+		event, err := model.NewOutboxEvent(model.UserErasedEvent, &released)
+		if err != nil {
+			return err
+		}
+		return s.Outbox.Add(ctx, event)
+	}
+
+	if s.Outbox == nil || s.Transactor == nil {
+		return erase(ctx)
+	}
+	return s.Transactor.WithTransaction(ctx, erase)
+}
+
+// Ensure PrivacyService implements IPrivacyService
+var _ IPrivacyService = (*PrivacyService)(nil)
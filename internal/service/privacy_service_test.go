@@ -0,0 +1,215 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var privacyNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+var erasureKey = []byte("erasure key")
+
+func newTestPrivacyService() (*service.PrivacyService, *MockIUserRepository, *MockIAuditRepository, *MockILoginAttemptRepository, *MockIAuditService) {
+	users := new(MockIUserRepository)
+	auditLog := new(MockIAuditRepository)
+	logins := new(MockILoginAttemptRepository)
+	audit := new(MockIAuditService)
+
+	privacyService := service.NewPrivacyService(users, auditLog, erasureKey, testLogger)
+	privacyService.Logins = logins
+	privacyService.Audit = audit
+	privacyService.Now = func() time.Time { return privacyNow }
+	return privacyService, users, auditLog, logins, audit
+}
+
+func newPrivacyUser() *model.PrivateUserModel {
+	return &model.PrivateUserModel{
+		ID:       primitive.NewObjectID(),
+		TenantID: "acme",
+		Email:    "test@mail.com",
+		Username: "test",
+		Hash:     "$argon2id$hash",
+		Roles:    []string{"user"},
+		Profile:  &publicModel.ProfileModel{DisplayName: "Test User"},
+		MFA:      &model.MFAState{Secret: "v1:sealed"},
+	}
+}
+
+func TestPrivacyExport(t *testing.T) {
+	// Arrange
+	privacyService, users, auditLog, logins, audit := newTestPrivacyService()
+	ctx := context.Background()
+	user := newPrivacyUser()
+	events := []*model.AuditEvent{{Sequence: 1, Action: model.AuditActionCreate, TargetUserID: user.ID.Hex()}}
+	attempts := []*model.LoginAttempt{{UserID: user.ID.Hex(), SourceIP: "10.0.0.1", Result: model.LoginSucceeded}}
+
+	users.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	auditLog.On("List", ctx, repository.AuditFilter{TenantID: "acme", TargetUserID: user.ID.Hex()}).Return(events, nil)
+	logins.On("List", ctx, repository.LoginAttemptFilter{UserID: user.ID.Hex()}).Return(attempts, nil)
+	audit.On("Record", ctx, model.AuditActionExport, user.ID.Hex(), (*model.PrivateUserModel)(nil), (*model.PrivateUserModel)(nil)).Return(nil)
+
+	// Act
+	export, err := privacyService.Export(ctx, user.ID.Hex())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, privacyNow, export.ExportedAt)
+	assert.Equal(t, "test@mail.com", export.User.Email)
+	assert.Equal(t, model.RedactedValue, export.User.Hash)
+	assert.Equal(t, "$argon2id$hash", user.Hash)
+	assert.Equal(t, events, export.AuditEvents)
+	assert.Equal(t, attempts, export.LoginAttempts)
+	assert.Equal(t, "user-"+user.ID.Hex()+"-export.json", export.Filename())
+	audit.AssertExpectations(t)
+}
+
+func TestPrivacyExport_AuditFailure(t *testing.T) {
+	// Arrange
+	privacyService, users, auditLog, logins, audit := newTestPrivacyService()
+	ctx := context.Background()
+	user := newPrivacyUser()
+
+	users.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	auditLog.On("List", ctx, mock.Anything).Return([]*model.AuditEvent{}, nil)
+	logins.On("List", ctx, mock.Anything).Return([]*model.LoginAttempt{}, nil)
+	audit.On("Record", ctx, model.AuditActionExport, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("audit unavailable"))
+
+	// Act
+	export, err := privacyService.Export(ctx, user.ID.Hex())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, export)
+}
+
+func TestPrivacyExport_SelfOnlyScope(t *testing.T) {
+	// Arrange
+	privacyService, users, _, _, _ := newTestPrivacyService()
+	ctx := authz.WithScope(context.Background(), authz.Scope{UserID: "self", SelfOnly: true})
+
+	// Act
+	export, err := privacyService.Export(ctx, primitive.NewObjectID().Hex())
+
+	// Assert
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+	assert.Nil(t, export)
+	users.AssertNotCalled(t, "FindById", mock.Anything, mock.Anything)
+}
+
+func TestPrivacyErase(t *testing.T) {
+	// Arrange
+	privacyService, users, auditLog, logins, audit := newTestPrivacyService()
+	outbox := new(MockIOutboxRepository)
+	transactor := new(MockTransactor)
+	privacyService.Outbox = outbox
+	privacyService.Transactor = transactor
+	ctx := context.Background()
+	user := newPrivacyUser()
+	var tombstone *model.PrivateUserModel
+	var event *model.OutboxEvent
+
+	users.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	logins.On("DeleteByUser", ctx, user.ID.Hex()).Return(int64(3), nil)
+	auditLog.On("Scrub", ctx, user.ID.Hex(), privacyNow).Return(2, nil)
+	transactor.On("WithTransaction", ctx)
+	users.On("Erase", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		tombstone = args.Get(1).(*model.PrivateUserModel)
+	}).Return(nil)
+	outbox.On("Add", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		event = args.Get(1).(*model.OutboxEvent)
+	}).Return(nil)
+	audit.On("Record", ctx, model.AuditActionErase, user.ID.Hex(), (*model.PrivateUserModel)(nil), (*model.PrivateUserModel)(nil)).Return(nil)
+
+	// Act
+	receipt, err := privacyService.Erase(ctx, user.ID.Hex())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID.Hex(), receipt.UserID)
+	assert.Equal(t, privacyNow, receipt.ErasedAt)
+	assert.Equal(t, privacyNow.Add(service.DefaultUsernameHold), receipt.UsernameReleaseAt)
+	assert.Equal(t, model.ErasureRef(erasureKey, user), receipt.ErasureRef)
+	assert.NotEqual(t, model.ErasureRef([]byte("other key"), user), receipt.ErasureRef)
+	if assert.NotNil(t, tombstone) {
+		assert.Equal(t, user.ID, tombstone.ID)
+		assert.Equal(t, "acme", tombstone.TenantID)
+		assert.Equal(t, "test", tombstone.Username)
+		assert.NotContains(t, tombstone.Email, "test@mail.com")
+		assert.Empty(t, tombstone.Hash)
+		assert.Nil(t, tombstone.Profile)
+		assert.Nil(t, tombstone.Roles)
+		assert.Nil(t, tombstone.MFA)
+		assert.True(t, tombstone.IsErased())
+		assert.NotContains(t, tombstone.ErasureRef, "test")
+	}
+	if assert.NotNil(t, event) {
+		assert.Equal(t, model.UserErasedEvent, event.Type)
+		assert.NotContains(t, string(event.Payload), `"test"`)
+	}
+	transactor.AssertExpectations(t)
+	auditLog.AssertExpectations(t)
+	audit.AssertExpectations(t)
+}
+
+func TestPrivacyErase_AlreadyErased(t *testing.T) {
+	// Arrange
+	privacyService, users, _, logins, _ := newTestPrivacyService()
+	ctx := context.Background()
+	user := newPrivacyUser().Tombstone(privacyNow.Add(-time.Hour), privacyNow.Add(time.Hour), erasureKey)
+
+	users.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+
+	// Act
+	receipt, err := privacyService.Erase(ctx, user.ID.Hex())
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrUserErased)
+	assert.Nil(t, receipt)
+	logins.AssertNotCalled(t, "DeleteByUser", mock.Anything, mock.Anything)
+}
+
+func TestPrivacyErase_LoginDeletionFails(t *testing.T) {
+	// Arrange
+	privacyService, users, _, logins, _ := newTestPrivacyService()
+	ctx := context.Background()
+	user := newPrivacyUser()
+
+	users.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	logins.On("DeleteByUser", ctx, user.ID.Hex()).Return(int64(0), errors.New("unavailable"))
+
+	// Act
+	_, err := privacyService.Erase(ctx, user.ID.Hex())
+
+	// Assert
+	assert.Error(t, err)
+	users.AssertNotCalled(t, "Erase", mock.Anything, mock.Anything)
+}
+
+func TestPrivacyErase_AuditScrubFails(t *testing.T) {
+	// Arrange
+	privacyService, users, auditLog, logins, _ := newTestPrivacyService()
+	ctx := context.Background()
+	user := newPrivacyUser()
+
+	users.On("FindById", ctx, user.ID.Hex()).Return(user, nil)
+	logins.On("DeleteByUser", ctx, user.ID.Hex()).Return(int64(0), nil)
+	auditLog.On("Scrub", ctx, user.ID.Hex(), privacyNow).Return(0, errors.New("unavailable"))
+
+	// Act
+	_, err := privacyService.Erase(ctx, user.ID.Hex())
+
+	// Assert
+	assert.Error(t, err)
+	users.AssertNotCalled(t, "Erase", mock.Anything, mock.Anything)
+}
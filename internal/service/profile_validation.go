@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+)
+
+const (
+	MaxDisplayNameLength   = 64
+	MaxBioLength           = 500
+	MaxAvatarURLLength     = 2048
+	MaxMetadataEntries     = 20
+	MaxMetadataKeyLength   = 64
+	MaxMetadataValueLength = 512
+)
+
+// ErrInvalidProfile is returned when a profile field fails validation.
+var ErrInvalidProfile = errors.New("invalid profile")
+
+// localePattern accepts BCP 47 style tags such as "en", "pt-BR" or "zh-Hant-TW".
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// ValidateProfile checks the lengths and content of the profile fields and the visibility map.
+func ValidateProfile(profile *publicModel.ProfileModel) error {
+	if profile == nil {
+		return nil
+	}
+
+	if err := validateText(publicModel.ProfileFieldDisplayName, profile.DisplayName, MaxDisplayNameLength, false); err != nil {
+		return err
+	}
+	if profile.DisplayName != strings.TrimSpace(profile.DisplayName) {
+		return fmt.Errorf("%w: %s must not start or end with whitespace", ErrInvalidProfile, publicModel.ProfileFieldDisplayName)
+	}
+	if err := validateText(publicModel.ProfileFieldBio, profile.Bio, MaxBioLength, true); err != nil {
+		return err
+	}
+
+	if profile.AvatarURL != "" {
+		avatarURL, err := url.Parse(profile.AvatarURL)
+		if err != nil || (avatarURL.Scheme != "https" && avatarURL.Scheme != "http") || avatarURL.Host == "" || len(profile.AvatarURL) > MaxAvatarURLLength {
+			return fmt.Errorf("%w: %s must be an absolute http(s) URL", ErrInvalidProfile, publicModel.ProfileFieldAvatarURL)
+		}
+	}
+
+	if profile.Locale != "" && !localePattern.MatchString(profile.Locale) {
+		return fmt.Errorf("%w: %s must be a language tag such as en-US", ErrInvalidProfile, publicModel.ProfileFieldLocale)
+	}
+
+	if profile.Timezone != "" {
+		if _, err := time.LoadLocation(profile.Timezone); err != nil || profile.Timezone == "Local" {
+			return fmt.Errorf("%w: %s must be an IANA time zone such as Europe/Oslo", ErrInvalidProfile, publicModel.ProfileFieldTimezone)
+		}
+	}
+
+	if len(profile.Metadata) > MaxMetadataEntries {
+		return fmt.Errorf("%w: %s holds more than %d entries", ErrInvalidProfile, publicModel.ProfileFieldMetadata, MaxMetadataEntries)
+	}
+	for key, value := range profile.Metadata {
+		if key == "" || len(key) > MaxMetadataKeyLength || len(value) > MaxMetadataValueLength {
+			return fmt.Errorf("%w: %s keys must be 1-%d bytes and values at most %d bytes", ErrInvalidProfile, publicModel.ProfileFieldMetadata, MaxMetadataKeyLength, MaxMetadataValueLength)
+		}
+	}
+
+	for field, visibility := range profile.Visibility {
+		if _, ok := publicModel.DefaultProfileVisibility[field]; !ok {
+			return fmt.Errorf("%w: unknown profile field %q in visibility", ErrInvalidProfile, field)
+		}
+		if !visibility.Valid() {
+			return fmt.Errorf("%w: visibility of %s must be public, authenticated or private", ErrInvalidProfile, field)
+		}
+	}
+
+	return nil
+}
+
+// validateText rejects text that is too long, is not valid UTF-8 or contains control or markup characters.
+// Line breaks are allowed only when multiline is set.
+func validateText(field string, value string, maxLength int, multiline bool) error {
+	if !utf8.ValidString(value) {
+		return fmt.Errorf("%w: %s must be valid UTF-8", ErrInvalidProfile, field)
+	}
+	if utf8.RuneCountInString(value) > maxLength {
+		return fmt.Errorf("%w: %s must be at most %d characters", ErrInvalidProfile, field, maxLength)
+	}
+
+	for _, r := range value {
+		if multiline && r == '\n' {
+			continue
+		}
+		if unicode.IsControl(r) || r == '<' || r == '>' {
+			return fmt.Errorf("%w: %s contains a disallowed character", ErrInvalidProfile, field)
+		}
+	}
+
+	return nil
+}
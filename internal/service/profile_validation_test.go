@@ -0,0 +1,49 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProfile_Valid(t *testing.T) {
+	profile := &publicModel.ProfileModel{
+		DisplayName: "Ada Lovelace",
+		AvatarURL:   "https://cdn.example.com/ada.png",
+		Bio:         "Mathematician.\nWrote the first program.",
+		Locale:      "en-GB",
+		Timezone:    "Europe/London",
+		Metadata:    map[string]string{"team": "engines"},
+		Visibility:  map[string]publicModel.Visibility{publicModel.ProfileFieldBio: publicModel.VisibilityAuthenticated},
+	}
+
+	assert.NoError(t, service.ValidateProfile(profile))
+	assert.NoError(t, service.ValidateProfile(nil))
+}
+
+func TestValidateProfile_Invalid(t *testing.T) {
+	tests := map[string]*publicModel.ProfileModel{
+		"display name too long":     {DisplayName: strings.Repeat("a", service.MaxDisplayNameLength+1)},
+		"display name padded":       {DisplayName: " Ada "},
+		"display name line break":   {DisplayName: "Ada\nLovelace"},
+		"display name markup":       {DisplayName: "<script>"},
+		"bio too long":              {Bio: strings.Repeat("a", service.MaxBioLength+1)},
+		"bio control character":     {Bio: "tab\x00"},
+		"avatar not http":           {AvatarURL: "javascript:alert(1)"},
+		"avatar relative":           {AvatarURL: "/ada.png"},
+		"locale malformed":          {Locale: "english please"},
+		"timezone unknown":          {Timezone: "Mars/Olympus"},
+		"metadata empty key":        {Metadata: map[string]string{"": "value"}},
+		"visibility unknown field":  {Visibility: map[string]publicModel.Visibility{"email": publicModel.VisibilityPublic}},
+		"visibility unknown levels": {Visibility: map[string]publicModel.Visibility{publicModel.ProfileFieldBio: "friends"}},
+	}
+
+	for name, profile := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, service.ValidateProfile(profile), service.ErrInvalidProfile)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+
+	common_crypto "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/crypto"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/events"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tracing"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracedUserService traces each call to a user service as a span named after the method.
+type TracedUserService struct {
+	Next   IUserService
+	Tracer trace.Tracer
+}
+
+var _ IUserService = (*TracedUserService)(nil)
+
+func NewTracedUserService(next IUserService, provider trace.TracerProvider) *TracedUserService {
+	return &TracedUserService{
+		Next:   next,
+		Tracer: provider.Tracer(tracing.InstrumentationName),
+	}
+}
+
+func (s *TracedUserService) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return s.Tracer.Start(ctx, "UserService."+method)
+}
+
+func (s *TracedUserService) Create(ctx context.Context, user *publicModel.CreateUserModel) (created *model.PrivateUserModel, err error) {
+	ctx, span := s.start(ctx, "Create")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.Create(ctx, user)
+}
+
+func (s *TracedUserService) FindById(ctx context.Context, id string) (user *model.PrivateUserModel, err error) {
+	ctx, span := s.start(ctx, "FindById")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.FindById(ctx, id)
+}
+
+func (s *TracedUserService) FindByEmail(ctx context.Context, email string) (user *model.PrivateUserModel, err error) {
+	ctx, span := s.start(ctx, "FindByEmail")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.FindByEmail(ctx, email)
+}
+
+func (s *TracedUserService) FindByUsername(ctx context.Context, username string) (user *model.PrivateUserModel, err error) {
+	ctx, span := s.start(ctx, "FindByUsername")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.FindByUsername(ctx, username)
+}
+
+func (s *TracedUserService) FindByIdentifier(ctx context.Context, identifier string) (user *model.PrivateUserModel, err error) {
+	ctx, span := s.start(ctx, "FindByIdentifier")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.FindByIdentifier(ctx, identifier)
+}
+
+func (s *TracedUserService) FindByIdentifierPrivate(ctx context.Context, identifier string) (user *model.PrivateUserModel, err error) {
+	ctx, span := s.start(ctx, "FindByIdentifierPrivate")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.FindByIdentifierPrivate(ctx, identifier)
+}
+
+func (s *TracedUserService) BatchFindByIdentifier(ctx context.Context, identifiers []string) (users []*model.PrivateUserModel, err error) {
+	ctx, span := s.start(ctx, "BatchFindByIdentifier")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.BatchFindByIdentifier(ctx, identifiers)
+}
+
+func (s *TracedUserService) WatchUsers(ctx context.Context, resumeToken string, handle func(event *events.UserChangeEvent) error) (err error) {
+	ctx, span := s.start(ctx, "WatchUsers")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.WatchUsers(ctx, resumeToken, handle)
+}
+
+func (s *TracedUserService) Update(ctx context.Context, id string, update *publicModel.UpdateUserModel) (user *model.PrivateUserModel, err error) {
+	ctx, span := s.start(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.Update(ctx, id, update)
+}
+
+func (s *TracedUserService) Delete(ctx context.Context, id string, password string) (err error) {
+	ctx, span := s.start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.Delete(ctx, id, password)
+}
+
+func (s *TracedUserService) VerifyCredentials(ctx context.Context, credentials *publicModel.VerifyCredentialsModel) (user *model.PrivateUserModel, err error) {
+	ctx, span := s.start(ctx, "VerifyCredentials")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.VerifyCredentials(ctx, credentials)
+}
+
+func (s *TracedUserService) Import(ctx context.Context, user *publicModel.ImportUserModel) (imported *model.PrivateUserModel, err error) {
+	ctx, span := s.start(ctx, "Import")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.Import(ctx, user)
+}
+
+func (s *TracedUserService) ResetPassword(ctx context.Context, id string, password string) (err error) {
+	ctx, span := s.start(ctx, "ResetPassword")
+	defer func() { tracing.End(span, err) }()
+	return s.Next.ResetPassword(ctx, id, password)
+}
+
+// TracedCrypto traces password hashing, which is slow by design. UserService hashes through
+// GenerateFromPasswordContext, so that the span is a child of the calling one.
+type TracedCrypto struct {
+	Next   common_crypto.ICrypto
+	Tracer trace.Tracer
+}
+
+var _ IPasswordHasher = (*TracedCrypto)(nil)
+var _ IContextCrypto = (*TracedCrypto)(nil)
+
+func NewTracedCrypto(next common_crypto.ICrypto, provider trace.TracerProvider) *TracedCrypto {
+	return &TracedCrypto{
+		Next:   next,
+		Tracer: provider.Tracer(tracing.InstrumentationName),
+	}
+}
+
+// GenerateFromPassword implements common_crypto.ICrypto. The span is a root span.
+func (c *TracedCrypto) GenerateFromPassword(password string) (string, error) {
+	return c.GenerateFromPasswordContext(context.Background(), password)
+}
+
+// GenerateFromPasswordContext implements IContextCrypto.
+func (c *TracedCrypto) GenerateFromPasswordContext(ctx context.Context, password string) (hash string, err error) {
+	_, span := c.Tracer.Start(ctx, "Crypto.GenerateFromPassword")
+	defer func() { tracing.End(span, err) }()
+	return c.Next.GenerateFromPassword(password)
+}
+
+// CompareHashAndPassword implements common_crypto.ICrypto.
+func (c *TracedCrypto) CompareHashAndPassword(hashedPassword string, password string) error {
+	return c.Next.CompareHashAndPassword(hashedPassword, password)
+}
+
+// NeedsRehash implements IPasswordHasher, and is false when the wrapped crypto is not one.
+func (c *TracedCrypto) NeedsRehash(hashedPassword string) bool {
+	hasher, ok := c.Next.(IPasswordHasher)
+	return ok && hasher.NeedsRehash(hashedPassword)
+}
+
+// Validate implements IPasswordHasher, and fails with ErrUnsupportedPasswordHash when the wrapped crypto
+// is not one.
+func (c *TracedCrypto) Validate(hashedPassword string) error {
+	hasher, ok := c.Next.(IPasswordHasher)
+	if !ok {
+		return ErrUnsupportedPasswordHash
+	}
+	return hasher.Validate(hashedPassword)
+}
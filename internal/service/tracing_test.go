@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/hashing"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
+	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracedUserService_Create(t *testing.T) {
+	// Arrange
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepository, service.NewTracedCrypto(mockCrypto, provider), testLogger)
+	tracedService := service.NewTracedUserService(userService, provider)
+	mockRepository.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockCrypto.On("GenerateFromPassword", "test").Return("hashedPassword", nil)
+
+	// Act
+	_, err := tracedService.Create(context.Background(), &publicModel.CreateUserModel{
+		Email:    "test@mail.com",
+		Username: "test",
+		Password: "test",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	hashSpan, createSpan := spans[0], spans[1]
+	assert.Equal(t, "Crypto.GenerateFromPassword", hashSpan.Name)
+	assert.Equal(t, "UserService.Create", createSpan.Name)
+	assert.Equal(t, createSpan.SpanContext.SpanID(), hashSpan.Parent.SpanID())
+	assert.Equal(t, codes.Unset, createSpan.Status.Code)
+}
+
+func TestTracedUserService_RedactsErrors(t *testing.T) {
+	// Arrange
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	mockRepository := new(MockIUserRepository)
+	tracedService := service.NewTracedUserService(service.NewUserService(mockRepository, new(MockCryptoService), testLogger), provider)
+	mockRepository.On("FindByEmail", mock.Anything, "test@mail.com").Return(nil, errors.New("no user test@mail.com"))
+
+	// Act
+	_, err := tracedService.FindByEmail(context.Background(), "test@mail.com")
+
+	// Assert
+	assert.Error(t, err)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "UserService.FindByEmail", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.NotContains(t, spans[0].Status.Description, "test@mail.com")
+	assert.Contains(t, spans[0].Status.Description, logging.Redacted)
+}
+
+func TestTracedCrypto_DelegatesRehash(t *testing.T) {
+	// Arrange
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	registry, err := hashing.NewRegistryFromConfig(hashing.DefaultConfig())
+	require.NoError(t, err)
+	tracedRegistry := service.NewTracedCrypto(registry, provider)
+	tracedMock := service.NewTracedCrypto(new(MockCryptoService), provider)
+
+	// Act
+	hash, err := tracedRegistry.GenerateFromPassword("test")
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, tracedRegistry.CompareHashAndPassword(hash, "test"))
+	assert.NoError(t, tracedRegistry.Validate(hash))
+	assert.False(t, tracedRegistry.NeedsRehash(hash))
+	assert.ErrorIs(t, tracedMock.Validate(hash), service.ErrUnsupportedPasswordHash)
+	assert.False(t, tracedMock.NeedsRehash(hash))
+	require.Len(t, exporter.GetSpans(), 1)
+	assert.Equal(t, "Crypto.GenerateFromPassword", exporter.GetSpans()[0].Name)
+}
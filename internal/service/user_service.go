@@ -2,12 +2,24 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/mail"
+	"strings"
+	"sync"
 	"time"
 
 	common_crypto "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/crypto"
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/events"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/metrics"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/passwordpolicy"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
 	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -18,24 +30,169 @@ type IUserService interface {
 	FindByEmail(ctx context.Context, email string) (*model.PrivateUserModel, error)
 	FindByUsername(ctx context.Context, username string) (*model.PrivateUserModel, error)
 	FindByIdentifier(ctx context.Context, identifier string) (*model.PrivateUserModel, error)
+	FindByIdentifierPrivate(ctx context.Context, identifier string) (*model.PrivateUserModel, error)
+	BatchFindByIdentifier(ctx context.Context, identifiers []string) ([]*model.PrivateUserModel, error)
+	WatchUsers(ctx context.Context, resumeToken string, handle func(event *events.UserChangeEvent) error) error
+	Update(ctx context.Context, id string, update *publicModel.UpdateUserModel) (*model.PrivateUserModel, error)
+	Delete(ctx context.Context, id string, password string) error
+	VerifyCredentials(ctx context.Context, credentials *publicModel.VerifyCredentialsModel) (*model.PrivateUserModel, error)
+	Import(ctx context.Context, user *publicModel.ImportUserModel) (*model.PrivateUserModel, error)
+	ResetPassword(ctx context.Context, id string, password string) error
 }
 
+// IPasswordHasher is implemented by crypto services that can tell outdated hashes apart and
+// validate hashes produced elsewhere. UserService uses it when its Crypto provides it.
+type IPasswordHasher interface {
+	common_crypto.ICrypto
+	NeedsRehash(hashedPassword string) bool
+	Validate(hashedPassword string) error
+}
+
+// IContextCrypto is implemented by crypto services that take the context of a hash, such as TracedCrypto.
+// UserService passes the context when its Crypto provides it.
+type IContextCrypto interface {
+	GenerateFromPasswordContext(ctx context.Context, password string) (string, error)
+}
+
+// DefaultMaxBatchSize is the number of identifiers a single batch lookup accepts unless configured otherwise.
+const DefaultMaxBatchSize = 100
+
+// ErrBatchTooLarge is returned when a batch lookup exceeds UserService.MaxBatchSize.
+var ErrBatchTooLarge = errors.New("batch size exceeds the configured maximum")
+
+// ErrWatchUnavailable is returned by WatchUsers when no event source is configured.
+var ErrWatchUnavailable = errors.New("user change events are not available")
+
+// ErrInvalidUserUpdate is returned when an update is empty or sets a field to an invalid value.
+var ErrInvalidUserUpdate = errors.New("invalid user update")
+
+// ErrPasswordConfirmation is returned when a sensitive change is not confirmed with the account's current password.
+var ErrPasswordConfirmation = errors.New("password confirmation failed")
+
+// ErrInvalidCredentials is returned by VerifyCredentials for an unknown user or a wrong password alike.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// ErrUnsupportedPasswordHash is returned when an imported hash is in a format no configured hasher can verify,
+// or has parameters outside the bounds its hasher accepts.
+var ErrUnsupportedPasswordHash = errors.New("unsupported password hash format")
+
 type UserService struct {
-	Repository repository.IUserRepository
-	Crypto     common_crypto.ICrypto
+	Repository   repository.IUserRepository
+	Crypto       common_crypto.ICrypto
+	MaxBatchSize int
+	Events       events.IUserEventSource
+	Outbox       repository.IOutboxRepository
+	Transactor   repository.ITransactor
+	Audit        IAuditService
+	Idempotency  IIdempotencyService
+	// PasswordPolicy screens new passwords on creation, change and reset
+	PasswordPolicy passwordpolicy.IPolicy
+	// Lockout tracks credential verifications and refuses them during lockouts
+	Lockout ILockoutService
+	// Metrics counts creations, conflicts and lookups, unless it is nil
+	Metrics *metrics.UserCounters
+	// Logger reports failures that do not fail the request
+	Logger *slog.Logger
+
+	// dummyHash is verified for sign-ins of unknown users, so that they take as long as wrong passwords
+	dummyHash     string
+	dummyHashLock sync.Mutex
 }
 
 // NewUserService creates a new instance of UserService.
-func NewUserService(repository repository.IUserRepository, crypto common_crypto.ICrypto) *UserService {
+func NewUserService(repository repository.IUserRepository, crypto common_crypto.ICrypto, logger *slog.Logger) *UserService {
 	return &UserService{
-		Repository: repository,
-		Crypto:     crypto,
+		Repository:   repository,
+		Crypto:       crypto,
+		MaxBatchSize: DefaultMaxBatchSize,
+		Logger:       logger,
 	}
 }
 
+type identifierKind int
+
+const (
+	identifierUsername identifierKind = iota
+	identifierEmail
+	identifierId
+)
+
+// label returns the identifier type of a kind in metrics.
+func (kind identifierKind) label() string {
+	switch kind {
+	case identifierEmail:
+		return metrics.IdentifierEmail
+	case identifierId:
+		return metrics.IdentifierID
+	}
+	return metrics.IdentifierUsername
+}
+
+// classifyIdentifier decides whether an identifier is an email, an ObjectID or a username.
+func classifyIdentifier(identifier string) identifierKind {
+	if _, err := mail.ParseAddress(identifier); err == nil {
+		return identifierEmail
+	}
+
+	if _, err := primitive.ObjectIDFromHex(identifier); err == nil {
+		return identifierId
+	}
+
+	return identifierUsername
+}
+
+// createdUserRef is the response stored for an idempotent creation. Only the id is kept, so that no
+// personal data or password hash is stored outside the user document, and replays read the user again.
+type createdUserRef struct {
+	ID primitive.ObjectID `json:"id"`
+}
+
 // Create implements IUserService.
+// When the context carries an idempotency key, a retry of an identical request returns the user created by the first one.
 func (s *UserService) Create(ctx context.Context, createUserModel *publicModel.CreateUserModel) (*model.PrivateUserModel, error) {
-	hashedPassword, err := s.Crypto.GenerateFromPassword(createUserModel.Password)
+	key := IdempotencyKeyFromContext(ctx)
+	if key == "" || s.Idempotency == nil {
+		return s.create(ctx, createUserModel)
+	}
+
+	// Service accounts share one user id across tenants, so keys are namespaced by tenant as well
+	caller := tenant.FromContext(ctx) + "/" + ActorFromContext(ctx).UserID
+	replay, err := s.Idempotency.Begin(ctx, caller, key, createUserModel)
+	if err != nil {
+		return nil, err
+	}
+	if replay != nil {
+		var ref createdUserRef
+		if err := json.Unmarshal(replay, &ref); err != nil {
+			return nil, err
+		}
+		return s.Repository.FindById(ctx, ref.ID.Hex())
+	}
+
+	user, err := s.create(ctx, createUserModel)
+	if err != nil {
+		if err := s.Idempotency.Abandon(ctx, caller, key); err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to release idempotency key", "idempotency_key", key, "error", err)
+		}
+		return nil, err
+	}
+
+	if err := s.Idempotency.Complete(ctx, caller, key, createdUserRef{ID: user.ID}); err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to store idempotent response", "idempotency_key", key, "error", err)
+	}
+
+	return user, nil
+}
+
+func (s *UserService) create(ctx context.Context, createUserModel *publicModel.CreateUserModel) (*model.PrivateUserModel, error) {
+	if err := ValidateProfile(createUserModel.Profile); err != nil {
+		return nil, err
+	}
+	if err := s.checkPassword(ctx, createUserModel.Password, createUserModel.Username, createUserModel.Email); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := s.hashPassword(ctx, createUserModel.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -45,19 +202,277 @@ func (s *UserService) Create(ctx context.Context, createUserModel *publicModel.C
 		Email:     createUserModel.Email,
 		Username:  createUserModel.Username,
 		Hash:      string(hashedPassword),
+		Profile:   createUserModel.Profile,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
-	err = s.Repository.Create(ctx, user)
+	if err := s.insert(ctx, model.AuditActionCreate, user); err != nil {
+		s.countConflict(metrics.OperationCreate, err)
+		return nil, err
+	}
+	s.countCreated(metrics.SourceCreate)
 
-	if err != nil {
+	return user, nil
+}
+
+// Import implements IUserService.
+// The password hash is stored as given, so accounts migrated from other systems keep their passwords;
+// it is replaced with a hash from the preferred algorithm the first time the password is verified.
+// The hash is fully decoded first, so that no hash is stored that would fail or exhaust the service on sign-in.
+func (s *UserService) Import(ctx context.Context, importUserModel *publicModel.ImportUserModel) (*model.PrivateUserModel, error) {
+	hasher, ok := s.Crypto.(IPasswordHasher)
+	if !ok {
+		return nil, ErrUnsupportedPasswordHash
+	}
+	if err := hasher.Validate(importUserModel.PasswordHash); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedPasswordHash, err)
+	}
+	if err := ValidateProfile(importUserModel.Profile); err != nil {
+		return nil, err
+	}
+
+	user := &model.PrivateUserModel{
+		ID:        primitive.NewObjectID(),
+		Email:     importUserModel.Email,
+		Username:  importUserModel.Username,
+		Hash:      importUserModel.PasswordHash,
+		Profile:   importUserModel.Profile,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.insert(ctx, model.AuditActionImport, user); err != nil {
+		s.countConflict(metrics.OperationImport, err)
 		return nil, err
 	}
+	s.countCreated(metrics.SourceImport)
+
+	return user, nil
+}
+
+// checkPassword applies the password policy, when one is configured. The account's username and
+// email must not make up the password.
+func (s *UserService) checkPassword(ctx context.Context, password string, username string, email string) error {
+	if s.PasswordPolicy == nil {
+		return nil
+	}
+	return s.PasswordPolicy.Check(ctx, password, username, email)
+}
+
+func (s *UserService) insert(ctx context.Context, action string, user *model.PrivateUserModel) error {
+	err := s.withEvent(ctx, model.UserCreatedEvent, user, func(ctx context.Context) error {
+		return s.Repository.Create(ctx, user)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.recordMutation(ctx, action, nil, user)
+
+	return nil
+}
+
+// countCreated counts a user created from source.
+func (s *UserService) countCreated(source string) {
+	if s.Metrics != nil {
+		s.Metrics.Created.WithLabelValues(source).Inc()
+	}
+}
+
+// countConflict counts a write refused because the email or username is taken. Other errors are not counted.
+func (s *UserService) countConflict(operation string, err error) {
+	var serviceError *common_error.ServiceError
+	if s.Metrics != nil && errors.As(err, &serviceError) && serviceError.Code == common_error.Conflict {
+		s.Metrics.Conflicts.WithLabelValues(operation).Inc()
+	}
+}
+
+// countLookups counts lookups of count identifiers of a kind.
+func (s *UserService) countLookups(kind identifierKind, count int) {
+	if s.Metrics != nil {
+		s.Metrics.Lookups.WithLabelValues(kind.label()).Add(float64(count))
+	}
+}
+
+// hashPassword hashes a password with Crypto, passing it the context when it takes one.
+func (s *UserService) hashPassword(ctx context.Context, password string) (string, error) {
+	if crypto, ok := s.Crypto.(IContextCrypto); ok {
+		return crypto.GenerateFromPasswordContext(ctx, password)
+	}
+	return s.Crypto.GenerateFromPassword(password)
+}
+
+// verifyPassword checks a password against the user's stored hash. When the hash was made with
+// an outdated algorithm or parameters, the password is hashed again and the new hash returned.
+func (s *UserService) verifyPassword(ctx context.Context, user *model.PrivateUserModel, password string) (string, error) {
+	if err := s.Crypto.CompareHashAndPassword(user.Hash, password); err != nil {
+		return "", err
+	}
+
+	hasher, ok := s.Crypto.(IPasswordHasher)
+	if !ok || !hasher.NeedsRehash(user.Hash) {
+		return "", nil
+	}
+
+	rehashed, err := s.hashPassword(ctx, password)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to rehash password", "user_id", user.ID.Hex(), "error", err)
+		return "", nil
+	}
+	return rehashed, nil
+}
+
+// verifyUnknownUser spends a password verification on a sign-in for an account that does not exist,
+// so that response times do not reveal which accounts do. The dummy hash is made on first use with
+// the preferred algorithm and parameters, which most stored hashes are upgraded to.
+func (s *UserService) verifyUnknownUser(ctx context.Context, password string) {
+	s.dummyHashLock.Lock()
+	if s.dummyHash == "" {
+		hash, err := s.hashPassword(ctx, "unknown user")
+		if err != nil {
+			s.dummyHashLock.Unlock()
+			s.Logger.ErrorContext(ctx, "Failed to hash dummy password", "error", err)
+			return
+		}
+		s.dummyHash = hash
+	}
+	hash := s.dummyHash
+	s.dummyHashLock.Unlock()
+
+	// The outcome is irrelevant, the account does not exist
+	_ = s.Crypto.CompareHashAndPassword(hash, password)
+}
+
+// VerifyCredentials implements IUserService.
+// An outdated stored hash is upgraded on success. Failing to store it does not fail the verification.
+// With a lockout service configured, every attempt is tracked and attempts during a lockout are refused.
+func (s *UserService) VerifyCredentials(ctx context.Context, credentials *publicModel.VerifyCredentialsModel) (*model.PrivateUserModel, error) {
+	sourceIP := credentials.SourceIP
+	if sourceIP == "" {
+		sourceIP = ActorFromContext(ctx).SourceIP
+	}
+
+	user, err := s.FindByIdentifier(ctx, credentials.Identifier)
+	if err != nil {
+		var serviceError *common_error.ServiceError
+		if !errors.As(err, &serviceError) || serviceError.Code != common_error.NotFound {
+			return nil, err
+		}
+		user = nil
+	}
+
+	if s.Lockout != nil {
+		if err := s.Lockout.Allow(ctx, user, sourceIP); err != nil {
+			return nil, err
+		}
+	}
+
+	var rehashed string
+	if user != nil {
+		rehashed, err = s.verifyPassword(ctx, user, credentials.Password)
+	} else {
+		s.verifyUnknownUser(ctx, credentials.Password)
+	}
+	if user == nil || err != nil {
+		if s.Lockout != nil {
+			if err := s.Lockout.RecordFailure(ctx, user, sourceIP); err != nil {
+				s.Logger.ErrorContext(ctx, "Failed to record failed sign-in attempt", "error", err)
+			}
+		}
+		return nil, ErrInvalidCredentials
+	}
+
+	// With MFA the sign-in succeeds only once the second factor is verified, so a correct
+	// password alone must not end a run of failed codes
+	if s.Lockout != nil && !user.MFAEnabled {
+		if err := s.Lockout.RecordSuccess(ctx, user, sourceIP); err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to record sign-in", "user_id", user.ID.Hex(), "error", err)
+		}
+	}
+
+	if rehashed != "" {
+		upgraded := *user
+		upgraded.Hash = rehashed
+		if err := s.Repository.Update(ctx, &upgraded); err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to store rehashed password", "user_id", user.ID.Hex(), "error", err)
+		} else {
+			user = &upgraded
+		}
+	}
 
 	return user, nil
 }
 
+// confirmPassword checks the password a user confirms a change with, returning a rehash as verifyPassword
+// does. Confirmations count towards the lockout like sign-ins, so they cannot be used to guess passwords.
+func (s *UserService) confirmPassword(ctx context.Context, user *model.PrivateUserModel, password string) (string, error) {
+	sourceIP := ActorFromContext(ctx).SourceIP
+	if s.Lockout != nil {
+		if err := s.Lockout.Allow(ctx, user, sourceIP); err != nil {
+			return "", err
+		}
+	}
+
+	rehashed, err := s.verifyPassword(ctx, user, password)
+	if err != nil {
+		if s.Lockout != nil {
+			if err := s.Lockout.RecordFailure(ctx, user, sourceIP); err != nil {
+				s.Logger.ErrorContext(ctx, "Failed to record failed password confirmation", "user_id", user.ID.Hex(), "error", err)
+			}
+		}
+		return "", ErrPasswordConfirmation
+	}
+
+	if s.Lockout != nil {
+		if err := s.Lockout.RecordSuccess(ctx, user, sourceIP); err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to record password confirmation", "user_id", user.ID.Hex(), "error", err)
+		}
+	}
+	return rehashed, nil
+}
+
+// recordMutation audits a change that has already been committed. Failing the request at this
+// point would invite a retry of a mutation that succeeded, so audit errors are only logged.
+func (s *UserService) recordMutation(ctx context.Context, action string, before *model.PrivateUserModel, after *model.PrivateUserModel) {
+	if s.Audit == nil {
+		return
+	}
+
+	target := after
+	if target == nil {
+		target = before
+	}
+	if err := s.Audit.Record(ctx, action, target.ID.Hex(), before, after); err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to audit", "action", action, "user_id", target.ID.Hex(), "error", err)
+	}
+}
+
+// withEvent applies a user mutation and records its domain event in the outbox.
+// When a transactor is configured both writes commit or roll back together.
+func (s *UserService) withEvent(ctx context.Context, eventType model.OutboxEventType, user *model.PrivateUserModel, mutate func(ctx context.Context) error) error {
+	if s.Outbox == nil {
+		return mutate(ctx)
+	}
+
+	apply := func(ctx context.Context) error {
+		if err := mutate(ctx); err != nil {
+			return err
+		}
+
+		event, err := model.NewOutboxEvent(eventType, user)
+		if err != nil {
+			return err
+		}
+		return s.Outbox.Add(ctx, event)
+	}
+
+	if s.Transactor == nil {
+		return apply(ctx)
+	}
+	return s.Transactor.WithTransaction(ctx, apply)
+}
+
 // FindByEmail implements IUserService.
 func (s *UserService) FindByEmail(ctx context.Context, email string) (*model.PrivateUserModel, error) {
 	privateUser, err := s.Repository.FindByEmail(ctx, email)
@@ -65,7 +480,7 @@ func (s *UserService) FindByEmail(ctx context.Context, email string) (*model.Pri
 		return nil, err
 	}
 
-	return privateUser, nil
+	return notErased(privateUser)
 }
 
 // FindById implements IUserService.
@@ -75,22 +490,260 @@ func (s *UserService) FindById(ctx context.Context, id string) (*model.PrivateUs
 		return nil, err
 	}
 
-	return privateUser, nil
+	return notErased(privateUser)
 }
 
 // FindByIdentifier implements IUserService.
 func (s *UserService) FindByIdentifier(ctx context.Context, identifier string) (*model.PrivateUserModel, error) {
-	_, err := mail.ParseAddress(identifier)
-	if err == nil {
-		return s.Repository.FindByEmail(ctx, identifier)
+	kind := classifyIdentifier(identifier)
+	s.countLookups(kind, 1)
+	switch kind {
+	case identifierEmail:
+		return s.FindByEmail(ctx, identifier)
+	case identifierId:
+		return s.FindById(ctx, identifier)
+	default:
+		return s.FindByUsername(ctx, identifier)
+	}
+}
+
+// notErased hides the tombstones of erased users, which only exist for audit integrity.
+func notErased(user *model.PrivateUserModel) (*model.PrivateUserModel, error) {
+	if user.IsErased() {
+		return nil, common_error.NewServiceError(common_error.NotFound, "User not found", nil)
+	}
+	return user, nil
+}
+
+// FindByIdentifierPrivate implements IUserService.
+// Unlike FindByIdentifier the lookup honours a self-only scope and is audited; it fails when the audit record cannot be written.
+// Contention on the audit chain only delays the lookup: if Record gives up, the failure is logged and the user returned.
+func (s *UserService) FindByIdentifierPrivate(ctx context.Context, identifier string) (*model.PrivateUserModel, error) {
+	user, err := s.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authz.CheckTarget(ctx, user.ID.Hex()); err != nil {
+		return nil, err
+	}
+
+	if s.Audit != nil {
+		err := s.Audit.Record(ctx, model.AuditActionLookupPrivate, user.ID.Hex(), nil, nil)
+		if errors.Is(err, ErrAuditContention) {
+			s.Logger.ErrorContext(ctx, "Failed to audit", "action", model.AuditActionLookupPrivate, "user_id", user.ID.Hex(), "error", err)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+// BatchFindByIdentifier implements IUserService.
+// The result has one entry per identifier in request order; identifiers that match no user yield nil.
+func (s *UserService) BatchFindByIdentifier(ctx context.Context, identifiers []string) ([]*model.PrivateUserModel, error) {
+	if s.MaxBatchSize > 0 && len(identifiers) > s.MaxBatchSize {
+		return nil, fmt.Errorf("%w: %d > %d", ErrBatchTooLarge, len(identifiers), s.MaxBatchSize)
+	}
+
+	grouped := map[identifierKind][]string{}
+	seen := map[string]struct{}{}
+	for _, identifier := range identifiers {
+		if _, ok := seen[identifier]; ok {
+			continue
+		}
+		seen[identifier] = struct{}{}
+		kind := classifyIdentifier(identifier)
+		grouped[kind] = append(grouped[kind], identifier)
+	}
+
+	found := make(map[string]*model.PrivateUserModel, len(seen))
+	for kind, values := range grouped {
+		s.countLookups(kind, len(values))
+		var users []*model.PrivateUserModel
+		var err error
+		switch kind {
+		case identifierEmail:
+			users, err = s.Repository.FindByEmails(ctx, values)
+		case identifierId:
+			users, err = s.Repository.FindByIds(ctx, values)
+		default:
+			users, err = s.Repository.FindByUsernames(ctx, values)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range users {
+			if user.IsErased() {
+				continue
+			}
+			switch kind {
+			case identifierEmail:
+				found[user.Email] = user
+			case identifierId:
+				found[user.ID.Hex()] = user
+			default:
+				found[user.Username] = user
+			}
+		}
+	}
+
+	results := make([]*model.PrivateUserModel, len(identifiers))
+	for i, identifier := range identifiers {
+		results[i] = found[identifier]
 	}
 
-	_, err = primitive.ObjectIDFromHex(identifier)
-	if err == nil {
-		return s.Repository.FindById(ctx, identifier)
+	return results, nil
+}
+
+// WatchUsers implements IUserService.
+func (s *UserService) WatchUsers(ctx context.Context, resumeToken string, handle func(event *events.UserChangeEvent) error) error {
+	if s.Events == nil {
+		return ErrWatchUnavailable
+	}
+
+	return s.Events.Watch(ctx, resumeToken, handle)
+}
+
+// Update implements IUserService.
+// Only the fields set in update change; email and password changes require the current password.
+func (s *UserService) Update(ctx context.Context, id string, update *publicModel.UpdateUserModel) (*model.PrivateUserModel, error) {
+	if update.Email == nil && update.Username == nil && update.Password == nil && update.Profile == nil {
+		return nil, fmt.Errorf("%w: no fields to update", ErrInvalidUserUpdate)
+	}
+	if err := authz.CheckTarget(ctx, id); err != nil {
+		return nil, err
+	}
+
+	before, err := s.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	after := *before
+	if update.Email != nil || update.Password != nil {
+		rehashed, err := s.confirmPassword(ctx, before, update.CurrentPassword)
+		if err != nil {
+			return nil, err
+		}
+		if rehashed != "" {
+			after.Hash = rehashed
+		}
+	}
+
+	eventType := model.UserUpdatedEvent
+	if update.Username != nil {
+		if strings.TrimSpace(*update.Username) == "" {
+			return nil, fmt.Errorf("%w: username must not be empty", ErrInvalidUserUpdate)
+		}
+		after.Username = *update.Username
+	}
+	if update.Email != nil {
+		if _, err := mail.ParseAddress(*update.Email); err != nil {
+			return nil, fmt.Errorf("%w: email is not a valid address", ErrInvalidUserUpdate)
+		}
+		after.Email = *update.Email
+	}
+	if update.Profile != nil {
+		if err := ValidateProfile(update.Profile); err != nil {
+			return nil, err
+		}
+		after.Profile = update.Profile
+	}
+	if update.Password != nil {
+		if *update.Password == "" {
+			return nil, fmt.Errorf("%w: password must not be empty", ErrInvalidUserUpdate)
+		}
+		if err := s.checkPassword(ctx, *update.Password, after.Username, after.Email); err != nil {
+			return nil, err
+		}
+		hashedPassword, err := s.hashPassword(ctx, *update.Password)
+		if err != nil {
+			return nil, err
+		}
+		after.Hash = string(hashedPassword)
+		eventType = model.PasswordChangedEvent
+	}
+	after.UpdatedAt = time.Now()
+
+	err = s.withEvent(ctx, eventType, &after, func(ctx context.Context) error {
+		return s.Repository.Update(ctx, &after)
+	})
+	if err != nil {
+		s.countConflict(metrics.OperationUpdate, err)
+		return nil, err
+	}
+
+	s.recordMutation(ctx, model.AuditActionUpdate, before, &after)
+
+	return &after, nil
+}
+
+// Delete implements IUserService.
+// The account's password must be supplied to confirm the deletion.
+func (s *UserService) Delete(ctx context.Context, id string, password string) error {
+	if err := authz.CheckTarget(ctx, id); err != nil {
+		return err
+	}
+
+	user, err := s.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.confirmPassword(ctx, user, password); err != nil {
+		return err
+	}
+
+	err = s.withEvent(ctx, model.UserDeletedEvent, user, func(ctx context.Context) error {
+		return s.Repository.Delete(ctx, user)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.recordMutation(ctx, model.AuditActionDelete, user, nil)
+
+	return nil
+}
+
+// ResetPassword implements IUserService.
+// Unlike a change through Update, a reset sets a new password without knowing the current one.
+func (s *UserService) ResetPassword(ctx context.Context, id string, password string) error {
+	if err := authz.CheckTarget(ctx, id); err != nil {
+		return err
 	}
 
-	return s.Repository.FindByUsername(ctx, identifier)
+	before, err := s.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkPassword(ctx, password, before.Username, before.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.hashPassword(ctx, password)
+	if err != nil {
+		return err
+	}
+
+	after := *before
+	after.Hash = hashedPassword
+	after.UpdatedAt = time.Now()
+
+	err = s.withEvent(ctx, model.PasswordChangedEvent, &after, func(ctx context.Context) error {
+		return s.Repository.Update(ctx, &after)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.recordMutation(ctx, model.AuditActionPasswordReset, before, &after)
+
+	return nil
 }
 
 // FindByUsername implements IUserService.
@@ -100,7 +753,7 @@ func (s *UserService) FindByUsername(ctx context.Context, username string) (*mod
 		return nil, err
 	}
 
-	return privateUser, nil
+	return notErased(privateUser)
 }
 
 // Ensure UserService implements IUserService
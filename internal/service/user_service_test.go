@@ -2,20 +2,36 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"log/slog"
 	"testing"
 	"time"
 
 	common_crypto "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/crypto"
+	common_error "github.com/Bit-Bridge-Source/BitBridge-CommonService-Go/public/error"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/authz"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/events"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/hashing"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/metrics"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/model"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/passwordpolicy"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/repository"
 	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/service"
 	publicModel "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/public/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// testLogger discards the failures services only log.
+var testLogger = logging.NewLogger(io.Discard, slog.LevelInfo)
+
 type MockIUserRepository struct {
 	mock.Mock
 }
@@ -68,6 +84,33 @@ func (m *MockIUserRepository) FindByUsername(ctx context.Context, username strin
 	return nil, errors.New("type assertion to *model.PrivateUserModel failed")
 }
 
+// FindByIds implements repository.IUserRepository.
+func (m *MockIUserRepository) FindByIds(ctx context.Context, ids []string) ([]*model.PrivateUserModel, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PrivateUserModel), args.Error(1)
+}
+
+// FindByEmails implements repository.IUserRepository.
+func (m *MockIUserRepository) FindByEmails(ctx context.Context, emails []string) ([]*model.PrivateUserModel, error) {
+	args := m.Called(ctx, emails)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PrivateUserModel), args.Error(1)
+}
+
+// FindByUsernames implements repository.IUserRepository.
+func (m *MockIUserRepository) FindByUsernames(ctx context.Context, usernames []string) ([]*model.PrivateUserModel, error) {
+	args := m.Called(ctx, usernames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PrivateUserModel), args.Error(1)
+}
+
 func (m *MockIUserRepository) FindByIdentifier(ctx context.Context, identifier string) (*model.PrivateUserModel, error) {
 	args := m.Called(ctx, identifier)
 	if args.Get(0) == nil {
@@ -85,9 +128,138 @@ func (m *MockIUserRepository) Update(ctx context.Context, user *model.PrivateUse
 	return args.Error(0)
 }
 
+// SetLockedUntil implements repository.IUserRepository.
+func (m *MockIUserRepository) SetLockedUntil(ctx context.Context, id string, until *time.Time) error {
+	args := m.Called(ctx, id, until)
+	return args.Error(0)
+}
+
+// SetMFA implements repository.IUserRepository.
+func (m *MockIUserRepository) SetMFA(ctx context.Context, id string, enabled bool, state *model.MFAState) error {
+	args := m.Called(ctx, id, enabled, state)
+	return args.Error(0)
+}
+
+// AdvanceMFAStep implements repository.IUserRepository.
+func (m *MockIUserRepository) AdvanceMFAStep(ctx context.Context, id string, step int64) (bool, error) {
+	args := m.Called(ctx, id, step)
+	return args.Bool(0), args.Error(1)
+}
+
+// ConsumeRecoveryCode implements repository.IUserRepository.
+func (m *MockIUserRepository) ConsumeRecoveryCode(ctx context.Context, id string, hash string) (bool, error) {
+	args := m.Called(ctx, id, hash)
+	return args.Bool(0), args.Error(1)
+}
+
+// Erase implements repository.IUserRepository.
+func (m *MockIUserRepository) Erase(ctx context.Context, tombstone *model.PrivateUserModel) error {
+	args := m.Called(ctx, tombstone)
+	return args.Error(0)
+}
+
 // Ensure that MockIUserRepository implements IUserRepository.
 var _ repository.IUserRepository = &MockIUserRepository{}
 
+type MockIOutboxRepository struct {
+	mock.Mock
+}
+
+// Add implements repository.IOutboxRepository.
+func (m *MockIOutboxRepository) Add(ctx context.Context, event *model.OutboxEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// FindPending implements repository.IOutboxRepository.
+func (m *MockIOutboxRepository) FindPending(ctx context.Context, now time.Time, limit int64) ([]*model.OutboxEvent, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.OutboxEvent), args.Error(1)
+}
+
+// MarkPublished implements repository.IOutboxRepository.
+func (m *MockIOutboxRepository) MarkPublished(ctx context.Context, id primitive.ObjectID, publishedAt time.Time) error {
+	args := m.Called(ctx, id, publishedAt)
+	return args.Error(0)
+}
+
+// MarkFailed implements repository.IOutboxRepository.
+func (m *MockIOutboxRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, reason string, nextAttemptAt time.Time) error {
+	args := m.Called(ctx, id, reason, nextAttemptAt)
+	return args.Error(0)
+}
+
+// Ensure that MockIOutboxRepository implements IOutboxRepository.
+var _ repository.IOutboxRepository = &MockIOutboxRepository{}
+
+type transactionContextKey struct{}
+
+type MockTransactor struct {
+	mock.Mock
+}
+
+// WithTransaction implements repository.ITransactor and marks the context it hands to fn.
+func (m *MockTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Called(ctx)
+	return fn(context.WithValue(ctx, transactionContextKey{}, true))
+}
+
+// Ensure that MockTransactor implements ITransactor.
+var _ repository.ITransactor = &MockTransactor{}
+
+type MockIAuditService struct {
+	mock.Mock
+}
+
+// Record implements service.IAuditService.
+func (m *MockIAuditService) Record(ctx context.Context, action string, targetUserID string, before *model.PrivateUserModel, after *model.PrivateUserModel) error {
+	args := m.Called(ctx, action, targetUserID, before, after)
+	return args.Error(0)
+}
+
+// List implements service.IAuditService.
+func (m *MockIAuditService) List(ctx context.Context, filter repository.AuditFilter) ([]*model.AuditEvent, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.AuditEvent), args.Error(1)
+}
+
+// Ensure that MockIAuditService implements IAuditService.
+var _ service.IAuditService = &MockIAuditService{}
+
+type MockIIdempotencyService struct {
+	mock.Mock
+}
+
+// Begin implements service.IIdempotencyService.
+func (m *MockIIdempotencyService) Begin(ctx context.Context, caller string, key string, request interface{}) (json.RawMessage, error) {
+	args := m.Called(ctx, caller, key, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(json.RawMessage), args.Error(1)
+}
+
+// Complete implements service.IIdempotencyService.
+func (m *MockIIdempotencyService) Complete(ctx context.Context, caller string, key string, response interface{}) error {
+	args := m.Called(ctx, caller, key, response)
+	return args.Error(0)
+}
+
+// Abandon implements service.IIdempotencyService.
+func (m *MockIIdempotencyService) Abandon(ctx context.Context, caller string, key string) error {
+	args := m.Called(ctx, caller, key)
+	return args.Error(0)
+}
+
+// Ensure that MockIIdempotencyService implements IIdempotencyService.
+var _ service.IIdempotencyService = &MockIIdempotencyService{}
+
 type MockCryptoService struct {
 	mock.Mock
 }
@@ -109,7 +281,7 @@ func TestCreate_Success(t *testing.T) {
 	// Arrange
 	mockRepository := new(MockIUserRepository)
 	mockCrypto := new(MockCryptoService)
-	service := service.NewUserService(mockRepository, mockCrypto)
+	service := service.NewUserService(mockRepository, mockCrypto, testLogger)
 	ctx := context.Background()
 	privateUser := &model.PrivateUserModel{
 		ID:        primitive.NewObjectID(),
@@ -143,7 +315,7 @@ func TestCreate_Failure(t *testing.T) {
 	// Arrange
 	mockRepository := new(MockIUserRepository)
 	mockCrypto := new(MockCryptoService)
-	service := service.NewUserService(mockRepository, mockCrypto)
+	service := service.NewUserService(mockRepository, mockCrypto, testLogger)
 	ctx := context.Background()
 	privateUser := &model.PrivateUserModel{
 		ID:        primitive.NewObjectID(),
@@ -175,7 +347,7 @@ func TestCreate_Failure_InvalidEncryption(t *testing.T) {
 	// Arrange
 	mockRepository := new(MockIUserRepository)
 	mockCrypto := new(MockCryptoService)
-	service := service.NewUserService(mockRepository, mockCrypto)
+	service := service.NewUserService(mockRepository, mockCrypto, testLogger)
 	ctx := context.Background()
 	privateUser := &model.PrivateUserModel{
 		ID:        primitive.NewObjectID(),
@@ -207,7 +379,7 @@ func TestFindByEmail_Success(t *testing.T) {
 	// Arrange
 	mockRepository := new(MockIUserRepository)
 	mockCrypto := new(MockCryptoService)
-	service := service.NewUserService(mockRepository, mockCrypto)
+	service := service.NewUserService(mockRepository, mockCrypto, testLogger)
 	ctx := context.Background()
 	privateUser := &model.PrivateUserModel{
 		ID:        primitive.NewObjectID(),
@@ -235,7 +407,7 @@ func TestFindByEmail_Failure(t *testing.T) {
 	// Arrange
 	mockRepository := new(MockIUserRepository)
 	mockCrypto := new(MockCryptoService)
-	service := service.NewUserService(mockRepository, mockCrypto)
+	service := service.NewUserService(mockRepository, mockCrypto, testLogger)
 	ctx := context.Background()
 	privateUser := &model.PrivateUserModel{
 		ID:        primitive.NewObjectID(),
@@ -261,7 +433,7 @@ func TestFindById_Success(t *testing.T) {
 	// Arrange
 	mockRepository := new(MockIUserRepository)
 	mockCrypto := new(MockCryptoService)
-	service := service.NewUserService(mockRepository, mockCrypto)
+	service := service.NewUserService(mockRepository, mockCrypto, testLogger)
 	ctx := context.Background()
 	privateUser := &model.PrivateUserModel{
 		ID:        primitive.NewObjectID(),
@@ -288,7 +460,7 @@ func TestFindById_Failure(t *testing.T) {
 	// Arrange
 	mockRepository := new(MockIUserRepository)
 	mockCrypto := new(MockCryptoService)
-	service := service.NewUserService(mockRepository, mockCrypto)
+	service := service.NewUserService(mockRepository, mockCrypto, testLogger)
 	ctx := context.Background()
 	privateUser := &model.PrivateUserModel{
 		ID:        primitive.NewObjectID(),
@@ -314,7 +486,7 @@ func TestFindById_Failure(t *testing.T) {
 func TestFindByUsername_Success(t *testing.T) {
 	mockRepo := new(MockIUserRepository)
 	mockCrypto := new(MockCryptoService)
-	service := service.NewUserService(mockRepo, mockCrypto)
+	service := service.NewUserService(mockRepo, mockCrypto, testLogger)
 
 	ctx := context.Background()
 	testUser := &model.PrivateUserModel{
@@ -339,7 +511,7 @@ func TestFindByUsername_Success(t *testing.T) {
 func TestFindByUsername_Failure(t *testing.T) {
 	mockRepo := new(MockIUserRepository)
 	mockCrypto := new(MockCryptoService)
-	service := service.NewUserService(mockRepo, mockCrypto)
+	service := service.NewUserService(mockRepo, mockCrypto, testLogger)
 
 	ctx := context.Background()
 	testUser := &model.PrivateUserModel{
@@ -363,7 +535,7 @@ func TestFindByUsername_Failure(t *testing.T) {
 func TestFindByIdentifier(t *testing.T) {
 	mockRepo := new(MockIUserRepository)
 	mockCrypto := new(MockCryptoService)
-	service := service.NewUserService(mockRepo, mockCrypto)
+	service := service.NewUserService(mockRepo, mockCrypto, testLogger)
 
 	ctx := context.Background()
 	testUser := &model.PrivateUserModel{
@@ -396,3 +568,990 @@ func TestFindByIdentifier(t *testing.T) {
 	assert.Equal(t, testUser.ID, user.ID)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestFindByIdentifier_ErasedUserNotFound(t *testing.T) {
+	mockRepo := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepo, mockCrypto, testLogger)
+
+	ctx := context.Background()
+	erased := (&model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test"}).Tombstone(time.Now(), time.Now().Add(time.Hour), []byte("erasure key"))
+	mockRepo.On("FindByUsername", ctx, "test").Return(erased, nil)
+
+	user, err := userService.FindByIdentifier(ctx, "test")
+
+	var serviceError *common_error.ServiceError
+	assert.ErrorAs(t, err, &serviceError)
+	assert.Equal(t, common_error.NotFound, serviceError.Code)
+	assert.Nil(t, user)
+}
+
+func TestBatchFindByIdentifier(t *testing.T) {
+	mockRepo := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepo, mockCrypto, testLogger)
+
+	ctx := context.Background()
+	byId := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "byid"}
+	byEmail := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "test@mail.com", Username: "byemail"}
+	byUsername := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "byusername"}
+	missingId := primitive.NewObjectID().Hex()
+
+	mockRepo.On("FindByIds", ctx, []string{missingId, byId.ID.Hex()}).Return([]*model.PrivateUserModel{byId}, nil)
+	mockRepo.On("FindByEmails", ctx, []string{"test@mail.com"}).Return([]*model.PrivateUserModel{byEmail}, nil)
+	mockRepo.On("FindByUsernames", ctx, []string{"byusername", "missing"}).Return([]*model.PrivateUserModel{byUsername}, nil)
+
+	users, err := userService.BatchFindByIdentifier(ctx, []string{
+		"byusername", missingId, "test@mail.com", byId.ID.Hex(), "missing", "byusername",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.PrivateUserModel{byUsername, nil, byEmail, byId, nil, byUsername}, users)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBatchFindByIdentifier_TooLarge(t *testing.T) {
+	mockRepo := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepo, mockCrypto, testLogger)
+	userService.MaxBatchSize = 1
+
+	users, err := userService.BatchFindByIdentifier(context.Background(), []string{"a", "b"})
+
+	assert.ErrorIs(t, err, service.ErrBatchTooLarge)
+	assert.Nil(t, users)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBatchFindByIdentifier_RepositoryError(t *testing.T) {
+	mockRepo := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepo, mockCrypto, testLogger)
+
+	ctx := context.Background()
+	mockRepo.On("FindByUsernames", ctx, []string{"test"}).Return(nil, assert.AnError)
+
+	users, err := userService.BatchFindByIdentifier(ctx, []string{"test"})
+
+	assert.Error(t, err)
+	assert.Nil(t, users)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWatchUsers(t *testing.T) {
+	mockRepo := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepo, mockCrypto, testLogger)
+	source := events.NewMemoryUserEventSource()
+	userService.Events = source
+	source.Publish(events.UserChangeEvent{Type: events.UserCreated, UserID: "1"})
+
+	var received *events.UserChangeEvent
+	err := userService.WatchUsers(context.Background(), "0", func(event *events.UserChangeEvent) error {
+		received = event
+		return assert.AnError
+	})
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, "1", received.UserID)
+}
+
+func TestWatchUsers_Unavailable(t *testing.T) {
+	mockRepo := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepo, mockCrypto, testLogger)
+
+	err := userService.WatchUsers(context.Background(), "", func(event *events.UserChangeEvent) error {
+		return nil
+	})
+
+	assert.ErrorIs(t, err, service.ErrWatchUnavailable)
+}
+
+func TestCreate_WritesOutboxEventInTransaction(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockOutbox := new(MockIOutboxRepository)
+	mockTransactor := new(MockTransactor)
+	service := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	service.Outbox = mockOutbox
+	service.Transactor = mockTransactor
+	ctx := context.Background()
+	inTransaction := mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Value(transactionContextKey{}) == true
+	})
+
+	mockTransactor.On("WithTransaction", ctx).Return()
+	mockCrypto.On("GenerateFromPassword", mock.Anything).Return("hashedPassword", nil)
+	mockRepository.On("Create", inTransaction, mock.Anything).Return(nil)
+	mockOutbox.On("Add", inTransaction, mock.MatchedBy(func(event *model.OutboxEvent) bool {
+		return event.Type == model.UserCreatedEvent && event.IdempotencyKey != ""
+	})).Return(nil)
+
+	// Act
+	result, err := service.Create(ctx, &publicModel.CreateUserModel{
+		Email:    "test@mail.com",
+		Username: "test",
+		Password: "test",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, result.ID.Hex(), mockOutbox.Calls[0].Arguments.Get(1).(*model.OutboxEvent).AggregateID)
+	mockRepository.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+	mockTransactor.AssertExpectations(t)
+}
+
+func TestCreate_SkipsOutboxWhenCreateFails(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockOutbox := new(MockIOutboxRepository)
+	service := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	service.Outbox = mockOutbox
+	ctx := context.Background()
+
+	mockCrypto.On("GenerateFromPassword", mock.Anything).Return("hashedPassword", nil)
+	mockRepository.On("Create", ctx, mock.Anything).Return(assert.AnError)
+
+	// Act
+	result, err := service.Create(ctx, &publicModel.CreateUserModel{
+		Email:    "test@mail.com",
+		Username: "test",
+		Password: "test",
+	})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockOutbox.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+}
+
+func TestCreate_Audited(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockAudit := new(MockIAuditService)
+	service := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	service.Audit = mockAudit
+	ctx := context.Background()
+
+	mockCrypto.On("GenerateFromPassword", mock.Anything).Return("hashedPassword", nil)
+	mockRepository.On("Create", ctx, mock.Anything).Return(nil)
+	mockAudit.On("Record", ctx, model.AuditActionCreate, mock.Anything, (*model.PrivateUserModel)(nil), mock.Anything).Return(assert.AnError)
+
+	// Act
+	result, err := service.Create(ctx, &publicModel.CreateUserModel{
+		Email:    "test@mail.com",
+		Username: "test",
+		Password: "test",
+	})
+
+	// Assert: the user exists, so a failed audit write does not fail the request
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestFindByIdentifierPrivate_Audited(t *testing.T) {
+	mockRepo := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockAudit := new(MockIAuditService)
+	userService := service.NewUserService(mockRepo, mockCrypto, testLogger)
+	userService.Audit = mockAudit
+
+	ctx := context.Background()
+	testUser := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test"}
+
+	mockRepo.On("FindByUsername", ctx, "test").Return(testUser, nil)
+	mockAudit.On("Record", ctx, model.AuditActionLookupPrivate, testUser.ID.Hex(), (*model.PrivateUserModel)(nil), (*model.PrivateUserModel)(nil)).Return(nil)
+
+	user, err := userService.FindByIdentifierPrivate(ctx, "test")
+
+	assert.NoError(t, err)
+	assert.Equal(t, testUser, user)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestFindByIdentifierPrivate_FailsWithoutAuditRecord(t *testing.T) {
+	mockRepo := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockAudit := new(MockIAuditService)
+	userService := service.NewUserService(mockRepo, mockCrypto, testLogger)
+	userService.Audit = mockAudit
+
+	ctx := context.Background()
+	testUser := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test"}
+
+	mockRepo.On("FindByUsername", ctx, "test").Return(testUser, nil)
+	mockAudit.On("Record", ctx, model.AuditActionLookupPrivate, testUser.ID.Hex(), mock.Anything, mock.Anything).Return(assert.AnError)
+
+	user, err := userService.FindByIdentifierPrivate(ctx, "test")
+
+	assert.Error(t, err)
+	assert.Nil(t, user)
+}
+
+func TestFindByIdentifierPrivate_AuditContention(t *testing.T) {
+	mockRepo := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockAudit := new(MockIAuditService)
+	userService := service.NewUserService(mockRepo, mockCrypto, testLogger)
+	userService.Audit = mockAudit
+
+	ctx := context.Background()
+	testUser := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test"}
+
+	mockRepo.On("FindByUsername", ctx, "test").Return(testUser, nil)
+	mockAudit.On("Record", ctx, model.AuditActionLookupPrivate, testUser.ID.Hex(), mock.Anything, mock.Anything).Return(service.ErrAuditContention)
+
+	user, err := userService.FindByIdentifierPrivate(ctx, "test")
+
+	assert.NoError(t, err)
+	assert.Equal(t, testUser, user)
+}
+
+func TestCreate_IdempotentReplay(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockIdempotency := new(MockIIdempotencyService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	userService.Idempotency = mockIdempotency
+	ctx := service.WithIdempotencyKey(service.WithActor(context.Background(), service.Actor{UserID: "-1"}), "key")
+	createUserModel := &publicModel.CreateUserModel{Email: "test@mail.com", Username: "test", Password: "test"}
+	firstUser := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "test@mail.com", Username: "test"}
+	stored := `{"id":"` + firstUser.ID.Hex() + `"}`
+
+	mockIdempotency.On("Begin", ctx, "default/-1", "key", createUserModel).Return(json.RawMessage(stored), nil)
+	mockRepository.On("FindById", ctx, firstUser.ID.Hex()).Return(firstUser, nil)
+
+	// Act
+	result, err := userService.Create(ctx, createUserModel)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, firstUser, result)
+	mockRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockIdempotency.AssertExpectations(t)
+}
+
+func TestCreate_IdempotentFirstRequest(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockIdempotency := new(MockIIdempotencyService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	userService.Idempotency = mockIdempotency
+	ctx := service.WithIdempotencyKey(service.WithActor(context.Background(), service.Actor{UserID: "-1"}), "key")
+	createUserModel := &publicModel.CreateUserModel{Email: "test@mail.com", Username: "test", Password: "test"}
+
+	mockIdempotency.On("Begin", ctx, "default/-1", "key", createUserModel).Return(nil, nil)
+	mockCrypto.On("GenerateFromPassword", mock.Anything).Return("hashedPassword", nil)
+	mockRepository.On("Create", ctx, mock.Anything).Return(nil)
+	mockIdempotency.On("Complete", ctx, "default/-1", "key", mock.Anything).Return(nil)
+
+	// Act
+	result, err := userService.Create(ctx, createUserModel)
+
+	// Assert
+	assert.NoError(t, err)
+	stored, err := json.Marshal(mockIdempotency.Calls[1].Arguments.Get(3))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":"`+result.ID.Hex()+`"}`, string(stored))
+	mockIdempotency.AssertExpectations(t)
+}
+
+func TestCreate_IdempotentFailureReleasesKey(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockIdempotency := new(MockIIdempotencyService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	userService.Idempotency = mockIdempotency
+	ctx := service.WithIdempotencyKey(context.Background(), "key")
+
+	mockIdempotency.On("Begin", ctx, "default/", "key", mock.Anything).Return(nil, nil)
+	mockCrypto.On("GenerateFromPassword", mock.Anything).Return("hashedPassword", nil)
+	mockRepository.On("Create", ctx, mock.Anything).Return(assert.AnError)
+	mockIdempotency.On("Abandon", ctx, "default/", "key").Return(nil)
+
+	// Act
+	result, err := userService.Create(ctx, &publicModel.CreateUserModel{Email: "test@mail.com", Username: "test", Password: "test"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockIdempotency.AssertExpectations(t)
+}
+
+func TestFindByIdentifierPrivate_SelfScopeRejectsOtherUsers(t *testing.T) {
+	mockRepo := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockAudit := new(MockIAuditService)
+	userService := service.NewUserService(mockRepo, mockCrypto, testLogger)
+	userService.Audit = mockAudit
+
+	ctx := authz.WithScope(context.Background(), authz.Scope{UserID: primitive.NewObjectID().Hex(), SelfOnly: true})
+	testUser := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test"}
+
+	mockRepo.On("FindByUsername", ctx, "test").Return(testUser, nil)
+
+	user, err := userService.FindByIdentifierPrivate(ctx, "test")
+
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+	assert.Nil(t, user)
+	mockAudit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdate_Username(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockAudit := new(MockIAuditService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	userService.Audit = mockAudit
+	ctx := context.Background()
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "test@mail.com", Username: "test", Hash: "hash"}
+	username := "renamed"
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+	mockRepository.On("Update", ctx, mock.MatchedBy(func(user *model.PrivateUserModel) bool {
+		return user.Username == "renamed" && user.Email == "test@mail.com" && user.Hash == "hash"
+	})).Return(nil)
+	mockAudit.On("Record", ctx, model.AuditActionUpdate, existing.ID.Hex(), existing, mock.Anything).Return(nil)
+
+	// Act
+	result, err := userService.Update(ctx, existing.ID.Hex(), &publicModel.UpdateUserModel{Username: &username})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed", result.Username)
+	assert.Equal(t, "test", existing.Username)
+	mockCrypto.AssertNotCalled(t, "CompareHashAndPassword", mock.Anything, mock.Anything)
+	mockRepository.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestUpdate_PasswordPublishesPasswordChanged(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockOutbox := new(MockIOutboxRepository)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	userService.Outbox = mockOutbox
+	ctx := context.Background()
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "test@mail.com", Username: "test", Hash: "hash"}
+	password := "new-password"
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+	mockCrypto.On("CompareHashAndPassword", "hash", "old-password").Return(nil)
+	mockCrypto.On("GenerateFromPassword", "new-password").Return("new-hash", nil)
+	mockRepository.On("Update", ctx, mock.Anything).Return(nil)
+	mockOutbox.On("Add", ctx, mock.MatchedBy(func(event *model.OutboxEvent) bool {
+		return event.Type == model.PasswordChangedEvent
+	})).Return(nil)
+
+	// Act
+	result, err := userService.Update(ctx, existing.ID.Hex(), &publicModel.UpdateUserModel{Password: &password, CurrentPassword: "old-password"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "new-hash", result.Hash)
+	mockOutbox.AssertExpectations(t)
+}
+
+func TestUpdate_RequiresCurrentPasswordForEmail(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	ctx := context.Background()
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "test@mail.com", Hash: "hash"}
+	email := "new@mail.com"
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+	mockCrypto.On("CompareHashAndPassword", "hash", "").Return(assert.AnError)
+
+	// Act
+	result, err := userService.Update(ctx, existing.ID.Hex(), &publicModel.UpdateUserModel{Email: &email})
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrPasswordConfirmation)
+	assert.Nil(t, result)
+	mockRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdate_InvalidFields(t *testing.T) {
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	ctx := context.Background()
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Hash: "hash"}
+	blank, invalidEmail := " ", "not-an-email"
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+	mockCrypto.On("CompareHashAndPassword", "hash", "password").Return(nil)
+
+	for _, update := range []*publicModel.UpdateUserModel{
+		{},
+		{Username: &blank},
+		{Email: &invalidEmail, CurrentPassword: "password"},
+	} {
+		_, err := userService.Update(ctx, existing.ID.Hex(), update)
+		assert.ErrorIs(t, err, service.ErrInvalidUserUpdate)
+	}
+	mockRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdate_SelfScopeRejectsOtherUsers(t *testing.T) {
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	ctx := authz.WithScope(context.Background(), authz.Scope{UserID: "caller", SelfOnly: true})
+	username := "renamed"
+
+	_, err := userService.Update(ctx, "someone-else", &publicModel.UpdateUserModel{Username: &username})
+
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+	mockRepository.AssertNotCalled(t, "FindById", mock.Anything, mock.Anything)
+}
+
+func TestDelete_Success(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockAudit := new(MockIAuditService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	userService.Audit = mockAudit
+	ctx := context.Background()
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Hash: "hash"}
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+	mockCrypto.On("CompareHashAndPassword", "hash", "password").Return(nil)
+	mockRepository.On("Delete", ctx, existing).Return(nil)
+	mockAudit.On("Record", ctx, model.AuditActionDelete, existing.ID.Hex(), existing, (*model.PrivateUserModel)(nil)).Return(nil)
+
+	// Act
+	err := userService.Delete(ctx, existing.ID.Hex(), "password")
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepository.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestDelete_WrongPassword(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	ctx := context.Background()
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Hash: "hash"}
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+	mockCrypto.On("CompareHashAndPassword", "hash", "wrong").Return(assert.AnError)
+
+	// Act
+	err := userService.Delete(ctx, existing.ID.Hex(), "wrong")
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrPasswordConfirmation)
+	mockRepository.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDelete_WrongPasswordCountsTowardsLockout(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockLockout := new(MockILockoutService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	userService.Lockout = mockLockout
+	ctx := service.WithActor(context.Background(), service.Actor{SourceIP: "10.0.0.1"})
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Hash: "hash"}
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+	mockCrypto.On("CompareHashAndPassword", "hash", "wrong").Return(assert.AnError)
+	mockLockout.On("Allow", ctx, existing, "10.0.0.1").Return(nil).Once()
+	mockLockout.On("RecordFailure", ctx, existing, "10.0.0.1").Return(nil).Once()
+	mockLockout.On("Allow", ctx, existing, "10.0.0.1").Return(&service.AccountLockedError{Until: time.Now().Add(time.Minute)})
+
+	// Act
+	wrongErr := userService.Delete(ctx, existing.ID.Hex(), "wrong")
+	lockedErr := userService.Delete(ctx, existing.ID.Hex(), "wrong")
+
+	// Assert
+	assert.ErrorIs(t, wrongErr, service.ErrPasswordConfirmation)
+	var lockedError *service.AccountLockedError
+	assert.ErrorAs(t, lockedErr, &lockedError)
+	mockCrypto.AssertNumberOfCalls(t, "CompareHashAndPassword", 1)
+	mockLockout.AssertExpectations(t)
+	mockRepository.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestUpdate_ConfirmedPasswordEndsFailures(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockLockout := new(MockILockoutService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	userService.Lockout = mockLockout
+	ctx := context.Background()
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "test@mail.com", Username: "test", Hash: "hash"}
+	email := "new@mail.com"
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+	mockCrypto.On("CompareHashAndPassword", "hash", "current").Return(nil)
+	mockLockout.On("Allow", ctx, existing, "").Return(nil)
+	mockLockout.On("RecordSuccess", ctx, existing, "").Return(nil)
+	mockRepository.On("Update", ctx, mock.Anything).Return(nil)
+
+	// Act
+	result, err := userService.Update(ctx, existing.ID.Hex(), &publicModel.UpdateUserModel{Email: &email, CurrentPassword: "current"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, email, result.Email)
+	mockLockout.AssertExpectations(t)
+	mockLockout.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreate_InvalidProfile(t *testing.T) {
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+
+	result, err := userService.Create(context.Background(), &publicModel.CreateUserModel{
+		Email:    "test@mail.com",
+		Username: "test",
+		Password: "test",
+		Profile:  &publicModel.ProfileModel{AvatarURL: "not a url"},
+	})
+
+	assert.ErrorIs(t, err, service.ErrInvalidProfile)
+	assert.Nil(t, result)
+	mockCrypto.AssertNotCalled(t, "GenerateFromPassword", mock.Anything)
+	mockRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUpdate_ReplacesProfile(t *testing.T) {
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	ctx := context.Background()
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Profile: &publicModel.ProfileModel{Bio: "old"}}
+	profile := &publicModel.ProfileModel{DisplayName: "Test"}
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+	mockRepository.On("Update", ctx, mock.Anything).Return(nil)
+
+	result, err := userService.Update(ctx, existing.ID.Hex(), &publicModel.UpdateUserModel{Profile: profile})
+
+	assert.NoError(t, err)
+	assert.Equal(t, profile, result.Profile)
+	mockCrypto.AssertNotCalled(t, "CompareHashAndPassword", mock.Anything, mock.Anything)
+}
+
+func newTestHashingRegistry() *hashing.Registry {
+	return hashing.NewRegistry(
+		hashing.NewArgon2idHasher(hashing.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}),
+		hashing.NewBcryptHasher(bcrypt.MinCost),
+	)
+}
+
+func TestVerifyCredentials_RehashesOutdatedHash(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	registry := newTestHashingRegistry()
+	userService := service.NewUserService(mockRepository, registry, testLogger)
+	ctx := context.Background()
+	legacyHash, _ := hashing.NewBcryptHasher(bcrypt.MinCost).Hash("correct horse")
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test", Hash: legacyHash}
+
+	mockRepository.On("FindByUsername", ctx, "test").Return(existing, nil)
+	mockRepository.On("Update", ctx, mock.MatchedBy(func(user *model.PrivateUserModel) bool {
+		return registry.Preferred.Identifies(user.Hash) && registry.CompareHashAndPassword(user.Hash, "correct horse") == nil
+	})).Return(nil)
+
+	// Act
+	result, err := userService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{Identifier: "test", Password: "correct horse"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, registry.Preferred.Identifies(result.Hash))
+	assert.Equal(t, legacyHash, existing.Hash)
+	mockRepository.AssertExpectations(t)
+}
+
+func TestVerifyCredentials_CurrentHashNotRewritten(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	registry := newTestHashingRegistry()
+	userService := service.NewUserService(mockRepository, registry, testLogger)
+	ctx := context.Background()
+	hash, _ := registry.GenerateFromPassword("correct horse")
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test", Hash: hash}
+
+	mockRepository.On("FindByUsername", ctx, "test").Return(existing, nil)
+
+	// Act
+	result, err := userService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{Identifier: "test", Password: "correct horse"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, existing, result)
+	mockRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestVerifyCredentials_InvalidCredentials(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	userService := service.NewUserService(mockRepository, newTestHashingRegistry(), testLogger)
+	ctx := context.Background()
+	hash, _ := hashing.NewBcryptHasher(bcrypt.MinCost).Hash("correct horse")
+
+	mockRepository.On("FindByUsername", ctx, "test").Return(&model.PrivateUserModel{ID: primitive.NewObjectID(), Hash: hash}, nil)
+	mockRepository.On("FindByUsername", ctx, "missing").Return(nil, common_error.NewServiceError(common_error.NotFound, "User not found", nil))
+
+	// Act
+	_, wrongPasswordErr := userService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{Identifier: "test", Password: "wrong horse"})
+	_, unknownUserErr := userService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{Identifier: "missing", Password: "correct horse"})
+
+	// Assert
+	assert.ErrorIs(t, wrongPasswordErr, service.ErrInvalidCredentials)
+	assert.ErrorIs(t, unknownUserErr, service.ErrInvalidCredentials)
+	mockRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestVerifyCredentials_UnknownUserVerifiesDummyHash(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	ctx := context.Background()
+
+	mockRepository.On("FindByUsername", ctx, "missing").Return(nil, common_error.NewServiceError(common_error.NotFound, "User not found", nil))
+	mockCrypto.On("GenerateFromPassword", mock.Anything).Return("dummy hash", nil).Once()
+	mockCrypto.On("CompareHashAndPassword", "dummy hash", "correct horse").Return(errors.New("mismatch"))
+
+	// Act
+	_, firstErr := userService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{Identifier: "missing", Password: "correct horse"})
+	_, secondErr := userService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{Identifier: "missing", Password: "correct horse"})
+
+	// Assert
+	assert.ErrorIs(t, firstErr, service.ErrInvalidCredentials)
+	assert.ErrorIs(t, secondErr, service.ErrInvalidCredentials)
+	mockCrypto.AssertNumberOfCalls(t, "GenerateFromPassword", 1)
+	mockCrypto.AssertNumberOfCalls(t, "CompareHashAndPassword", 2)
+}
+
+func TestUpdate_RehashesConfirmedPassword(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	registry := newTestHashingRegistry()
+	userService := service.NewUserService(mockRepository, registry, testLogger)
+	ctx := context.Background()
+	legacyHash, _ := hashing.NewBcryptHasher(bcrypt.MinCost).Hash("correct horse")
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "test@mail.com", Hash: legacyHash}
+	email := "new@mail.com"
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+	mockRepository.On("Update", ctx, mock.Anything).Return(nil)
+
+	// Act
+	result, err := userService.Update(ctx, existing.ID.Hex(), &publicModel.UpdateUserModel{Email: &email, CurrentPassword: "correct horse"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "new@mail.com", result.Email)
+	assert.True(t, registry.Preferred.Identifies(result.Hash))
+}
+
+func TestImport_StoresRecognisedHash(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockAudit := new(MockIAuditService)
+	userService := service.NewUserService(mockRepository, newTestHashingRegistry(), testLogger)
+	userService.Audit = mockAudit
+	ctx := context.Background()
+	legacyHash, _ := hashing.NewBcryptHasher(bcrypt.MinCost).Hash("correct horse")
+
+	mockRepository.On("Create", ctx, mock.MatchedBy(func(user *model.PrivateUserModel) bool {
+		return user.Hash == legacyHash && user.Username == "imported"
+	})).Return(nil)
+	mockAudit.On("Record", ctx, model.AuditActionImport, mock.Anything, (*model.PrivateUserModel)(nil), mock.Anything).Return(nil)
+
+	// Act
+	result, err := userService.Import(ctx, &publicModel.ImportUserModel{Email: "imported@mail.com", Username: "imported", PasswordHash: legacyHash})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, legacyHash, result.Hash)
+	mockRepository.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestImport_RejectsUnknownHash(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	ctx := context.Background()
+
+	// Act
+	_, unknownErr := service.NewUserService(mockRepository, newTestHashingRegistry(), testLogger).Import(ctx, &publicModel.ImportUserModel{Username: "imported", PasswordHash: "5f4dcc3b5aa765d61d8327deb882cf99"})
+	_, unsupportedErr := service.NewUserService(mockRepository, new(MockCryptoService), testLogger).Import(ctx, &publicModel.ImportUserModel{Username: "imported", PasswordHash: "$2a$04$hash"})
+	_, zeroIterationsErr := service.NewUserService(mockRepository, newTestHashingRegistry(), testLogger).Import(ctx, &publicModel.ImportUserModel{Username: "imported", PasswordHash: "$argon2id$v=19$m=64,t=0,p=1$c2FsdA$aGFzaA"})
+	_, zeroParallelismErr := service.NewUserService(mockRepository, newTestHashingRegistry(), testLogger).Import(ctx, &publicModel.ImportUserModel{Username: "imported", PasswordHash: "$scrypt$ln=4,r=8,p=0$c2FsdA$aGFzaA"})
+
+	// Assert
+	assert.ErrorIs(t, unknownErr, service.ErrUnsupportedPasswordHash)
+	assert.ErrorIs(t, unsupportedErr, service.ErrUnsupportedPasswordHash)
+	assert.ErrorIs(t, zeroIterationsErr, service.ErrUnsupportedPasswordHash)
+	assert.ErrorIs(t, zeroParallelismErr, service.ErrUnsupportedPasswordHash)
+	mockRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreate_RejectsWeakPassword(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	userService.PasswordPolicy = passwordpolicy.DefaultPolicy(testLogger)
+
+	// Act
+	result, err := userService.Create(context.Background(), &publicModel.CreateUserModel{Email: "test@mail.com", Username: "test", Password: "password123"})
+
+	// Assert
+	assert.ErrorIs(t, err, passwordpolicy.ErrWeakPassword)
+	assert.Nil(t, result)
+	mockCrypto.AssertNotCalled(t, "GenerateFromPassword", mock.Anything)
+	mockRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUpdate_RejectsPasswordContainingUsername(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	userService.PasswordPolicy = passwordpolicy.DefaultPolicy(testLogger)
+	ctx := context.Background()
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "test@mail.com", Username: "johnsmith", Hash: "hash"}
+	password := "xJohnSmith#2!q"
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+	mockCrypto.On("CompareHashAndPassword", "hash", "old-password").Return(nil)
+
+	// Act
+	result, err := userService.Update(ctx, existing.ID.Hex(), &publicModel.UpdateUserModel{Password: &password, CurrentPassword: "old-password"})
+
+	// Assert
+	var validationError *passwordpolicy.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Equal(t, passwordpolicy.ReasonContainsUserInfo, validationError.Reasons[0].Code)
+	assert.Nil(t, result)
+	mockRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestResetPassword_Success(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	mockOutbox := new(MockIOutboxRepository)
+	mockAudit := new(MockIAuditService)
+	userService := service.NewUserService(mockRepository, mockCrypto, testLogger)
+	userService.PasswordPolicy = passwordpolicy.DefaultPolicy(testLogger)
+	userService.Outbox = mockOutbox
+	userService.Audit = mockAudit
+	ctx := context.Background()
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Email: "test@mail.com", Username: "test", Hash: "hash"}
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+	mockCrypto.On("GenerateFromPassword", "kX9#mQ2!vLp7").Return("new-hash", nil)
+	mockRepository.On("Update", ctx, mock.MatchedBy(func(user *model.PrivateUserModel) bool {
+		return user.Hash == "new-hash"
+	})).Return(nil)
+	mockOutbox.On("Add", ctx, mock.MatchedBy(func(event *model.OutboxEvent) bool {
+		return event.Type == model.PasswordChangedEvent
+	})).Return(nil)
+	mockAudit.On("Record", ctx, model.AuditActionPasswordReset, existing.ID.Hex(), existing, mock.Anything).Return(nil)
+
+	// Act
+	err := userService.ResetPassword(ctx, existing.ID.Hex(), "kX9#mQ2!vLp7")
+
+	// Assert
+	assert.NoError(t, err)
+	mockCrypto.AssertNotCalled(t, "CompareHashAndPassword", mock.Anything, mock.Anything)
+	mockRepository.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestResetPassword_RejectsWeakPassword(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	userService := service.NewUserService(mockRepository, new(MockCryptoService), testLogger)
+	userService.PasswordPolicy = passwordpolicy.DefaultPolicy(testLogger)
+	ctx := context.Background()
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test", Hash: "hash"}
+
+	mockRepository.On("FindById", ctx, existing.ID.Hex()).Return(existing, nil)
+
+	// Act
+	err := userService.ResetPassword(ctx, existing.ID.Hex(), "letmein")
+
+	// Assert
+	assert.ErrorIs(t, err, passwordpolicy.ErrWeakPassword)
+	mockRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+type MockILockoutService struct {
+	mock.Mock
+}
+
+// Allow implements service.ILockoutService.
+func (m *MockILockoutService) Allow(ctx context.Context, user *model.PrivateUserModel, sourceIP string) error {
+	args := m.Called(ctx, user, sourceIP)
+	return args.Error(0)
+}
+
+// RecordFailure implements service.ILockoutService.
+func (m *MockILockoutService) RecordFailure(ctx context.Context, user *model.PrivateUserModel, sourceIP string) error {
+	args := m.Called(ctx, user, sourceIP)
+	return args.Error(0)
+}
+
+// RecordSuccess implements service.ILockoutService.
+func (m *MockILockoutService) RecordSuccess(ctx context.Context, user *model.PrivateUserModel, sourceIP string) error {
+	args := m.Called(ctx, user, sourceIP)
+	return args.Error(0)
+}
+
+// Unlock implements service.ILockoutService.
+func (m *MockILockoutService) Unlock(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// History implements service.ILockoutService.
+func (m *MockILockoutService) History(ctx context.Context, id string, limit int64) ([]*model.LoginAttempt, error) {
+	args := m.Called(ctx, id, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.LoginAttempt), args.Error(1)
+}
+
+// Ensure that MockILockoutService implements ILockoutService.
+var _ service.ILockoutService = &MockILockoutService{}
+
+func TestVerifyCredentials_RefusedDuringLockout(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockLockout := new(MockILockoutService)
+	userService := service.NewUserService(mockRepository, newTestHashingRegistry(), testLogger)
+	userService.Lockout = mockLockout
+	ctx := service.WithActor(context.Background(), service.Actor{SourceIP: "10.0.0.1"})
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test"}
+	locked := &service.AccountLockedError{Until: time.Now().Add(time.Minute)}
+
+	mockRepository.On("FindByUsername", ctx, "test").Return(existing, nil)
+	mockLockout.On("Allow", ctx, existing, "10.0.0.1").Return(locked)
+
+	// Act
+	_, err := userService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{Identifier: "test", Password: "correct horse"})
+
+	// Assert
+	assert.ErrorIs(t, err, service.ErrAccountLocked)
+	mockLockout.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything, mock.Anything)
+	mockLockout.AssertNotCalled(t, "RecordSuccess", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVerifyCredentials_RecordsAttempts(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockLockout := new(MockILockoutService)
+	registry := newTestHashingRegistry()
+	userService := service.NewUserService(mockRepository, registry, testLogger)
+	userService.Lockout = mockLockout
+	ctx := context.Background()
+	hash, _ := registry.GenerateFromPassword("correct horse")
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test", Hash: hash}
+
+	mockRepository.On("FindByUsername", ctx, "test").Return(existing, nil)
+	mockRepository.On("FindByUsername", ctx, "missing").Return(nil, common_error.NewServiceError(common_error.NotFound, "User not found", nil))
+	mockLockout.On("Allow", ctx, mock.Anything, "10.0.0.2").Return(nil)
+	mockLockout.On("RecordSuccess", ctx, existing, "10.0.0.2").Return(nil).Once()
+	mockLockout.On("RecordFailure", ctx, existing, "10.0.0.2").Return(nil).Once()
+	mockLockout.On("RecordFailure", ctx, (*model.PrivateUserModel)(nil), "10.0.0.2").Return(nil).Once()
+
+	// Act
+	_, successErr := userService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{Identifier: "test", Password: "correct horse", SourceIP: "10.0.0.2"})
+	_, wrongPasswordErr := userService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{Identifier: "test", Password: "wrong horse", SourceIP: "10.0.0.2"})
+	_, unknownUserErr := userService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{Identifier: "missing", Password: "correct horse", SourceIP: "10.0.0.2"})
+
+	// Assert
+	assert.NoError(t, successErr)
+	assert.ErrorIs(t, wrongPasswordErr, service.ErrInvalidCredentials)
+	assert.ErrorIs(t, unknownUserErr, service.ErrInvalidCredentials)
+	mockLockout.AssertExpectations(t)
+}
+
+func TestVerifyCredentials_MFAUserSuccessNotRecorded(t *testing.T) {
+	// Arrange
+	mockRepository := new(MockIUserRepository)
+	mockLockout := new(MockILockoutService)
+	registry := newTestHashingRegistry()
+	userService := service.NewUserService(mockRepository, registry, testLogger)
+	userService.Lockout = mockLockout
+	ctx := context.Background()
+	hash, _ := registry.GenerateFromPassword("correct horse")
+	existing := &model.PrivateUserModel{ID: primitive.NewObjectID(), Username: "test", Hash: hash, MFAEnabled: true}
+
+	mockRepository.On("FindByUsername", ctx, "test").Return(existing, nil)
+	mockLockout.On("Allow", ctx, existing, "").Return(nil)
+
+	// Act
+	result, err := userService.VerifyCredentials(ctx, &publicModel.VerifyCredentialsModel{Identifier: "test", Password: "correct horse"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.MFAEnabled)
+	mockLockout.AssertNotCalled(t, "RecordSuccess", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserService_Metrics(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockIUserRepository)
+	mockCrypto := new(MockCryptoService)
+	userService := service.NewUserService(mockRepo, mockCrypto, testLogger)
+	userService.Metrics = metrics.NewUserCounters(prometheus.NewRegistry())
+	ctx := context.Background()
+	taken := &publicModel.CreateUserModel{Email: "taken@mail.com", Username: "taken", Password: "test"}
+	userID := primitive.NewObjectID().Hex()
+
+	mockCrypto.On("GenerateFromPassword", mock.Anything).Return("hashedPassword", nil)
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(user *model.PrivateUserModel) bool { return user.Username == "test" })).Return(nil)
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(user *model.PrivateUserModel) bool { return user.Username == "taken" })).
+		Return(common_error.NewServiceError(common_error.Conflict, "User creation failed", nil))
+	mockRepo.On("FindByUsername", ctx, "test").Return(&model.PrivateUserModel{Username: "test"}, nil)
+	mockRepo.On("FindByIds", ctx, []string{userID}).Return([]*model.PrivateUserModel{}, nil)
+	mockRepo.On("FindByEmails", ctx, []string{"a@mail.com", "b@mail.com"}).Return([]*model.PrivateUserModel{}, nil)
+
+	// Act
+	_, createErr := userService.Create(ctx, &publicModel.CreateUserModel{Email: "test@mail.com", Username: "test", Password: "test"})
+	_, conflictErr := userService.Create(ctx, taken)
+	_, findErr := userService.FindByIdentifier(ctx, "test")
+	_, batchErr := userService.BatchFindByIdentifier(ctx, []string{userID, "a@mail.com", "b@mail.com", "a@mail.com"})
+
+	// Assert
+	assert.NoError(t, createErr)
+	assert.Error(t, conflictErr)
+	assert.NoError(t, findErr)
+	assert.NoError(t, batchErr)
+	assert.Equal(t, float64(1), testutil.ToFloat64(userService.Metrics.Created.WithLabelValues(metrics.SourceCreate)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(userService.Metrics.Conflicts.WithLabelValues(metrics.OperationCreate)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(userService.Metrics.Lookups.WithLabelValues(metrics.IdentifierUsername)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(userService.Metrics.Lookups.WithLabelValues(metrics.IdentifierID)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(userService.Metrics.Lookups.WithLabelValues(metrics.IdentifierEmail)))
+}
@@ -0,0 +1,84 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	// DefaultTenantID is used when a request names no tenant, and holds the users created before tenants existed.
+	DefaultTenantID = "default"
+	// AllTenants selects the cross-tenant mode, which lifts the tenant filter for callers allowed to use it.
+	AllTenants = "*"
+)
+
+var (
+	// ErrTenantMismatch is returned when a request names a tenant other than the one in the caller's token.
+	ErrTenantMismatch = errors.New("requested tenant does not match the authenticated tenant")
+	// ErrTenantRequired is returned when an operation needs a single tenant but runs in cross-tenant mode.
+	ErrTenantRequired = errors.New("operation requires a single tenant")
+	// ErrCrossTenantAnonymous is returned when an unauthenticated request asks for cross-tenant mode.
+	ErrCrossTenantAnonymous = errors.New("cross-tenant mode requires authentication")
+)
+
+type tenantContextKey struct{}
+
+type crossingContextKey struct{}
+
+// WithTenant returns a copy of ctx scoped to the tenant.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// FromContext returns the tenant stored by WithTenant, or DefaultTenantID when there is none.
+func FromContext(ctx context.Context) string {
+	if tenantID, ok := ctx.Value(tenantContextKey{}).(string); ok && tenantID != "" {
+		return tenantID
+	}
+	return DefaultTenantID
+}
+
+// IsAllTenants reports whether ctx runs in cross-tenant mode.
+func IsAllTenants(ctx context.Context) bool {
+	return FromContext(ctx) == AllTenants
+}
+
+// WithCrossing returns a copy of ctx marked as working outside the tenant of the caller's token.
+func WithCrossing(ctx context.Context) context.Context {
+	return context.WithValue(ctx, crossingContextKey{}, true)
+}
+
+// IsCrossing reports whether ctx runs in cross-tenant mode or was marked by WithCrossing. Such requests
+// are only authorized for callers allowed to use cross-tenant mode.
+func IsCrossing(ctx context.Context) bool {
+	crossing, _ := ctx.Value(crossingContextKey{}).(bool)
+	return crossing || IsAllTenants(ctx)
+}
+
+// Crosses reports whether an authenticated request resolved to tenantID works outside the tenant of its
+// token: in cross-tenant mode, or in a tenant other than DefaultTenantID named by a caller whose token
+// carries no tenant.
+func Crosses(claimed string, tenantID string, authenticated bool) bool {
+	return authenticated && (tenantID == AllTenants || claimed == "" && tenantID != DefaultTenantID)
+}
+
+// Resolve picks the tenant of a request. claimed is the tenant carried by the caller's token and
+// requested the one named in the request header or metadata. A token tenant can only be narrowed
+// to itself or widened to AllTenants; requests without either use DefaultTenantID. Callers whose token
+// carries no tenant may name any, but authorization then requires cross-tenant mode, see Crosses.
+func Resolve(claimed string, requested string, authenticated bool) (string, error) {
+	if requested == AllTenants && !authenticated {
+		return "", ErrCrossTenantAnonymous
+	}
+
+	switch {
+	case requested == "" && claimed == "":
+		return DefaultTenantID, nil
+	case requested == "":
+		return claimed, nil
+	case claimed == "", requested == claimed, requested == AllTenants:
+		return requested, nil
+	}
+
+	return "", ErrTenantMismatch
+}
@@ -0,0 +1,71 @@
+package tenant_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, tenant.DefaultTenantID, tenant.FromContext(context.Background()))
+	assert.Equal(t, "acme", tenant.FromContext(tenant.WithTenant(context.Background(), "acme")))
+	assert.True(t, tenant.IsAllTenants(tenant.WithTenant(context.Background(), tenant.AllTenants)))
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name          string
+		claimed       string
+		requested     string
+		authenticated bool
+		expected      string
+		err           error
+	}{
+		{"nothing named", "", "", false, tenant.DefaultTenantID, nil},
+		{"token tenant", "acme", "", true, "acme", nil},
+		{"header tenant", "", "acme", true, "acme", nil},
+		{"matching header", "acme", "acme", true, "acme", nil},
+		{"other tenant", "acme", "globex", true, "", tenant.ErrTenantMismatch},
+		{"cross-tenant", "acme", tenant.AllTenants, true, tenant.AllTenants, nil},
+		{"anonymous cross-tenant", "", tenant.AllTenants, false, "", tenant.ErrCrossTenantAnonymous},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantID, err := tenant.Resolve(tt.claimed, tt.requested, tt.authenticated)
+
+			assert.Equal(t, tt.expected, tenantID)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCrosses(t *testing.T) {
+	tests := []struct {
+		name          string
+		claimed       string
+		tenantID      string
+		authenticated bool
+		expected      bool
+	}{
+		{"anonymous header tenant", "", "acme", false, false},
+		{"token tenant", "acme", "acme", true, false},
+		{"no token tenant", "", tenant.DefaultTenantID, true, false},
+		{"header tenant without token tenant", "", "acme", true, true},
+		{"cross-tenant", "acme", tenant.AllTenants, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tenant.Crosses(tt.claimed, tt.tenantID, tt.authenticated))
+		})
+	}
+}
+
+func TestIsCrossing(t *testing.T) {
+	assert.False(t, tenant.IsCrossing(tenant.WithTenant(context.Background(), "acme")))
+	assert.True(t, tenant.IsCrossing(tenant.WithCrossing(tenant.WithTenant(context.Background(), "acme"))))
+	assert.True(t, tenant.IsCrossing(tenant.WithTenant(context.Background(), tenant.AllTenants)))
+}
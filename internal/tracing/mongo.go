@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// commandKey identifies a command in flight; request ids are only unique per connection.
+type commandKey struct {
+	connectionID string
+	requestID    int64
+}
+
+// NewCommandMonitor returns a monitor that traces every MongoDB command as a client span, named after the
+// command and child of the span of the context the command runs in. Command bodies are not recorded,
+// since they hold the documents being written.
+func NewCommandMonitor(provider trace.TracerProvider) *event.CommandMonitor {
+	tracer := provider.Tracer(InstrumentationName)
+	var spans sync.Map
+	finish := func(connectionID string, requestID int64, failure string) {
+		value, ok := spans.LoadAndDelete(commandKey{connectionID, requestID})
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		if failure != "" {
+			Fail(span, failure)
+		}
+		span.End()
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, started *event.CommandStartedEvent) {
+			_, span := tracer.Start(ctx, "mongo."+started.CommandName,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					semconv.DBSystemMongoDB,
+					semconv.DBName(started.DatabaseName),
+					semconv.DBOperation(started.CommandName),
+				),
+			)
+			spans.Store(commandKey{started.ConnectionID, started.RequestID}, span)
+		},
+		Succeeded: func(_ context.Context, succeeded *event.CommandSucceededEvent) {
+			finish(succeeded.ConnectionID, succeeded.RequestID, "")
+		},
+		Failed: func(_ context.Context, failed *event.CommandFailedEvent) {
+			// Failures such as duplicate keys quote the offending values
+			finish(failed.ConnectionID, failed.RequestID, "command failed")
+		},
+	}
+}
+
+// JoinCommandMonitors returns a monitor that passes each event to every monitor in turn.
+func JoinCommandMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, started *event.CommandStartedEvent) {
+			for _, monitor := range monitors {
+				if monitor.Started != nil {
+					monitor.Started(ctx, started)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, succeeded *event.CommandSucceededEvent) {
+			for _, monitor := range monitors {
+				if monitor.Succeeded != nil {
+					monitor.Succeeded(ctx, succeeded)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, failed *event.CommandFailedEvent) {
+			for _, monitor := range monitors {
+				if monitor.Failed != nil {
+					monitor.Failed(ctx, failed)
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,82 @@
+// Package tracing sets up OpenTelemetry tracing. Spans are exported to an OTLP collector, to stdout or
+// nowhere, and propagate between services in W3C Trace Context headers and metadata.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporters.
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterNone   = "none"
+)
+
+// ServiceName identifies the spans of the service.
+const ServiceName = "user-service"
+
+// InstrumentationName names the tracers of the service's own instrumentation.
+const InstrumentationName = "github.com/Bit-Bridge-Source/BitBridge-UserService-Go"
+
+// ErrUnknownExporter is returned by NewExporter for a name that is not one of the exporters.
+var ErrUnknownExporter = errors.New("unknown trace exporter")
+
+// Propagator reads and writes the W3C traceparent and tracestate headers.
+var Propagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+// NewExporter returns the exporter of a name, or nil for ExporterNone. The OTLP exporter is configured
+// with the standard OTEL_EXPORTER_OTLP_* environment variables; the stdout exporter writes to out.
+func NewExporter(ctx context.Context, name string, out io.Writer) (sdktrace.SpanExporter, error) {
+	switch name {
+	case ExporterOTLP:
+		return otlptracegrpc.New(ctx)
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(out))
+	case ExporterNone, "":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownExporter, name)
+}
+
+// NewTracerProvider returns a provider that exports spans in batches, or records none when exporter is nil.
+// It must be shut down to flush the last batch.
+func NewTracerProvider(exporter sdktrace.SpanExporter) *sdktrace.TracerProvider {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(ServiceName))),
+	}
+	if exporter == nil {
+		options = append(options, sdktrace.WithSampler(sdktrace.NeverSample()))
+	} else {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	return sdktrace.NewTracerProvider(options...)
+}
+
+// End ends a span, marking it failed when err is not nil. Error messages are redacted like log records,
+// since exported spans leave the service as well.
+func End(span trace.Span, err error) {
+	if err != nil {
+		Fail(span, logging.RedactString(err.Error()))
+	}
+	span.End()
+}
+
+// Fail marks a span failed with a description, which must not contain personal data.
+func Fail(span trace.Span, description string) {
+	span.SetStatus(codes.Error, description)
+	span.AddEvent(semconv.ExceptionEventName, trace.WithAttributes(semconv.ExceptionMessage(description)))
+}
@@ -0,0 +1,115 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/logging"
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewExporter(t *testing.T) {
+	// Setup
+	var out bytes.Buffer
+
+	// Test
+	none, noneErr := tracing.NewExporter(context.Background(), tracing.ExporterNone, &out)
+	stdout, stdoutErr := tracing.NewExporter(context.Background(), tracing.ExporterStdout, &out)
+	_, unknownErr := tracing.NewExporter(context.Background(), "zipkin", &out)
+
+	// Assert
+	assert.NoError(t, noneErr)
+	assert.Nil(t, none)
+	assert.NoError(t, stdoutErr)
+	assert.NotNil(t, stdout)
+	assert.ErrorIs(t, unknownErr, tracing.ErrUnknownExporter)
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	// Setup
+	var out bytes.Buffer
+	exporter, err := tracing.NewExporter(context.Background(), tracing.ExporterStdout, &out)
+	require.NoError(t, err)
+	provider := tracing.NewTracerProvider(exporter)
+
+	// Test
+	_, span := provider.Tracer(tracing.InstrumentationName).Start(context.Background(), "UserService.Create")
+	span.End()
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	// Assert
+	assert.Contains(t, out.String(), `"Name":"UserService.Create"`)
+	assert.Contains(t, out.String(), tracing.ServiceName)
+}
+
+func TestNewTracerProvider_WithoutExporter(t *testing.T) {
+	// Setup
+	provider := tracing.NewTracerProvider(nil)
+
+	// Test
+	_, span := provider.Tracer(tracing.InstrumentationName).Start(context.Background(), "UserService.Create")
+
+	// Assert
+	assert.False(t, span.IsRecording())
+	span.End()
+}
+
+func TestEnd(t *testing.T) {
+	// Setup
+	exporter := tracetest.NewInMemoryExporter()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)).Tracer(tracing.InstrumentationName)
+	_, okSpan := tracer.Start(context.Background(), "ok")
+	_, failedSpan := tracer.Start(context.Background(), "failed")
+
+	// Test
+	tracing.End(okSpan, nil)
+	tracing.End(failedSpan, errors.New("no user test@mail.com"))
+
+	// Assert
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, "no user "+logging.Redacted, spans[1].Status.Description)
+	require.Len(t, spans[1].Events, 1)
+	assert.Equal(t, semconv.ExceptionEventName, spans[1].Events[0].Name)
+}
+
+func TestNewCommandMonitor(t *testing.T) {
+	// Setup
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	monitor := tracing.JoinCommandMonitors(tracing.NewCommandMonitor(provider), &event.CommandMonitor{})
+	ctx, parent := provider.Tracer(tracing.InstrumentationName).Start(context.Background(), "UserService.Create")
+
+	// Test
+	monitor.Started(ctx, &event.CommandStartedEvent{CommandName: "insert", DatabaseName: "user", RequestID: 1, ConnectionID: "a"})
+	monitor.Started(ctx, &event.CommandStartedEvent{CommandName: "find", DatabaseName: "user", RequestID: 1, ConnectionID: "b"})
+	monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "insert", RequestID: 1, ConnectionID: "a"}, Failure: "E11000 duplicate key { email: \"test@mail.com\" }"})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "find", RequestID: 1, ConnectionID: "b"}})
+	parent.End()
+
+	// Assert
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	insert, find := spans[0], spans[1]
+	assert.Equal(t, "mongo.insert", insert.Name)
+	assert.Equal(t, trace.SpanKindClient, insert.SpanKind)
+	assert.Equal(t, parent.SpanContext().SpanID(), insert.Parent.SpanID())
+	assert.Contains(t, insert.Attributes, semconv.DBSystemMongoDB)
+	assert.Contains(t, insert.Attributes, semconv.DBOperation("insert"))
+	assert.Equal(t, codes.Error, insert.Status.Code)
+	assert.Equal(t, "command failed", insert.Status.Description)
+	assert.Equal(t, "mongo.find", find.Name)
+	assert.Equal(t, codes.Unset, find.Status.Code)
+}
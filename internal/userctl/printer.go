@@ -0,0 +1,138 @@
+package userctl
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	pbv2 "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// IPrinter writes the results of commands in an output format.
+type IPrinter interface {
+	User(user *pbv2.User) error
+	PublicUser(user *pbv2.PublicUser) error
+	BatchResults(response *pbv2.BatchGetPublicUsersResponse) error
+	ErasureReceipt(userID string, receipt *pbv2.EraseUserResponse) error
+}
+
+// NewPrinter returns the printer of an output format.
+func NewPrinter(format string, out io.Writer) (IPrinter, error) {
+	switch format {
+	case OutputTable:
+		return &TablePrinter{Out: out}, nil
+	case OutputJSON:
+		return &JSONPrinter{Out: out}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown output format %q", ErrUsage, format)
+}
+
+// withoutHash returns a copy of the user without the password hash, which userctl never prints.
+func withoutHash(user *pbv2.User) *pbv2.User {
+	user = proto.Clone(user).(*pbv2.User)
+	user.Hash = ""
+	return user
+}
+
+// JSONPrinter writes messages as JSON with their proto field names, the format of the HTTP API.
+type JSONPrinter struct {
+	Out io.Writer
+}
+
+var _ IPrinter = (*JSONPrinter)(nil)
+
+func (p *JSONPrinter) write(message proto.Message) error {
+	body, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(p.Out, string(body))
+	return err
+}
+
+func (p *JSONPrinter) User(user *pbv2.User) error {
+	return p.write(withoutHash(user))
+}
+
+func (p *JSONPrinter) PublicUser(user *pbv2.PublicUser) error {
+	return p.write(user)
+}
+
+func (p *JSONPrinter) BatchResults(response *pbv2.BatchGetPublicUsersResponse) error {
+	return p.write(response)
+}
+
+func (p *JSONPrinter) ErasureReceipt(userID string, receipt *pbv2.EraseUserResponse) error {
+	return p.write(receipt)
+}
+
+// TablePrinter writes one row per result under a header.
+type TablePrinter struct {
+	Out io.Writer
+}
+
+var _ IPrinter = (*TablePrinter)(nil)
+
+func (p *TablePrinter) write(rows [][]string) error {
+	writer := tabwriter.NewWriter(p.Out, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		for i, cell := range row {
+			if i > 0 {
+				fmt.Fprint(writer, "\t")
+			}
+			fmt.Fprint(writer, cell)
+		}
+		fmt.Fprintln(writer)
+	}
+	return writer.Flush()
+}
+
+// formatTimestamp formats a timestamp in UTC, or a dash when it is unset.
+func formatTimestamp(timestamp *timestamppb.Timestamp) string {
+	if timestamp == nil {
+		return "-"
+	}
+	return timestamp.AsTime().UTC().Format(time.RFC3339)
+}
+
+func (p *TablePrinter) User(user *pbv2.User) error {
+	locked := strconv.FormatBool(user.Locked)
+	if user.Locked {
+		locked = "until " + formatTimestamp(user.LockedUntil)
+	}
+	return p.write([][]string{
+		{"ID", "EMAIL", "USERNAME", "LOCKED", "MFA", "CREATED", "UPDATED"},
+		{user.Id, user.Email, user.Username, locked, strconv.FormatBool(user.MfaEnabled), formatTimestamp(user.CreatedAt), formatTimestamp(user.UpdatedAt)},
+	})
+}
+
+func (p *TablePrinter) PublicUser(user *pbv2.PublicUser) error {
+	return p.write([][]string{
+		{"ID", "USERNAME", "CREATED"},
+		{user.Id, user.Username, formatTimestamp(user.CreatedAt)},
+	})
+}
+
+func (p *TablePrinter) BatchResults(response *pbv2.BatchGetPublicUsersResponse) error {
+	rows := [][]string{{"IDENTIFIER", "FOUND", "ID", "USERNAME", "CREATED"}}
+	for _, result := range response.Results {
+		if result.User == nil {
+			rows = append(rows, []string{result.UserIdentifier, "false", "-", "-", "-"})
+			continue
+		}
+		rows = append(rows, []string{result.UserIdentifier, "true", result.User.Id, result.User.Username, formatTimestamp(result.User.CreatedAt)})
+	}
+	return p.write(rows)
+}
+
+func (p *TablePrinter) ErasureReceipt(userID string, receipt *pbv2.EraseUserResponse) error {
+	return p.write([][]string{
+		{"ID", "ERASED", "USERNAME RELEASE", "ERASURE REF"},
+		{userID, formatTimestamp(receipt.ErasedAt), formatTimestamp(receipt.UsernameReleaseAt), receipt.ErasureRef},
+	})
+}
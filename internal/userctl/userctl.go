@@ -0,0 +1,220 @@
+// Package userctl implements the commands of the userctl client, which calls the v2 gRPC API so that
+// on-call engineers can inspect and fix accounts without access to the database.
+package userctl
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	pbv2 "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb/v2"
+	"google.golang.org/grpc/metadata"
+)
+
+// Output formats.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+// PasswordEnv is read by create when the -password flag is not set, keeping the password out of shell history.
+const PasswordEnv = "USERCTL_PASSWORD"
+
+// ErrUsage is returned when the arguments do not match a command.
+var ErrUsage = errors.New("invalid usage")
+
+// Usage describes the commands.
+const Usage = `Commands:
+  get <identifier>                    show an account by id, email or username
+  create -email E -username U [-password P] [-display-name N]
+                                      create an account; the password defaults to $USERCTL_PASSWORD
+  list <identifier>...                show the public profiles of several accounts
+  update <user-id> [-password P] [-unlock] [-disable-mfa]
+                                      reset the password, lift a lockout or disable MFA, then show the account
+  delete <user-id> -confirm           erase an account, keeping a tombstone; this cannot be undone
+`
+
+// WithCredentials returns a context whose calls carry the bearer token and, when set, the tenant.
+func WithCredentials(ctx context.Context, token string, tenant string) context.Context {
+	if token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	if tenant != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-tenant-id", tenant)
+	}
+	return ctx
+}
+
+type CLI struct {
+	Client  pbv2.UserServiceClient
+	Printer IPrinter
+	// Err receives the messages of flag parsing
+	Err io.Writer
+}
+
+func NewCLI(client pbv2.UserServiceClient, printer IPrinter, err io.Writer) *CLI {
+	return &CLI{
+		Client:  client,
+		Printer: printer,
+		Err:     err,
+	}
+}
+
+// Run runs the command named by the first argument.
+func (cli *CLI) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: missing command", ErrUsage)
+	}
+
+	switch args[0] {
+	case "get":
+		return cli.get(ctx, args[1:])
+	case "create":
+		return cli.create(ctx, args[1:])
+	case "list":
+		return cli.list(ctx, args[1:])
+	case "update":
+		return cli.update(ctx, args[1:])
+	case "delete":
+		return cli.delete(ctx, args[1:])
+	}
+	return fmt.Errorf("%w: unknown command %q", ErrUsage, args[0])
+}
+
+// parse parses the flags of a command, which come before its positional arguments,
+// and checks the number of positional arguments is between min and max, or at least min when max is negative.
+func (cli *CLI) parse(flags *flag.FlagSet, args []string, min int, max int) ([]string, error) {
+	flags.SetOutput(cli.Err)
+	if err := flags.Parse(args); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+
+	positional := flags.Args()
+	if len(positional) < min || (max >= 0 && len(positional) > max) {
+		return nil, fmt.Errorf("%w: %s takes %s", ErrUsage, flags.Name(), argumentCount(min, max))
+	}
+	return positional, nil
+}
+
+func argumentCount(min int, max int) string {
+	switch {
+	case max < 0:
+		return fmt.Sprintf("at least %d arguments", min)
+	case max == 0:
+		return "no arguments"
+	case min == max && min == 1:
+		return "one argument"
+	}
+	return fmt.Sprintf("%d to %d arguments", min, max)
+}
+
+func (cli *CLI) get(ctx context.Context, args []string) error {
+	positional, err := cli.parse(flag.NewFlagSet("get", flag.ContinueOnError), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	user, err := cli.Client.GetPrivateUserByIdentifier(ctx, &pbv2.GetPrivateUserByIdentifierRequest{UserIdentifier: positional[0]})
+	if err != nil {
+		return err
+	}
+	return cli.Printer.User(user)
+}
+
+func (cli *CLI) create(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("create", flag.ContinueOnError)
+	email := flags.String("email", "", "email address")
+	username := flags.String("username", "", "username")
+	password := flags.String("password", "", "password, $"+PasswordEnv+" when unset")
+	displayName := flags.String("display-name", "", "display name of the profile")
+	if _, err := cli.parse(flags, args, 0, 0); err != nil {
+		return err
+	}
+	if *password == "" {
+		*password = os.Getenv(PasswordEnv)
+	}
+	if *email == "" || *username == "" || *password == "" {
+		return fmt.Errorf("%w: create needs -email, -username and a password", ErrUsage)
+	}
+
+	request := &pbv2.CreateUserRequest{Email: *email, Username: *username, Password: *password}
+	if *displayName != "" {
+		request.Profile = &pbv2.UserProfile{DisplayName: *displayName}
+	}
+	user, err := cli.Client.CreateUser(ctx, request)
+	if err != nil {
+		return err
+	}
+	return cli.Printer.PublicUser(user)
+}
+
+func (cli *CLI) list(ctx context.Context, args []string) error {
+	positional, err := cli.parse(flag.NewFlagSet("list", flag.ContinueOnError), args, 1, -1)
+	if err != nil {
+		return err
+	}
+
+	response, err := cli.Client.BatchGetPublicUsers(ctx, &pbv2.BatchGetPublicUsersRequest{UserIdentifiers: positional})
+	if err != nil {
+		return err
+	}
+	return cli.Printer.BatchResults(response)
+}
+
+func (cli *CLI) update(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("update", flag.ContinueOnError)
+	password := flags.String("password", "", "set a new password without the current one")
+	unlock := flags.Bool("unlock", false, "lift a lockout after failed sign-ins")
+	disableMFA := flags.Bool("disable-mfa", false, "disable MFA, for users who lost their device")
+	positional, err := cli.parse(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *password == "" && !*unlock && !*disableMFA {
+		return fmt.Errorf("%w: update needs -password, -unlock or -disable-mfa", ErrUsage)
+	}
+
+	userID := positional[0]
+	if *password != "" {
+		if _, err := cli.Client.ResetPassword(ctx, &pbv2.ResetPasswordRequest{UserId: userID, Password: *password}); err != nil {
+			return fmt.Errorf("reset password: %w", err)
+		}
+	}
+	if *unlock {
+		if _, err := cli.Client.UnlockUser(ctx, &pbv2.UnlockUserRequest{UserId: userID}); err != nil {
+			return fmt.Errorf("unlock: %w", err)
+		}
+	}
+	if *disableMFA {
+		if _, err := cli.Client.DisableMFA(ctx, &pbv2.DisableMFARequest{UserId: userID}); err != nil {
+			return fmt.Errorf("disable MFA: %w", err)
+		}
+	}
+
+	user, err := cli.Client.GetPrivateUserByIdentifier(ctx, &pbv2.GetPrivateUserByIdentifierRequest{UserIdentifier: userID})
+	if err != nil {
+		return err
+	}
+	return cli.Printer.User(user)
+}
+
+func (cli *CLI) delete(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("delete", flag.ContinueOnError)
+	confirm := flags.Bool("confirm", false, "confirm the erasure, which cannot be undone")
+	positional, err := cli.parse(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if !*confirm {
+		return fmt.Errorf("%w: delete erases the user and cannot be undone, pass -confirm", ErrUsage)
+	}
+
+	receipt, err := cli.Client.EraseUser(ctx, &pbv2.EraseUserRequest{UserId: positional[0]})
+	if err != nil {
+		return err
+	}
+	return cli.Printer.ErasureReceipt(positional[0], receipt)
+}
@@ -0,0 +1,232 @@
+package userctl_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Bit-Bridge-Source/BitBridge-UserService-Go/internal/userctl"
+	pbv2 "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var createdAt = time.Date(2023, 11, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeUserServer answers with fixed users and records the calls and metadata it received.
+type fakeUserServer struct {
+	pbv2.UnimplementedUserServiceServer
+	calls    []string
+	metadata metadata.MD
+}
+
+func (server *fakeUserServer) record(ctx context.Context, call string) {
+	server.calls = append(server.calls, call)
+	server.metadata, _ = metadata.FromIncomingContext(ctx)
+}
+
+func (server *fakeUserServer) GetPrivateUserByIdentifier(ctx context.Context, req *pbv2.GetPrivateUserByIdentifierRequest) (*pbv2.User, error) {
+	server.record(ctx, "GetPrivateUserByIdentifier "+req.UserIdentifier)
+	return &pbv2.User{Id: "1", Email: "test@mail.com", Username: "test", Hash: "$argon2id$hash", CreatedAt: timestamppb.New(createdAt)}, nil
+}
+
+func (server *fakeUserServer) CreateUser(ctx context.Context, req *pbv2.CreateUserRequest) (*pbv2.PublicUser, error) {
+	server.record(ctx, "CreateUser "+req.Username+" "+req.Password)
+	return &pbv2.PublicUser{Id: "1", Username: req.Username, CreatedAt: timestamppb.New(createdAt)}, nil
+}
+
+func (server *fakeUserServer) BatchGetPublicUsers(ctx context.Context, req *pbv2.BatchGetPublicUsersRequest) (*pbv2.BatchGetPublicUsersResponse, error) {
+	server.record(ctx, "BatchGetPublicUsers "+strings.Join(req.UserIdentifiers, ","))
+	return &pbv2.BatchGetPublicUsersResponse{Results: []*pbv2.BatchGetPublicUsersResult{
+		{UserIdentifier: "test", Found: true, User: &pbv2.PublicUser{Id: "1", Username: "test", CreatedAt: timestamppb.New(createdAt)}},
+		{UserIdentifier: "missing"},
+	}}, nil
+}
+
+func (server *fakeUserServer) ResetPassword(ctx context.Context, req *pbv2.ResetPasswordRequest) (*pbv2.ResetPasswordResponse, error) {
+	server.record(ctx, "ResetPassword "+req.UserId)
+	return &pbv2.ResetPasswordResponse{}, nil
+}
+
+func (server *fakeUserServer) UnlockUser(ctx context.Context, req *pbv2.UnlockUserRequest) (*pbv2.UnlockUserResponse, error) {
+	server.record(ctx, "UnlockUser "+req.UserId)
+	return nil, status.Error(codes.PermissionDenied, "Forbidden")
+}
+
+func (server *fakeUserServer) EraseUser(ctx context.Context, req *pbv2.EraseUserRequest) (*pbv2.EraseUserResponse, error) {
+	server.record(ctx, "EraseUser "+req.UserId)
+	return &pbv2.EraseUserResponse{ErasedAt: timestamppb.New(createdAt), UsernameReleaseAt: timestamppb.New(createdAt), ErasureRef: "ref"}, nil
+}
+
+// newTestCLI returns a CLI connected to fake over an in-memory listener.
+func newTestCLI(t *testing.T, fake *fakeUserServer, format string) (*userctl.CLI, *bytes.Buffer) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pbv2.RegisterUserServiceServer(server, fake)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	out := &bytes.Buffer{}
+	printer, err := userctl.NewPrinter(format, out)
+	require.NoError(t, err)
+	return userctl.NewCLI(pbv2.NewUserServiceClient(conn), printer, &bytes.Buffer{}), out
+}
+
+func TestGet_JSONWithoutHash(t *testing.T) {
+	// Setup
+	fake := &fakeUserServer{}
+	cli, out := newTestCLI(t, fake, userctl.OutputJSON)
+	ctx := userctl.WithCredentials(context.Background(), "token", "acme")
+
+	// Test
+	err := cli.Run(ctx, []string{"get", "test"})
+
+	// Assert
+	assert.NoError(t, err)
+	var user map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &user))
+	assert.Equal(t, "test@mail.com", user["email"])
+	assert.Equal(t, "2023-11-01T12:00:00Z", user["created_at"])
+	assert.NotContains(t, user, "hash")
+	assert.Equal(t, []string{"GetPrivateUserByIdentifier test"}, fake.calls)
+	assert.Equal(t, []string{"Bearer token"}, fake.metadata.Get("authorization"))
+	assert.Equal(t, []string{"acme"}, fake.metadata.Get("x-tenant-id"))
+}
+
+func TestGet_Table(t *testing.T) {
+	// Setup
+	cli, out := newTestCLI(t, &fakeUserServer{}, userctl.OutputTable)
+
+	// Test
+	err := cli.Run(context.Background(), []string{"get", "test"})
+
+	// Assert
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"ID", "EMAIL", "USERNAME", "LOCKED", "MFA", "CREATED", "UPDATED"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"1", "test@mail.com", "test", "false", "false", "2023-11-01T12:00:00Z", "-"}, strings.Fields(lines[1]))
+	assert.NotContains(t, out.String(), "argon2id")
+}
+
+func TestCreate_PasswordFromEnv(t *testing.T) {
+	// Setup
+	fake := &fakeUserServer{}
+	cli, out := newTestCLI(t, fake, userctl.OutputTable)
+	t.Setenv(userctl.PasswordEnv, "secret")
+
+	// Test
+	err := cli.Run(context.Background(), []string{"create", "-email", "new@mail.com", "-username", "new"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CreateUser new secret"}, fake.calls)
+	assert.Contains(t, out.String(), "new")
+}
+
+func TestList_Table(t *testing.T) {
+	// Setup
+	fake := &fakeUserServer{}
+	cli, out := newTestCLI(t, fake, userctl.OutputTable)
+
+	// Test
+	err := cli.Run(context.Background(), []string{"list", "test", "missing"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"BatchGetPublicUsers test,missing"}, fake.calls)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"test", "true", "1", "test", "2023-11-01T12:00:00Z"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"missing", "false", "-", "-", "-"}, strings.Fields(lines[2]))
+}
+
+func TestUpdate_StopsAtFirstError(t *testing.T) {
+	// Setup
+	fake := &fakeUserServer{}
+	cli, out := newTestCLI(t, fake, userctl.OutputTable)
+
+	// Test
+	err := cli.Run(context.Background(), []string{"update", "-password", "new-secret", "-unlock", "1"})
+
+	// Assert
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, []string{"ResetPassword 1", "UnlockUser 1"}, fake.calls)
+	assert.Empty(t, out.String())
+}
+
+func TestDelete_RequiresConfirm(t *testing.T) {
+	// Setup
+	fake := &fakeUserServer{}
+	cli, _ := newTestCLI(t, fake, userctl.OutputTable)
+
+	// Test
+	err := cli.Run(context.Background(), []string{"delete", "1"})
+
+	// Assert
+	assert.ErrorIs(t, err, userctl.ErrUsage)
+	assert.Empty(t, fake.calls)
+}
+
+func TestDelete_Confirmed(t *testing.T) {
+	// Setup
+	fake := &fakeUserServer{}
+	cli, out := newTestCLI(t, fake, userctl.OutputJSON)
+
+	// Test
+	err := cli.Run(context.Background(), []string{"delete", "-confirm", "1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"EraseUser 1"}, fake.calls)
+	var receipt map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &receipt))
+	assert.Equal(t, "ref", receipt["erasure_ref"])
+}
+
+func TestRun_UsageErrors(t *testing.T) {
+	// Setup
+	cli, _ := newTestCLI(t, &fakeUserServer{}, userctl.OutputTable)
+
+	for _, args := range [][]string{
+		nil,
+		{"unknown"},
+		{"get"},
+		{"get", "a", "b"},
+		{"create", "-email", "new@mail.com"},
+		{"update", "1"},
+		{"list", "-unknown-flag", "test"},
+	} {
+		// Test
+		err := cli.Run(context.Background(), args)
+
+		// Assert
+		assert.ErrorIs(t, err, userctl.ErrUsage, "%v", args)
+	}
+}
+
+func TestNewPrinter_UnknownFormat(t *testing.T) {
+	// Test
+	_, err := userctl.NewPrinter("yaml", &bytes.Buffer{})
+
+	// Assert
+	assert.ErrorIs(t, err, userctl.ErrUsage)
+}
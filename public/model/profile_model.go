@@ -0,0 +1,150 @@
+package model
+
+import "github.com/Bit-Bridge-Source/BitBridge-UserService-Go/proto/pb"
+
+// Visibility controls who may see a profile field.
+type Visibility string
+
+const (
+	// VisibilityPublic fields are returned to anyone, including unauthenticated callers.
+	VisibilityPublic Visibility = "public"
+	// VisibilityAuthenticated fields are returned to any authenticated caller.
+	VisibilityAuthenticated Visibility = "authenticated"
+	// VisibilityPrivate fields are returned only to the owner and to privileged callers.
+	VisibilityPrivate Visibility = "private"
+)
+
+// Profile field names, used as keys of ProfileModel.Visibility.
+const (
+	ProfileFieldDisplayName = "display_name"
+	ProfileFieldAvatarURL   = "avatar_url"
+	ProfileFieldBio         = "bio"
+	ProfileFieldLocale      = "locale"
+	ProfileFieldTimezone    = "timezone"
+	ProfileFieldMetadata    = "metadata"
+)
+
+// DefaultProfileVisibility applies to fields without an entry in ProfileModel.Visibility.
+var DefaultProfileVisibility = map[string]Visibility{
+	ProfileFieldDisplayName: VisibilityPublic,
+	ProfileFieldAvatarURL:   VisibilityPublic,
+	ProfileFieldBio:         VisibilityPublic,
+	ProfileFieldLocale:      VisibilityAuthenticated,
+	ProfileFieldTimezone:    VisibilityAuthenticated,
+	ProfileFieldMetadata:    VisibilityPrivate,
+}
+
+var visibilityRank = map[Visibility]int{
+	VisibilityPublic:        0,
+	VisibilityAuthenticated: 1,
+	VisibilityPrivate:       2,
+}
+
+// Valid reports whether v is one of the known visibilities.
+func (v Visibility) Valid() bool {
+	_, ok := visibilityRank[v]
+	return ok
+}
+
+type ProfileModel struct {
+	DisplayName string                `json:"display_name,omitempty" bson:"display_name,omitempty"`
+	AvatarURL   string                `json:"avatar_url,omitempty" bson:"avatar_url,omitempty"`
+	Bio         string                `json:"bio,omitempty" bson:"bio,omitempty"`
+	Locale      string                `json:"locale,omitempty" bson:"locale,omitempty"`
+	Timezone    string                `json:"timezone,omitempty" bson:"timezone,omitempty"`
+	Metadata    map[string]string     `json:"metadata,omitempty" bson:"metadata,omitempty"`
+	Visibility  map[string]Visibility `json:"visibility,omitempty" bson:"visibility,omitempty"`
+}
+
+// FieldVisibility returns the visibility of a field, falling back to DefaultProfileVisibility.
+func (p *ProfileModel) FieldVisibility(field string) Visibility {
+	if visibility, ok := p.Visibility[field]; ok {
+		return visibility
+	}
+	return DefaultProfileVisibility[field]
+}
+
+// VisibleTo returns a copy of the profile holding only the fields the audience may see.
+// The visibility map itself is left out, since it is only meaningful to the owner.
+func (p *ProfileModel) VisibleTo(audience Visibility) *ProfileModel {
+	if p == nil {
+		return nil
+	}
+
+	visible := func(field string) bool {
+		return visibilityRank[p.FieldVisibility(field)] <= visibilityRank[audience]
+	}
+
+	profile := &ProfileModel{}
+	if visible(ProfileFieldDisplayName) {
+		profile.DisplayName = p.DisplayName
+	}
+	if visible(ProfileFieldAvatarURL) {
+		profile.AvatarURL = p.AvatarURL
+	}
+	if visible(ProfileFieldBio) {
+		profile.Bio = p.Bio
+	}
+	if visible(ProfileFieldLocale) {
+		profile.Locale = p.Locale
+	}
+	if visible(ProfileFieldTimezone) {
+		profile.Timezone = p.Timezone
+	}
+	if visible(ProfileFieldMetadata) {
+		profile.Metadata = p.Metadata
+	}
+	if audience == VisibilityPrivate {
+		profile.Visibility = p.Visibility
+	}
+
+	return profile
+}
+
+func (p *ProfileModel) ToUserProfile() *pb.UserProfile {
+	if p == nil {
+		return nil
+	}
+
+	var visibility map[string]string
+	if len(p.Visibility) > 0 {
+		visibility = make(map[string]string, len(p.Visibility))
+		for field, value := range p.Visibility {
+			visibility[field] = string(value)
+		}
+	}
+
+	return &pb.UserProfile{
+		DisplayName: p.DisplayName,
+		AvatarUrl:   p.AvatarURL,
+		Bio:         p.Bio,
+		Locale:      p.Locale,
+		Timezone:    p.Timezone,
+		Metadata:    p.Metadata,
+		Visibility:  visibility,
+	}
+}
+
+func NewProfileModel(userProfile *pb.UserProfile) *ProfileModel {
+	if userProfile == nil {
+		return nil
+	}
+
+	var visibility map[string]Visibility
+	if len(userProfile.Visibility) > 0 {
+		visibility = make(map[string]Visibility, len(userProfile.Visibility))
+		for field, value := range userProfile.Visibility {
+			visibility[field] = Visibility(value)
+		}
+	}
+
+	return &ProfileModel{
+		DisplayName: userProfile.DisplayName,
+		AvatarURL:   userProfile.AvatarUrl,
+		Bio:         userProfile.Bio,
+		Locale:      userProfile.Locale,
+		Timezone:    userProfile.Timezone,
+		Metadata:    userProfile.Metadata,
+		Visibility:  visibility,
+	}
+}
@@ -10,13 +10,130 @@ import (
 type PublicUserModel struct {
 	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	Username  string             `json:"username" bson:"username"`
+	Profile   *ProfileModel      `json:"profile,omitempty" bson:"profile,omitempty"`
 	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// SelfUserModel is the view of an account returned to its owner.
+type SelfUserModel struct {
+	ID         primitive.ObjectID `json:"id"`
+	Email      string             `json:"email"`
+	Username   string             `json:"username"`
+	Profile    *ProfileModel      `json:"profile,omitempty"`
+	MFAEnabled bool               `json:"mfa_enabled"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+}
+
+// AccountUserModel is the view of an account returned to privileged callers, such as services that
+// verify credentials. It is the only view with the password hash and the lockout state.
+type AccountUserModel struct {
+	ID          primitive.ObjectID `json:"id"`
+	Email       string             `json:"email"`
+	Username    string             `json:"username"`
+	Hash        string             `json:"hash"`
+	Profile     *ProfileModel      `json:"profile,omitempty"`
+	Locked      bool               `json:"locked"`
+	LockedUntil *time.Time         `json:"locked_until,omitempty"`
+	MFAEnabled  bool               `json:"mfa_enabled"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// UpdateUserModel holds the fields a user may change on their own account. Nil fields are left unchanged,
+// and a profile replaces the stored profile as a whole.
+// Changing the email or password must be confirmed with the current password.
+type UpdateUserModel struct {
+	Email           *string       `json:"email,omitempty"`
+	Username        *string       `json:"username,omitempty"`
+	Password        *string       `json:"password,omitempty"`
+	Profile         *ProfileModel `json:"profile,omitempty"`
+	CurrentPassword string        `json:"current_password,omitempty"`
+}
+
+// DeleteUserModel confirms the deletion of an account with its password.
+type DeleteUserModel struct {
+	Password string `json:"password"`
+}
+
+// ImportUserModel creates an account from another system with its existing password hash.
+type ImportUserModel struct {
+	Email        string        `json:"email"`
+	Username     string        `json:"username"`
+	PasswordHash string        `json:"password_hash"`
+	Profile      *ProfileModel `json:"profile,omitempty"`
+}
+
+// VerifyCredentialsModel checks a password for the account matching an identifier. SourceIP is the
+// address the end user signs in from, which brute-force protection tracks; it defaults to the caller's.
+type VerifyCredentialsModel struct {
+	Identifier string `json:"identifier"`
+	Password   string `json:"password"`
+	SourceIP   string `json:"source_ip,omitempty"`
+}
+
+// ResetPasswordModel sets a new password for an account without confirming the current one.
+type ResetPasswordModel struct {
+	Password string `json:"password"`
+}
+
+// MFACodeModel carries a TOTP or recovery code, confirming an enrolment or the disabling of MFA.
+type MFACodeModel struct {
+	Code string `json:"code"`
+}
+
+// MFAEnrollmentModel is returned once when TOTP enrolment starts. The secret is shown for manual
+// entry and the URI is rendered as a QR code for authenticator apps.
+type MFAEnrollmentModel struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// MFARecoveryCodesModel returns recovery codes in plaintext, the only time they are shown.
+type MFARecoveryCodesModel struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// VerifyMFAModel checks the second factor of a sign-in. SourceIP is tracked as for VerifyCredentialsModel.
+type VerifyMFAModel struct {
+	Code     string `json:"code"`
+	SourceIP string `json:"source_ip,omitempty"`
+}
+
+// MFAVerificationModel reports how a second factor was verified.
+type MFAVerificationModel struct {
+	Method                 string `json:"method"`
+	RemainingRecoveryCodes int    `json:"remaining_recovery_codes"`
+}
+
+// ErasureReceiptModel confirms the erasure of a user. ErasureRef is kept on the tombstone as well.
+type ErasureReceiptModel struct {
+	UserID            string    `json:"user_id"`
+	ErasedAt          time.Time `json:"erased_at"`
+	UsernameReleaseAt time.Time `json:"username_release_at"`
+	ErasureRef        string    `json:"erasure_ref"`
+}
+
+type BatchIdentifierModel struct {
+	Identifiers []string `json:"identifiers"`
+}
+
+type BatchPublicUserResultModel struct {
+	Identifier string           `json:"identifier"`
+	Found      bool             `json:"found"`
+	User       *PublicUserModel `json:"user,omitempty"`
+}
+
+type BatchPublicUserModel struct {
+	Results []BatchPublicUserResultModel `json:"results"`
 }
 
 type CreateUserModel struct {
-	Email    string `json:"email" bson:"email"`
-	Username string `json:"username" bson:"username"`
-	Password string `json:"password" bson:"password"`
+	Email    string        `json:"email" bson:"email"`
+	Username string        `json:"username" bson:"username"`
+	Password string        `json:"password" bson:"password"`
+	Profile  *ProfileModel `json:"profile,omitempty" bson:"profile,omitempty"`
 }
 
 func (c *CreateUserModel) ToCreateUserRequest() *pb.CreateUserRequest {
@@ -24,5 +141,6 @@ func (c *CreateUserModel) ToCreateUserRequest() *pb.CreateUserRequest {
 		Email:    c.Email,
 		Username: c.Username,
 		Password: c.Password,
+		Profile:  c.Profile.ToUserProfile(),
 	}
 }